@@ -0,0 +1,410 @@
+// Command pingdom manages Pingdom checks from the shell.
+//
+// Usage:
+//
+//	pingdom [-token TOKEN] [-o table|json] COMMAND [ARGS]
+//
+// The API token defaults to the PINGDOM_API_TOKEN environment variable.
+// Commands:
+//
+//	checks list [-tags TAG,...]        list the checks
+//	checks show ID                     show the details of a check
+//	checks create -type TYPE -name NAME -host HOST [-resolution MIN] [-url PATH] [-port PORT] [-tags TAG,...] [-dry-run]
+//	checks delete ID...                delete checks
+//	checks pause [-resume] (-tags TAG,... | ID...)
+//	                                   pause or resume checks
+//	results [-limit N] ID              list the latest results of a check
+//	report sla [-days N] [-maintenance] ID
+//	                                   availability, MTTR and MTBF of a check
+//	probes                             list the active probes
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "pingdom:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// cli holds the client and the output settings shared by the commands.
+type cli struct {
+	client *pingdom.Client
+	out    io.Writer
+	json   bool
+}
+
+func run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("pingdom", flag.ContinueOnError)
+	token := fs.String("token", "", "Pingdom API token, PINGDOM_API_TOKEN by default")
+	baseURL := fs.String("api-url", "", "Pingdom API URL, for testing")
+	format := fs.String("o", "table", "output format, table or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("unknown output format %q", *format)
+	}
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{APIToken: *token, BaseURL: *baseURL})
+	if err != nil {
+		return err
+	}
+	c := &cli{client: client, out: out, json: *format == "json"}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		return errors.New("missing command, one of checks, results, report or probes")
+	}
+	switch args[0] {
+	case "checks":
+		return c.checks(args[1:])
+	case "results":
+		return c.results(args[1:])
+	case "report":
+		return c.report(args[1:])
+	case "probes":
+		return c.probes(args[1:])
+	}
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func (c *cli) checks(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing checks command, one of list, show, create, delete or pause")
+	}
+	switch args[0] {
+	case "list":
+		return c.checksList(args[1:])
+	case "show":
+		return c.checksShow(args[1:])
+	case "create":
+		return c.checksCreate(args[1:])
+	case "delete":
+		return c.checksDelete(args[1:])
+	case "pause":
+		return c.checksPause(args[1:])
+	}
+	return fmt.Errorf("unknown checks command %q", args[0])
+}
+
+func (c *cli) checksList(args []string) error {
+	fs := flag.NewFlagSet("checks list", flag.ContinueOnError)
+	tags := fs.String("tags", "", "list only the checks with any of these comma separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var checks []pingdom.CheckResponse
+	var err error
+	if *tags != "" {
+		checks, err = c.client.Checks.ListByTags(pingdom.SplitTags(*tags)...)
+	} else {
+		checks, err = c.client.Checks.List(map[string]string{"include_tags": "true"})
+	}
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.writeJSON(checks)
+	}
+	return c.writeTable([]string{"ID", "NAME", "TYPE", "HOST", "STATUS", "TAGS"}, len(checks), func(i int) []string {
+		ck := checks[i]
+		return []string{strconv.Itoa(ck.ID), ck.Name, ck.Type.Name, ck.Hostname, ck.Status, strings.Join(ck.TagNames(), ",")}
+	})
+}
+
+func (c *cli) checksShow(args []string) error {
+	ids, err := parseIDs(args, 1)
+	if err != nil {
+		return err
+	}
+	check, err := c.client.Checks.Read(ids[0])
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.writeJSON(check)
+	}
+	rows := [][]string{
+		{"ID", strconv.Itoa(check.ID)},
+		{"Name", check.Name},
+		{"Type", check.Type.Name},
+		{"Host", check.Hostname},
+		{"Status", check.Status},
+		{"Resolution", strconv.Itoa(check.Resolution) + "m"},
+		{"Paused", strconv.FormatBool(check.Paused)},
+		{"Tags", strings.Join(check.TagNames(), ",")},
+	}
+	if check.Type.HTTP != nil {
+		rows = append(rows, []string{"URL", check.Type.HTTP.Url})
+	}
+	return c.writeTable(nil, len(rows), func(i int) []string { return rows[i] })
+}
+
+func (c *cli) checksCreate(args []string) error {
+	fs := flag.NewFlagSet("checks create", flag.ContinueOnError)
+	kind := fs.String("type", pingdom.CheckKindHTTP, "check type, http, ping or tcp")
+	name := fs.String("name", "", "name of the check")
+	host := fs.String("host", "", "host to check")
+	resolution := fs.Int("resolution", 5, "minutes between tests, 1, 5, 15, 30 or 60")
+	url := fs.String("url", "", "path of http checks")
+	port := fs.Int("port", 0, "port of tcp checks, or of http checks on a custom port")
+	tags := fs.String("tags", "", "comma separated tags")
+	dryRun := fs.Bool("dry-run", false, "validate the check against the account without creating it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var check pingdom.Check
+	switch *kind {
+	case pingdom.CheckKindHTTP:
+		check = &pingdom.HttpCheck{Name: *name, Hostname: *host, Resolution: *resolution, Url: *url, Port: *port, Tags: *tags}
+	case pingdom.CheckKindPing:
+		check = &pingdom.PingCheck{Name: *name, Hostname: *host, Resolution: *resolution, Tags: *tags}
+	case pingdom.CheckKindTCP:
+		check = &pingdom.TCPCheck{Name: *name, Hostname: *host, Resolution: *resolution, Port: *port, Tags: *tags}
+	default:
+		return fmt.Errorf("unknown check type %q", *kind)
+	}
+
+	if *dryRun {
+		if err := c.client.Checks.Validate(check); err != nil {
+			return err
+		}
+		if c.json {
+			return c.writeJSON(map[string]interface{}{"valid": true})
+		}
+		fmt.Fprintln(c.out, "check is valid")
+		return nil
+	}
+
+	created, err := c.client.Checks.Create(check)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.writeJSON(created)
+	}
+	fmt.Fprintf(c.out, "created check %d\n", created.ID)
+	return nil
+}
+
+func (c *cli) checksDelete(args []string) error {
+	ids, err := parseIDs(args, -1)
+	if err != nil {
+		return err
+	}
+	err = c.client.Checks.BulkDelete(ids, 0)
+
+	// The checks which didn't fail were deleted.
+	failed := map[int]string{}
+	var bulkErr *pingdom.BulkError
+	if errors.As(err, &bulkErr) {
+		for _, e := range bulkErr.Errors {
+			failed[e.ID] = e.Err.Error()
+		}
+	} else if err != nil {
+		return err
+	}
+	deleted := []int{}
+	for _, id := range ids {
+		if _, ok := failed[id]; !ok {
+			deleted = append(deleted, id)
+		}
+	}
+
+	if c.json {
+		type failure struct {
+			ID    int    `json:"id"`
+			Error string `json:"error"`
+		}
+		failures := []failure{}
+		for _, id := range ids {
+			if msg, ok := failed[id]; ok {
+				failures = append(failures, failure{ID: id, Error: msg})
+			}
+		}
+		if jerr := c.writeJSON(map[string]interface{}{"deleted": deleted, "failed": failures}); err == nil {
+			err = jerr
+		}
+		return err
+	}
+	for _, id := range deleted {
+		fmt.Fprintf(c.out, "deleted check %d\n", id)
+	}
+	return err
+}
+
+func (c *cli) checksPause(args []string) error {
+	fs := flag.NewFlagSet("checks pause", flag.ContinueOnError)
+	resume := fs.Bool("resume", false, "resume the checks instead")
+	tags := fs.String("tags", "", "pause the checks with any of these comma separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var ids []int
+	if *tags != "" {
+		if fs.NArg() > 0 {
+			return errors.New("either -tags or check ids must be given, not both")
+		}
+		checks, err := c.client.Checks.ListByTags(pingdom.SplitTags(*tags)...)
+		if err != nil {
+			return err
+		}
+		for _, ck := range checks {
+			ids = append(ids, ck.ID)
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("no check has any of the tags %s", *tags)
+		}
+	} else {
+		var err error
+		if ids, err = parseIDs(fs.Args(), -1); err != nil {
+			return err
+		}
+	}
+
+	msg, err := c.client.Checks.SetPaused(!*resume, ids...)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.writeJSON(msg)
+	}
+	fmt.Fprintln(c.out, msg.Message)
+	return nil
+}
+
+func (c *cli) results(args []string) error {
+	fs := flag.NewFlagSet("results", flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "number of results")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids, err := parseIDs(fs.Args(), 1)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.client.Results.List(pingdom.ResultsRequest{Id: ids[0], Limit: *limit})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.writeJSON(r.Results)
+	}
+	return c.writeTable([]string{"TIME", "STATUS", "RESPONSE", "PROBE", "DESCRIPTION"}, len(r.Results), func(i int) []string {
+		res := r.Results[i]
+		return []string{
+			res.TestedAt().Format(time.RFC3339),
+			res.Status,
+			strconv.Itoa(res.ResponseTime) + "ms",
+			strconv.Itoa(res.ProbeID),
+			res.StatusDesc,
+		}
+	})
+}
+
+func (c *cli) report(args []string) error {
+	if len(args) == 0 || args[0] != "sla" {
+		return errors.New("missing report, only sla is supported")
+	}
+	fs := flag.NewFlagSet("report sla", flag.ContinueOnError)
+	days := fs.Int("days", 30, "length of the period, ending now")
+	maintenance := fs.Bool("maintenance", false, "leave the maintenance windows out")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	ids, err := parseIDs(fs.Args(), 1)
+	if err != nil {
+		return err
+	}
+
+	to := time.Now()
+	report, err := c.client.Checks.CalculateSLA(ids[0], to.AddDate(0, 0, -*days), to, *maintenance)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.writeJSON(report)
+	}
+	fmt.Fprintln(c.out, report)
+	return nil
+}
+
+func (c *cli) probes(args []string) error {
+	probes, err := c.client.Probes.ListWithOptions(pingdom.ProbeFilter.OnlyActive())
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return c.writeJSON(probes)
+	}
+	return c.writeTable([]string{"ID", "NAME", "LOCATION", "REGION", "IP"}, len(probes), func(i int) []string {
+		p := probes[i]
+		return []string{strconv.Itoa(p.ID), p.Name, p.Location(), p.Region, p.IP}
+	})
+}
+
+// parseIDs parses check ids, exactly n of them or at least one when n is
+// negative.
+func parseIDs(args []string, n int) ([]int, error) {
+	if n >= 0 && len(args) != n || len(args) == 0 {
+		return nil, fmt.Errorf("expected %s, got %d arguments", plural(n), len(args))
+	}
+	ids := make([]int, len(args))
+	for i, a := range args {
+		id, err := strconv.Atoi(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid check id %q", a)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return "a check id"
+	}
+	if n < 0 {
+		return "check ids"
+	}
+	return strconv.Itoa(n) + " check ids"
+}
+
+func (c *cli) writeJSON(v interface{}) error {
+	enc := json.NewEncoder(c.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeTable writes n rows aligned in columns, under header when given.
+func (c *cli) writeTable(header []string, n int, row func(i int) []string) error {
+	tw := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+	for i := 0; i < n; i++ {
+		fmt.Fprintln(tw, strings.Join(row(i), "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdomtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func runCLI(t *testing.T, server *pingdomtest.Server, args ...string) (string, error) {
+	var out bytes.Buffer
+	args = append([]string{"-token", pingdomtest.Token, "-api-url", server.URL}, args...)
+	err := run(args, &out)
+	return out.String(), err
+}
+
+func TestChecksCommands(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+
+	out, err := runCLI(t, server, "checks", "create", "-dry-run", "-type", "http", "-name", "web", "-host", "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "check is valid\n", out)
+	assert.Empty(t, server.Checks(), "dry runs create nothing")
+	_, err = runCLI(t, server, "checks", "create", "-dry-run", "-type", "ping", "-host", "example.com", "-resolution", "2")
+	assert.Error(t, err)
+
+	out, err = runCLI(t, server, "checks", "create", "-type", "http", "-name", "web", "-host", "example.com", "-url", "/health", "-tags", "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "created check 1\n", out)
+	server.AddCheck(pingdom.CheckResponse{Name: "db", Hostname: "db.example.com", Status: "down", Type: pingdom.CheckResponseType{Name: "tcp"}})
+
+	out, err = runCLI(t, server, "checks", "list")
+	assert.NoError(t, err)
+	assert.Equal(t, "ID  NAME  TYPE  HOST            STATUS  TAGS\n"+
+		"1   web   http  example.com     up      prod\n"+
+		"2   db    tcp   db.example.com  down    \n", out)
+
+	out, err = runCLI(t, server, "-o", "json", "checks", "list", "-tags", "prod")
+	assert.NoError(t, err)
+	var checks []pingdom.CheckResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &checks))
+	if assert.Len(t, checks, 1) {
+		assert.Equal(t, "web", checks[0].Name)
+	}
+
+	out, err = runCLI(t, server, "checks", "show", "1")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "URL         /health\n")
+
+	out, err = runCLI(t, server, "checks", "pause", "-tags", "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "Modification of 1 checks was successful!\n", out)
+	assert.True(t, server.Checks()[0].Paused)
+
+	_, err = runCLI(t, server, "checks", "pause", "-resume", "1")
+	assert.NoError(t, err)
+	assert.False(t, server.Checks()[0].Paused)
+
+	out, err = runCLI(t, server, "-o", "json", "checks", "delete", "1", "9")
+	assert.Error(t, err)
+	var deleted struct {
+		Deleted []int
+		Failed  []struct {
+			ID    int
+			Error string
+		}
+	}
+	assert.NoError(t, json.Unmarshal([]byte(out), &deleted))
+	assert.Equal(t, []int{1}, deleted.Deleted)
+	if assert.Len(t, deleted.Failed, 1) {
+		assert.Equal(t, 9, deleted.Failed[0].ID)
+		assert.NotEmpty(t, deleted.Failed[0].Error)
+	}
+
+	out, err = runCLI(t, server, "checks", "delete", "2")
+	assert.NoError(t, err)
+	assert.Equal(t, "deleted check 2\n", out)
+	assert.Empty(t, server.Checks())
+}
+
+func TestRunErrors(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "missing command, one of checks, results, report or probes"},
+		{[]string{"nope"}, `unknown command "nope"`},
+		{[]string{"-o", "xml", "probes"}, `unknown output format "xml"`},
+		{[]string{"checks", "show"}, "expected a check id, got 0 arguments"},
+		{[]string{"checks", "delete", "x"}, `invalid check id "x"`},
+		{[]string{"checks", "create", "-type", "dns"}, `unknown check type "dns"`},
+		{[]string{"checks", "pause", "-tags", "none"}, "no check has any of the tags none"},
+		{[]string{"report", "uptime"}, "missing report, only sla is supported"},
+	}
+	for _, tt := range tests {
+		_, err := runCLI(t, server, tt.args...)
+		assert.EqualError(t, err, tt.want, "%v", tt.args)
+	}
+}
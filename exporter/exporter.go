@@ -0,0 +1,205 @@
+// Package exporter exposes the status of Pingdom checks as Prometheus
+// metrics.
+//
+// The checks are collected periodically through the client and served in the
+// Prometheus text format, so scrapes never wait on the Pingdom API nor use its
+// rate limit:
+//
+//	e := exporter.New(client)
+//	go e.Run(ctx, time.Minute)
+//	http.Handle("/metrics", e)
+//
+// Each check has the labels check_id, check_name, check_type, tags (comma
+// separated) and region (from its probe filters, empty when not filtered).
+// The metrics are:
+//
+//	pingdom_check_up                  1 when the check is up, 0 when down
+//	pingdom_check_paused              1 when the check is paused
+//	pingdom_check_response_time_ms    response time of the last test
+//	pingdom_check_last_test_timestamp_seconds
+//	pingdom_exporter_collections_total, pingdom_exporter_collection_errors_total
+//	pingdom_exporter_last_collection_success, pingdom_exporter_last_collection_timestamp_seconds
+//
+// Checks which are neither up nor down, e.g. paused or not tested yet, have
+// no pingdom_check_up sample.
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Exporter collects the checks of an account and serves them as metrics.
+type Exporter struct {
+	client *pingdom.Client
+	// Tags limits the exported checks to those with any of the tags, all
+	// the checks are exported when empty.  Set it before collecting.
+	Tags []string
+
+	mu          sync.Mutex
+	checks      []pingdom.CheckResponse
+	collections int
+	errors      int
+	lastOK      bool
+	lastTime    time.Time
+}
+
+// New returns an exporter collecting the checks through client.
+func New(client *pingdom.Client) *Exporter {
+	return &Exporter{client: client}
+}
+
+// Collect fetches the checks now.  On error the checks of the previous
+// collection are still served.
+func (e *Exporter) Collect(ctx context.Context) error {
+	var checks []pingdom.CheckResponse
+	var err error
+	if len(e.Tags) > 0 {
+		checks, err = e.client.Checks.ListByTagsWithContext(ctx, e.Tags...)
+	} else {
+		checks, err = e.client.Checks.ListWithContext(ctx, map[string]string{"include_tags": "true"})
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.collections++
+	e.lastTime = time.Now()
+	e.lastOK = err == nil
+	if err != nil {
+		e.errors++
+		return err
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].ID < checks[j].ID })
+	e.checks = checks
+	return nil
+}
+
+// Run collects the checks right away and then every interval until ctx is
+// done.  Errors are counted in the metrics.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		e.Collect(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ServeHTTP writes the metrics of the last collection.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	e.WriteMetrics(w)
+}
+
+// WriteMetrics writes the metrics of the last collection in the Prometheus
+// text format.
+func (e *Exporter) WriteMetrics(w io.Writer) error {
+	e.mu.Lock()
+	checks := e.checks
+	collections, errors, lastOK, lastTime := e.collections, e.errors, e.lastOK, e.lastTime
+	e.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	family := func(name, typ, help string) {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+	sample := func(name, labels string, v float64) {
+		fmt.Fprintf(bw, "%s%s %s\n", name, labels, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+
+	labels := make([]string, len(checks))
+	for i, c := range checks {
+		labels[i] = checkLabels(c)
+	}
+
+	family("pingdom_check_up", "gauge", "Whether the check is up (1) or down (0).")
+	for i, c := range checks {
+		switch c.Status {
+		case "up":
+			sample("pingdom_check_up", labels[i], 1)
+		case "down":
+			sample("pingdom_check_up", labels[i], 0)
+		}
+	}
+	family("pingdom_check_paused", "gauge", "Whether the check is paused.")
+	for i, c := range checks {
+		sample("pingdom_check_paused", labels[i], boolValue(c.Paused))
+	}
+	family("pingdom_check_response_time_ms", "gauge", "Response time of the last test of the check in milliseconds.")
+	for i, c := range checks {
+		if c.LastTestTime != 0 {
+			sample("pingdom_check_response_time_ms", labels[i], float64(c.LastResponseTime))
+		}
+	}
+	family("pingdom_check_last_test_timestamp_seconds", "gauge", "Time of the last test of the check.")
+	for i, c := range checks {
+		if c.LastTestTime != 0 {
+			sample("pingdom_check_last_test_timestamp_seconds", labels[i], float64(c.LastTestTime))
+		}
+	}
+
+	family("pingdom_exporter_collections_total", "counter", "Number of collections of the checks.")
+	sample("pingdom_exporter_collections_total", "", float64(collections))
+	family("pingdom_exporter_collection_errors_total", "counter", "Number of collections which failed.")
+	sample("pingdom_exporter_collection_errors_total", "", float64(errors))
+	family("pingdom_exporter_last_collection_success", "gauge", "Whether the last collection succeeded.")
+	sample("pingdom_exporter_last_collection_success", "", boolValue(lastOK))
+	if !lastTime.IsZero() {
+		family("pingdom_exporter_last_collection_timestamp_seconds", "gauge", "Time of the last collection.")
+		sample("pingdom_exporter_last_collection_timestamp_seconds", "", float64(lastTime.Unix()))
+	}
+	return bw.Flush()
+}
+
+// checkLabels renders the labels of a check.
+func checkLabels(c pingdom.CheckResponse) string {
+	pairs := [][2]string{
+		{"check_id", strconv.Itoa(c.ID)},
+		{"check_name", c.Name},
+		{"check_type", c.Type.Name},
+		{"tags", strings.Join(c.TagNames(), ",")},
+		{"region", region(c.ProbeFilters)},
+	}
+	parts := make([]string, len(pairs))
+	for i, p := range pairs {
+		parts[i] = p[0] + `="` + escapeLabel(p[1]) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// region returns the region of probe filters like "region: EU".
+func region(filters []string) string {
+	for _, f := range filters {
+		if r, ok := pingdom.ParseProbeFilter(f); ok {
+			return string(r)
+		}
+	}
+	return ""
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
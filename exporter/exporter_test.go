@@ -0,0 +1,79 @@
+package exporter
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdomtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExporter(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	server.AddCheck(pingdom.CheckResponse{
+		ID: 1, Name: `web "main"`, Status: "up", LastTestTime: 1600000000, LastResponseTime: 215,
+		Tags: []pingdom.CheckResponseTag{{Name: "prod"}}, ProbeFilters: []string{"region: EU"},
+		Type: pingdom.CheckResponseType{Name: "http"},
+	})
+	server.AddCheck(pingdom.CheckResponse{ID: 2, Name: "db", Status: "down", Type: pingdom.CheckResponseType{Name: "tcp"}})
+	server.AddCheck(pingdom.CheckResponse{ID: 3, Name: "old", Status: "paused", Paused: true, Type: pingdom.CheckResponseType{Name: "ping"}})
+
+	e := New(client)
+	assert.NoError(t, e.Collect(context.Background()))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+
+	web := `{check_id="1",check_name="web \"main\"",check_type="http",tags="prod",region="EU"}`
+	db := `{check_id="2",check_name="db",check_type="tcp",tags="",region=""}`
+	old := `{check_id="3",check_name="old",check_type="ping",tags="",region=""}`
+	for _, line := range []string{
+		"# TYPE pingdom_check_up gauge",
+		"pingdom_check_up" + web + " 1",
+		"pingdom_check_up" + db + " 0",
+		"pingdom_check_paused" + old + " 1",
+		"pingdom_check_response_time_ms" + web + " 215",
+		"pingdom_check_last_test_timestamp_seconds" + web + " 1.6e+09",
+		"pingdom_exporter_collections_total 1",
+		"pingdom_exporter_collection_errors_total 0",
+		"pingdom_exporter_last_collection_success 1",
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	assert.NotContains(t, body, "pingdom_check_up"+old)
+	assert.NotContains(t, body, "pingdom_check_response_time_ms"+db)
+}
+
+func TestExporterTagsAndErrors(t *testing.T) {
+	server := pingdomtest.NewServer()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	server.AddCheck(pingdom.CheckResponse{ID: 1, Name: "web", Status: "up", Tags: []pingdom.CheckResponseTag{{Name: "prod"}}})
+	server.AddCheck(pingdom.CheckResponse{ID: 2, Name: "staging", Status: "up"})
+
+	e := New(client)
+	e.Tags = []string{"prod"}
+	assert.NoError(t, e.Collect(context.Background()))
+
+	server.Close()
+	assert.Error(t, e.Collect(context.Background()))
+
+	var b strings.Builder
+	assert.NoError(t, e.WriteMetrics(&b))
+	body := b.String()
+	assert.Contains(t, body, `check_name="web"`)
+	assert.NotContains(t, body, `check_name="staging"`)
+	assert.Contains(t, body, "pingdom_exporter_collections_total 2\n")
+	assert.Contains(t, body, "pingdom_exporter_collection_errors_total 1\n")
+	assert.Contains(t, body, "pingdom_exporter_last_collection_success 0\n")
+}
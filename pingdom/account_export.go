@@ -0,0 +1,263 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// AccountExportVersion is the version of the AccountExport document written
+// by this package.
+const AccountExportVersion = 1
+
+// AccountExport is a portable document holding the checks of an account
+// along with the contacts and teams they alert, see Client.ExportAll.  Ids
+// are those of the exported account, they are remapped on import.
+type AccountExport struct {
+	Version  int               `json:"version"`
+	Exported time.Time         `json:"exported"`
+	Contacts []ContactResponse `json:"contacts"`
+	Teams    []TeamResponse    `json:"teams"`
+	Checks   []ExportedCheck   `json:"checks"`
+	// Skipped describes the checks which could not be exported.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// ExportedCheck is the definition of an exported check, only the field of
+// its type is set.
+type ExportedCheck struct {
+	ID   int        `json:"id"`
+	HTTP *HttpCheck `json:"http,omitempty"`
+	Ping *PingCheck `json:"ping,omitempty"`
+	TCP  *TCPCheck  `json:"tcp,omitempty"`
+}
+
+// Config returns the definition of the check, nil when none is set.
+func (c ExportedCheck) Config() CheckConfig {
+	switch {
+	case c.HTTP != nil:
+		return c.HTTP
+	case c.Ping != nil:
+		return c.Ping
+	case c.TCP != nil:
+		return c.TCP
+	}
+	return nil
+}
+
+// IDMapping maps the ids of an imported account to the ids of the objects
+// created, or reused, in the target account.
+type IDMapping struct {
+	Checks   map[int]int
+	Contacts map[int]int
+	Teams    map[int]int
+	// Warnings describes what could not be imported as is, e.g. dropped
+	// integrations.
+	Warnings []string
+}
+
+// WriteJSON writes the export as indented JSON.
+func (e *AccountExport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e)
+}
+
+// ReadAccountExport reads an export written by WriteJSON.
+func ReadAccountExport(r io.Reader) (*AccountExport, error) {
+	e := &AccountExport{}
+	if err := json.NewDecoder(r).Decode(e); err != nil {
+		return nil, err
+	}
+	if e.Version != AccountExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d, expected %d", e.Version, AccountExportVersion)
+	}
+	return e, nil
+}
+
+// ExportAll exports the uptime checks of the account, with their tags,
+// contacts and teams.  Each check is read individually to get its details.
+// Checks which CheckResponse.Config can't convert are skipped and
+// reported in Skipped.
+func (pc *Client) ExportAll() (*AccountExport, error) {
+	inv, err := pc.loadAuditInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &AccountExport{
+		Version:  AccountExportVersion,
+		Exported: time.Now().UTC(),
+		Contacts: inv.contacts,
+		Teams:    inv.teams,
+		Checks:   []ExportedCheck{},
+	}
+	for _, check := range inv.checks {
+		config, err := check.Config()
+		if err != nil {
+			e.Skipped = append(e.Skipped, err.Error())
+			continue
+		}
+		exported := ExportedCheck{ID: check.ID}
+		switch ck := config.(type) {
+		case *HttpCheck:
+			exported.HTTP = ck
+		case *PingCheck:
+			exported.Ping = ck
+		case *TCPCheck:
+			exported.TCP = ck
+		}
+		e.Checks = append(e.Checks, exported)
+	}
+	return e, nil
+}
+
+// ImportAll recreates an export in the account of the client.  Contacts and
+// teams are matched by name with the existing ones and created when
+// missing, checks are always created.  The contacts and teams alerted by the
+// checks are remapped to the new ids.  Integrations can't be listed through
+// the API, so they are dropped with a warning.
+//
+// Importing stops at the first error, the mapping of what was imported so far
+// is returned along with it.
+func (pc *Client) ImportAll(e *AccountExport) (*IDMapping, error) {
+	m := &IDMapping{Checks: map[int]int{}, Contacts: map[int]int{}, Teams: map[int]int{}}
+
+	if err := pc.importContacts(e.Contacts, m); err != nil {
+		return m, err
+	}
+	if err := pc.importTeams(e.Teams, m); err != nil {
+		return m, err
+	}
+
+	for _, exported := range e.Checks {
+		config := exported.Config()
+		if config == nil {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("check %d: no definition", exported.ID))
+			continue
+		}
+		if len(checkIntegrationIds(config)) > 0 {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("check %q: integrations dropped", config.CheckName()))
+		}
+		check := remapCheck(exported, m)
+
+		created, err := pc.Checks.Create(check)
+		if err != nil {
+			return m, fmt.Errorf("check %q: %v", config.CheckName(), err)
+		}
+		m.Checks[exported.ID] = created.ID
+	}
+	return m, nil
+}
+
+func (pc *Client) importContacts(contacts []ContactResponse, m *IDMapping) error {
+	existing, err := pc.Contacts.List()
+	if err != nil {
+		return err
+	}
+	byName := map[string]int{}
+	for _, c := range existing {
+		byName[c.Name] = c.ID
+	}
+
+	for _, c := range contacts {
+		if id, ok := byName[c.Name]; ok {
+			m.Contacts[c.ID] = id
+			continue
+		}
+		contact := &Contact{Name: c.Name, Paused: c.Paused}
+		for _, t := range c.NotificationTargets.Email {
+			contact.NotificationTargets.Email = append(contact.NotificationTargets.Email, EmailTarget{Severity: t.Severity, Address: t.Address})
+		}
+		for _, t := range c.NotificationTargets.SMS {
+			contact.NotificationTargets.SMS = append(contact.NotificationTargets.SMS, SMSTarget{Severity: t.Severity, CountryCode: t.CountryCode, Number: t.Number, Provider: t.Provider})
+		}
+		if contact.Valid() != nil {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("contact %q: no email or SMS target, skipped", c.Name))
+			continue
+		}
+
+		created, err := pc.Contacts.Create(contact)
+		if err != nil {
+			return fmt.Errorf("contact %q: %v", c.Name, err)
+		}
+		m.Contacts[c.ID] = created.ID
+	}
+	return nil
+}
+
+func (pc *Client) importTeams(teams []TeamResponse, m *IDMapping) error {
+	existing, err := pc.Teams.List()
+	if err != nil {
+		return err
+	}
+	byName := map[string]int{}
+	for _, t := range existing {
+		byName[t.Name] = t.ID
+	}
+
+	for _, t := range teams {
+		if id, ok := byName[t.Name]; ok {
+			m.Teams[t.ID] = id
+			continue
+		}
+		team := &Team{Name: t.Name, MemberIDs: []int{}}
+		for _, member := range t.Members {
+			if id, ok := m.Contacts[member.ID]; ok {
+				team.MemberIDs = append(team.MemberIDs, id)
+			}
+		}
+		sort.Ints(team.MemberIDs)
+
+		created, err := pc.Teams.Create(team)
+		if err != nil {
+			return fmt.Errorf("team %q: %v", t.Name, err)
+		}
+		m.Teams[t.ID] = created.ID
+	}
+	return nil
+}
+
+// remapCheck returns a copy of the exported check alerting the imported
+// contacts and teams, without integrations.
+func remapCheck(exported ExportedCheck, m *IDMapping) CheckConfig {
+	switch {
+	case exported.HTTP != nil:
+		ck := *exported.HTTP
+		ck.UserIds, ck.TeamIds, ck.IntegrationIds = remapIDs(ck.UserIds, m.Contacts), remapIDs(ck.TeamIds, m.Teams), nil
+		return &ck
+	case exported.Ping != nil:
+		ck := *exported.Ping
+		ck.UserIds, ck.TeamIds, ck.IntegrationIds = remapIDs(ck.UserIds, m.Contacts), remapIDs(ck.TeamIds, m.Teams), nil
+		return &ck
+	default:
+		ck := *exported.TCP
+		ck.UserIds, ck.TeamIds, ck.IntegrationIds = remapIDs(ck.UserIds, m.Contacts), remapIDs(ck.TeamIds, m.Teams), nil
+		return &ck
+	}
+}
+
+// remapIDs maps ids, dropping those without a mapping.
+func remapIDs(ids []int, mapping map[int]int) []int {
+	var mapped []int
+	for _, id := range ids {
+		if to, ok := mapping[id]; ok {
+			mapped = append(mapped, to)
+		}
+	}
+	return mapped
+}
+
+func checkIntegrationIds(c CheckConfig) []int {
+	switch ck := c.(type) {
+	case *HttpCheck:
+		return ck.IntegrationIds
+	case *PingCheck:
+		return ck.IntegrationIds
+	case *TCPCheck:
+		return ck.IntegrationIds
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package pingdom
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientExportAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "name": "John Doe", "notification_targets": {"email": [{"severity": "HIGH", "address": "john@example.com"}]}}]}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"teams": [{"id": 7, "name": "Ops", "members": [{"id": 1}]}]}`)
+	})
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks": [{"id": 100}, {"id": 200}]}`)
+	})
+	mux.HandleFunc("/checks/100", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 100, "name": "web", "hostname": "example.com", "resolution": 5,
+			"userids": [1], "teams": [{"id": 7, "name": "Ops"}], "tags": [{"name": "prod"}],
+			"type": {"http": {"url": "/health", "encryption": true}}}}`)
+	})
+	mux.HandleFunc("/checks/200", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 200, "name": "dns", "type": "dns"}}`)
+	})
+
+	e, err := client.ExportAll()
+	assert.NoError(t, err)
+	assert.Equal(t, AccountExportVersion, e.Version)
+	assert.Len(t, e.Contacts, 1)
+	assert.Len(t, e.Teams, 1)
+	if assert.Len(t, e.Checks, 1) {
+		assert.Equal(t, 100, e.Checks[0].ID)
+		ck := e.Checks[0].HTTP
+		assert.Equal(t, "web", ck.Name)
+		assert.Equal(t, "prod", ck.Tags)
+		assert.Equal(t, []int{1}, ck.UserIds)
+		assert.Equal(t, []int{7}, ck.TeamIds)
+		assert.Equal(t, "/health", ck.Url)
+	}
+	assert.Equal(t, []string{`check 200 has type "dns", whose details are not returned by Pingdom`}, e.Skipped)
+
+	var buf bytes.Buffer
+	assert.NoError(t, e.WriteJSON(&buf))
+	read, err := ReadAccountExport(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, e.Checks, read.Checks)
+	assert.True(t, e.Exported.Equal(read.Exported))
+
+	_, err = ReadAccountExport(strings.NewReader(`{"version": 2}`))
+	assert.EqualError(t, err, "unsupported export version 2, expected 1")
+}
+
+func TestClientImportAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name": "Jane Doe", "paused": false, "notification_targets": {"sms": [{"severity": "LOW", "country_code": "46", "number": "5551234"}]}}`, string(body))
+			fmt.Fprint(w, `{"contact": {"id": 31}}`)
+			return
+		}
+		fmt.Fprint(w, `{"contacts": [{"id": 11, "name": "John Doe"}]}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name": "Ops", "member_ids": [11, 31]}`, string(body))
+			fmt.Fprint(w, `{"team": {"id": 37}}`)
+			return
+		}
+		fmt.Fprint(w, `{"teams": []}`)
+	})
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		assert.Equal(t, "web", r.FormValue("name"))
+		assert.Equal(t, "11", r.FormValue("userids"))
+		assert.Equal(t, "37", r.FormValue("teamids"))
+		assert.Empty(t, r.FormValue("integrationids"))
+		fmt.Fprint(w, `{"check": {"id": 300, "name": "web"}}`)
+	})
+
+	e := &AccountExport{
+		Version: AccountExportVersion,
+		Contacts: []ContactResponse{
+			{ID: 1, Name: "John Doe"},
+			{ID: 2, Name: "Jane Doe", NotificationTargets: ContactNotificationTargets{
+				SMS: []UserSmsResponse{{Severity: "LOW", CountryCode: "46", Number: "5551234"}},
+			}},
+			{ID: 3, Name: "App only"},
+		},
+		Teams: []TeamResponse{{ID: 7, Name: "Ops", Members: []TeamMemberResponse{{ID: 2}, {ID: 1}, {ID: 3}}}},
+		Checks: []ExportedCheck{{ID: 100, HTTP: &HttpCheck{
+			Name: "web", Hostname: "example.com", Resolution: 5,
+			UserIds: []int{1, 3}, TeamIds: []int{7}, IntegrationIds: []int{55},
+		}}},
+	}
+
+	m, err := client.ImportAll(e)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{1: 11, 2: 31}, m.Contacts)
+	assert.Equal(t, map[int]int{7: 37}, m.Teams)
+	assert.Equal(t, map[int]int{100: 300}, m.Checks)
+	assert.Equal(t, []string{
+		`contact "App only": no email or SMS target, skipped`,
+		`check "web": integrations dropped`,
+	}, m.Warnings)
+	assert.Equal(t, []int{55}, e.Checks[0].HTTP.IntegrationIds, "the export is not modified")
+}
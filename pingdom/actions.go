@@ -0,0 +1,258 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ActionsService provides an interface to the Pingdom alert history.
+type ActionsService struct {
+	client *Client
+}
+
+// ActionsRequest is the API request to Pingdom for the alerts sent.
+type ActionsRequest struct {
+	// From and To bound the alerts in Unix time.
+	From int64
+	To   int64
+	// Limit is at most 300, Pingdom defaults to 100.
+	Limit  int
+	Offset int
+	// CheckIds and ContactIds only return the alerts of the given checks
+	// and sent to the given contacts.
+	CheckIds   []int
+	ContactIds []int
+	// Status only returns the alerts with any of the given statuses: "sent",
+	// "delivered", "error", "not_delivered" or "no_credits".
+	Status []string
+	// Via only returns the alerts sent through any of the given channels:
+	// "email", "sms", "twitter", "iphone" or "android".
+	Via []string
+	// Severity only returns the alerts sent to notification targets of any
+	// of the given severities, SeverityHigh or SeverityLow.  Pingdom can't
+	// filter on severity, so the alerts of the page are filtered by the
+	// client and a page may hold fewer than Limit alerts.  Implies
+	// IncludeSeverity.
+	Severity []string
+	// IncludeSeverity fills in the Severity of the alerts from the
+	// notification targets of their contacts, which takes a request
+	// listing the contacts.
+	IncludeSeverity bool
+}
+
+// Valid determines whether an ActionsRequest contains valid fields for the Pingdom API.
+func (ar ActionsRequest) Valid() error {
+	if ar.From != 0 && ar.To != 0 && ar.From > ar.To {
+		return ErrBadTimeRange
+	}
+
+	if ar.Limit < 0 || ar.Limit > 300 {
+		return fmt.Errorf("Invalid value for `Limit`.  Must be between 0 and 300")
+	}
+
+	for _, s := range ar.Status {
+		switch s {
+		case "sent", "delivered", "error", "not_delivered", "no_credits":
+		default:
+			return fmt.Errorf("Invalid value %q for `Status`.  Must be one of sent, delivered, error, not_delivered or no_credits", s)
+		}
+	}
+
+	for _, v := range ar.Via {
+		switch v {
+		case "email", "sms", "twitter", "iphone", "android":
+		default:
+			return fmt.Errorf("Invalid value %q for `Via`.  Must be one of email, sms, twitter, iphone or android", v)
+		}
+	}
+
+	for _, s := range ar.Severity {
+		if s != SeverityHigh && s != SeverityLow {
+			return fmt.Errorf("Invalid value %q for `Severity`.  Must be one of %s or %s", s, SeverityHigh, SeverityLow)
+		}
+	}
+	return nil
+}
+
+// GetParams returns the query params for a Pingdom ActionsRequest.
+func (ar ActionsRequest) GetParams() (params url.Values) {
+	params = url.Values{}
+
+	if ar.From != 0 {
+		params.Set("from", strconv.FormatInt(ar.From, 10))
+	}
+
+	if ar.To != 0 {
+		params.Set("to", strconv.FormatInt(ar.To, 10))
+	}
+
+	if ar.Limit != 0 {
+		params.Set("limit", strconv.Itoa(ar.Limit))
+	}
+
+	if ar.Offset != 0 {
+		params.Set("offset", strconv.Itoa(ar.Offset))
+	}
+
+	if len(ar.CheckIds) > 0 {
+		params.Set("checkids", intListToCDString(ar.CheckIds))
+	}
+
+	if len(ar.ContactIds) > 0 {
+		params.Set("contactids", intListToCDString(ar.ContactIds))
+	}
+
+	if len(ar.Status) > 0 {
+		params.Set("status", strings.Join(ar.Status, ","))
+	}
+
+	if len(ar.Via) > 0 {
+		params.Set("via", strings.Join(ar.Via, ","))
+	}
+
+	return
+}
+
+// List returns the alerts sent by Pingdom, most recent first.
+func (as *ActionsService) List(request ActionsRequest) ([]AlertEntry, error) {
+	return as.ListWithContext(context.Background(), request)
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (as *ActionsService) ListWithContext(ctx context.Context, request ActionsRequest) ([]AlertEntry, error) {
+	l, err := as.ListWithMetaWithContext(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return l.Alerts, nil
+}
+
+// ListWithMeta returns a page of alerts along with the paging parameters,
+// see List.
+func (as *ActionsService) ListWithMeta(request ActionsRequest) (*ActionsList, error) {
+	return as.ListWithMetaWithContext(context.Background(), request)
+}
+
+// ListWithMetaWithContext is like ListWithMeta, the request is bound to ctx
+// so it can be canceled or given a deadline.
+func (as *ActionsService) ListWithMetaWithContext(ctx context.Context, request ActionsRequest) (*ActionsList, error) {
+	alerts, resp, err := as.fetchPage(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if alerts, err = as.withSeverity(ctx, request, alerts); err != nil {
+		return nil, err
+	}
+	return &ActionsList{
+		Alerts: alerts,
+		Meta: ListMeta{
+			Total:     len(alerts),
+			Limit:     request.Limit,
+			Offset:    request.Offset,
+			RateLimit: ResponseRateLimit(resp),
+		},
+	}, nil
+}
+
+// fetchPage returns a page of alerts as sent by Pingdom, before the severity
+// filter of the request is applied.
+func (as *ActionsService) fetchPage(ctx context.Context, request ActionsRequest) ([]AlertEntry, *http.Response, error) {
+	if err := request.Valid(); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := as.client.NewRequestWithValues("GET", "/actions", request.GetParams())
+	if err != nil {
+		return nil, nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &listActionsJSONResponse{}
+	resp, err := as.client.Do(req, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m.Actions.Alerts, resp, nil
+}
+
+// withSeverity fills in the severity of the alerts and filters them on it,
+// as asked by the request.
+func (as *ActionsService) withSeverity(ctx context.Context, request ActionsRequest, alerts []AlertEntry) ([]AlertEntry, error) {
+	if !request.IncludeSeverity && len(request.Severity) == 0 {
+		return alerts, nil
+	}
+	if err := as.fillSeverity(ctx, alerts); err != nil {
+		return nil, err
+	}
+	if len(request.Severity) > 0 {
+		alerts = filterSeverity(alerts, request.Severity)
+	}
+	return alerts, nil
+}
+
+// fillSeverity sets the Severity of the alerts to the one of the email or
+// SMS notification target of their contact they were sent to.  Alerts sent
+// through other channels have no severity.
+func (as *ActionsService) fillSeverity(ctx context.Context, alerts []AlertEntry) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	contacts, err := as.client.Contacts.ListWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	targets := map[int]ContactNotificationTargets{}
+	for _, c := range contacts {
+		targets[c.ID] = c.NotificationTargets
+	}
+
+	for i := range alerts {
+		a := &alerts[i]
+		t := targets[a.ContactID]
+		switch a.Via {
+		case "email":
+			for _, e := range t.Email {
+				if strings.EqualFold(strings.TrimSpace(e.Address), strings.TrimSpace(a.SentTo)) {
+					a.Severity = e.Severity
+				}
+			}
+		case "sms":
+			sentTo := digits(a.SentTo)
+			for _, sms := range t.SMS {
+				if n := digits(sms.Number); n != "" && strings.HasSuffix(sentTo, n) {
+					a.Severity = sms.Severity
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// filterSeverity keeps the alerts of any of the given severities.
+func filterSeverity(alerts []AlertEntry, severities []string) []AlertEntry {
+	var kept []AlertEntry
+	for _, a := range alerts {
+		for _, s := range severities {
+			if a.Severity == s {
+				kept = append(kept, a)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// digits returns the digits of a phone number.
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, s)
+}
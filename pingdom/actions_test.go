@@ -0,0 +1,194 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionsRequestValid(t *testing.T) {
+	assert.NoError(t, ActionsRequest{}.Valid())
+	assert.NoError(t, ActionsRequest{From: 10, To: 20, Limit: 300, Status: []string{"sent", "no_credits"}, Via: []string{"sms"}}.Valid())
+	assert.Equal(t, ErrBadTimeRange, ActionsRequest{From: 20, To: 10}.Valid())
+	assert.Error(t, ActionsRequest{Limit: 301}.Valid())
+	assert.Error(t, ActionsRequest{Status: []string{"lost"}}.Valid())
+	assert.Error(t, ActionsRequest{Via: []string{"pager"}}.Valid())
+}
+
+func TestActionsRequestGetParams(t *testing.T) {
+	assert.Equal(t, url.Values{}, ActionsRequest{}.GetParams())
+
+	want := url.Values{
+		"from":       {"10"},
+		"to":         {"20"},
+		"limit":      {"50"},
+		"offset":     {"100"},
+		"checkids":   {"1,2"},
+		"contactids": {"3"},
+		"status":     {"sent,delivered"},
+		"via":        {"email,sms"},
+	}
+	params := ActionsRequest{
+		From:       10,
+		To:         20,
+		Limit:      50,
+		Offset:     100,
+		CheckIds:   []int{1, 2},
+		ContactIds: []int{3},
+		Status:     []string{"sent", "delivered"},
+		Via:        []string{"email", "sms"},
+	}.GetParams()
+	assert.Equal(t, want, params)
+}
+
+func TestActionsServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/actions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "12345", r.URL.Query().Get("checkids"))
+		fmt.Fprint(w, `{
+			"actions": {
+				"alerts": [
+					{
+						"contactname": "Jane Doe",
+						"contactid": 111250,
+						"checkid": 12345,
+						"time": 1600000000,
+						"via": "email",
+						"status": "sent",
+						"messageshort": "down",
+						"messagefull": "example.com is down",
+						"sentto": "jane@example.com",
+						"charged": false
+					}
+				]
+			}
+		}`)
+	})
+
+	want := []AlertEntry{
+		{
+			ContactName:  "Jane Doe",
+			ContactID:    111250,
+			CheckID:      12345,
+			Time:         1600000000,
+			Via:          "email",
+			Status:       "sent",
+			MessageShort: "down",
+			MessageFull:  "example.com is down",
+			SentTo:       "jane@example.com",
+		},
+	}
+
+	alerts, err := client.Actions.List(ActionsRequest{CheckIds: []int{12345}})
+	assert.NoError(t, err)
+	assert.Equal(t, want, alerts)
+}
+
+func TestActionsServiceListWithMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/actions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		assert.Equal(t, "4", r.URL.Query().Get("offset"))
+		w.Header().Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+		fmt.Fprint(w, `{"actions": {"alerts": [{"checkid": 1}, {"checkid": 2}]}}`)
+	})
+
+	l, err := client.Actions.ListWithMeta(ActionsRequest{Limit: 2, Offset: 4})
+	assert.NoError(t, err)
+	assert.Len(t, l.Alerts, 2)
+	assert.Equal(t, 2, l.Meta.Total)
+	assert.Equal(t, 2, l.Meta.Limit)
+	assert.Equal(t, 4, l.Meta.Offset)
+	assert.NotNil(t, l.Meta.RateLimit)
+
+	_, err = client.Actions.ListWithMeta(ActionsRequest{Limit: 301})
+	assert.Error(t, err)
+}
+
+func TestActionsServiceListSeverity(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/actions", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get("severity"), "Pingdom can't filter on severity")
+		fmt.Fprint(w, `{"actions": {"alerts": [
+			{"contactid": 1, "via": "email", "sentto": "Jane@example.com"},
+			{"contactid": 1, "via": "sms", "sentto": "+46 555 1234"},
+			{"contactid": 1, "via": "iphone", "sentto": "Jane's phone"},
+			{"contactid": 2, "via": "email", "sentto": "john@example.com"}
+		]}}`)
+	})
+	contactLists := 0
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		contactLists++
+		fmt.Fprint(w, `{"contacts": [
+			{"id": 1, "notification_targets": {
+				"email": [{"severity": "HIGH", "address": "jane@example.com"}],
+				"sms": [{"severity": "LOW", "country_code": "46", "number": "5551234"}]}},
+			{"id": 2, "notification_targets": {
+				"email": [{"severity": "LOW", "address": "john@example.com"}]}}
+		]}`)
+	})
+
+	alerts, err := client.Actions.List(ActionsRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, alerts[0].Severity)
+	assert.Equal(t, 0, contactLists)
+
+	alerts, err = client.Actions.List(ActionsRequest{IncludeSeverity: true})
+	assert.NoError(t, err)
+	var severities []string
+	for _, a := range alerts {
+		severities = append(severities, a.Severity)
+	}
+	assert.Equal(t, []string{SeverityHigh, SeverityLow, "", SeverityLow}, severities)
+
+	alerts, err = client.Actions.List(ActionsRequest{Severity: []string{SeverityLow}})
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, "sms", alerts[0].Via)
+	assert.Equal(t, 2, alerts[1].ContactID)
+
+	_, err = client.Actions.List(ActionsRequest{Severity: []string{"urgent"}})
+	assert.Error(t, err)
+}
+
+func TestActionsServiceListAllSeverity(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/actions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"actions": {"alerts": [
+				{"checkid": 1, "contactid": 1, "via": "email", "sentto": "low@example.com"},
+				{"checkid": 2, "contactid": 1, "via": "iphone"}
+			]}}`)
+		case "2":
+			fmt.Fprint(w, `{"actions": {"alerts": [
+				{"checkid": 3, "contactid": 1, "via": "email", "sentto": "high@example.com"}
+			]}}`)
+		default:
+			t.Errorf("unexpected offset %s", r.URL.Query().Get("offset"))
+		}
+	})
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "notification_targets": {"email": [
+			{"severity": "LOW", "address": "low@example.com"},
+			{"severity": "HIGH", "address": "high@example.com"}]}}]}`)
+	})
+
+	alerts, err := client.Actions.ListAll(ActionsRequest{Limit: 2, Severity: []string{SeverityHigh}})
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 1, "a page emptied by the filter doesn't stop the walk")
+	assert.Equal(t, 3, alerts[0].CheckID)
+}
@@ -0,0 +1,60 @@
+package pingdom
+
+import (
+	"context"
+	"strconv"
+)
+
+// AnalysisService provides an interface to the Pingdom root cause analyses.
+type AnalysisService struct {
+	client *Client
+}
+
+// List returns the root cause analyses made for a check.  Params such as
+// from, to, limit and offset can be used to filter the analyses.
+func (as *AnalysisService) List(checkID int, params ...map[string]string) ([]AnalysisResponse, error) {
+	return as.ListWithContext(context.Background(), checkID, params...)
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (as *AnalysisService) ListWithContext(ctx context.Context, checkID int, params ...map[string]string) ([]AnalysisResponse, error) {
+	param := map[string]string{}
+	if len(params) == 1 {
+		param = params[0]
+	}
+	req, err := as.client.NewRequest("GET", "/analysis/"+strconv.Itoa(checkID), param)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &listAnalysisJSONResponse{}
+	_, err = as.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Analysis, nil
+}
+
+// Read returns the raw root cause analysis of a check, as listed by List.
+func (as *AnalysisService) Read(checkID, analysisID int) (*AnalysisDetails, error) {
+	return as.ReadWithContext(context.Background(), checkID, analysisID)
+}
+
+// ReadWithContext is like Read, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (as *AnalysisService) ReadWithContext(ctx context.Context, checkID, analysisID int) (*AnalysisDetails, error) {
+	req, err := as.client.NewRequest("GET", "/analysis/"+strconv.Itoa(checkID)+"/"+strconv.Itoa(analysisID), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &AnalysisDetails{}
+	_, err = as.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
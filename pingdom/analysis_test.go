@@ -0,0 +1,50 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalysisServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analysis/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `{
+			"analysis": [
+				{"id": 4, "timefirsttest": 1284051910, "timeconfirmtest": 1284051920},
+				{"id": 5, "timefirsttest": 1284052910, "timeconfirmtest": 1284052920}
+			]
+		}`)
+	})
+
+	want := []AnalysisResponse{
+		{ID: 4, TimeFirstTest: 1284051910, TimeConfirmTest: 1284051920},
+		{ID: 5, TimeFirstTest: 1284052910, TimeConfirmTest: 1284052920},
+	}
+
+	analyses, err := client.Analysis.List(12345, map[string]string{"limit": "10"})
+	assert.NoError(t, err)
+	assert.Equal(t, want, analyses)
+}
+
+func TestAnalysisServiceRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	body := `{"id":4,"timefirsttest":1284051910,"timeconfirmtest":1284051920,"tasks":[{"probeid":33,"result":"timeout"}]}`
+	mux.HandleFunc("/analysis/12345/4", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, body)
+	})
+
+	analysis, err := client.Analysis.Read(12345, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, AnalysisResponse{ID: 4, TimeFirstTest: 1284051910, TimeConfirmTest: 1284051920}, analysis.AnalysisResponse)
+	assert.JSONEq(t, body, string(analysis.Raw))
+}
@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 )
@@ -15,6 +16,23 @@ type PingdomError struct {
 	StatusCode int    `json:"statuscode"`
 	StatusDesc string `json:"statusdesc"`
 	Message    string `json:"errormessage"`
+
+	// Details lists the individual problems reported by Pingdom, typically
+	// one per rejected parameter on validation errors.
+	Details []PingdomErrorDetail `json:"errors,omitempty"`
+
+	// Diagnostic identifiers taken from the response headers, these are
+	// what Pingdom support asks for when investigating a failed request.
+	RequestID string `json:"-"`
+	TraceID   string `json:"-"`
+}
+
+// PingdomErrorDetail is a single entry of the detail array Pingdom includes in
+// some error responses.
+type PingdomErrorDetail struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 // CheckResponse represents the JSON response for a check from the Pingdom API.
@@ -44,7 +62,7 @@ type CheckResponse struct {
 
 	// Legacy; this is not returned by the API, we backfill the value from the
 	// Teams field.
-	TeamIds []int
+	TeamIds []int `json:"teamids,omitempty"`
 }
 
 // CheckTeamResponse is a Team returned inside of a Check instance. (We can't
@@ -86,6 +104,54 @@ type MaintenanceCheckResponse struct {
 	Tms    []int `json:"tms"`
 }
 
+// ListMeta is the paging metadata of a list call.
+type ListMeta struct {
+	// Total is the number of items matching the request over all pages,
+	// when Pingdom does not report it this is the number of items returned.
+	Total int
+	// Limit and Offset are the paging parameters the request was made
+	// with, zero when not set.
+	Limit  int
+	Offset int
+	// RateLimit is the remaining request quota after the call, nil when
+	// Pingdom did not report it.
+	RateLimit *RateLimit
+}
+
+// CheckList is a page of checks along with its metadata.
+type CheckList struct {
+	Checks []CheckResponse
+	Meta   ListMeta
+}
+
+// MaintenanceList is a page of maintenance windows along with its metadata.
+type MaintenanceList struct {
+	Maintenances []MaintenanceResponse
+	Meta         ListMeta
+}
+
+// ProbeList is a list of probes along with its metadata.
+type ProbeList struct {
+	Probes []ProbeResponse
+	Meta   ListMeta
+}
+
+// ActionsList is a page of alerts along with its metadata.  Pingdom does
+// not count the alerts, so Meta.Total is the number of alerts returned.
+type ActionsList struct {
+	Alerts []AlertEntry
+	Meta   ListMeta
+}
+
+// OccurrenceResponse represents the JSON response for an occurrence of a
+// maintenance window from the Pingdom API.
+type OccurrenceResponse struct {
+	ID            int   `json:"id"`
+	MaintenanceID int   `json:"maintenanceid"`
+	From          int64 `json:"from"`
+	To            int64 `json:"to"`
+}
+
 // ProbeResponse represents the JSON response for probes from the Pingdom API.
 type ProbeResponse struct {
 	ID         int    `json:"id"`
@@ -100,6 +166,43 @@ type ProbeResponse struct {
 	Region     string `json:"region"`
 }
 
+// CreditsResponse represents the JSON response for the account credits from
+// the Pingdom API.  Default checks are uptime checks, transaction checks are
+// the transaction (TMS) checks.
+type CreditsResponse struct {
+	CheckLimit           int  `json:"checklimit"`
+	AvailableChecks      int  `json:"availablechecks"`
+	UsedDefault          int  `json:"useddefault"`
+	AvailableDefault     int  `json:"availabledefault"`
+	UsedTransaction      int  `json:"usedtransaction"`
+	AvailableTransaction int  `json:"availabletransaction"`
+	AvailableSMS         int  `json:"availablesms"`
+	AvailableSMSTests    int  `json:"availablesmstests"`
+	AutoFillSMS          bool `json:"autofillsms"`
+	AutoFillSMSAmount    int  `json:"autofillsms_amount"`
+	AutoFillSMSWhenLeft  int  `json:"autofillsms_when_left"`
+	MaxSMSOverage        int  `json:"max_sms_overage"`
+	AvailableRUMSites    int  `json:"availablerumsites"`
+	UsedRUMSites         int  `json:"usedrumsites"`
+}
+
+// SingleResult represents the result of a one-off check from the Pingdom API.
+type SingleResult struct {
+	Status         string `json:"status"`
+	ResponseTime   int    `json:"responsetime"`
+	StatusDesc     string `json:"statusdesc"`
+	StatusDescLong string `json:"statusdesclong"`
+	ProbeID        int    `json:"probeid"`
+	ProbeDesc      string `json:"probedesc"`
+}
+
+// TracerouteResponse represents the result of a traceroute from the Pingdom API.
+type TracerouteResponse struct {
+	Result           string `json:"result"`
+	ProbeID          int    `json:"probeid"`
+	ProbeDescription string `json:"probedescription"`
+}
+
 // SummaryPerformanceResponse represents the JSON response for a summary performance from the Pingdom API.
 type SummaryPerformanceResponse struct {
 	Summary SummaryPerformanceMap `json:"summary"`
@@ -121,6 +224,135 @@ type SummaryPerformanceSummary struct {
 	Uptime      int `json:"uptime"`
 }
 
+// SummaryAverageResponse represents the JSON response for a summary average from the Pingdom API.
+type SummaryAverageResponse struct {
+	Summary SummaryAverage `json:"summary"`
+}
+
+// SummaryAverage holds the average response time of a check and, when
+// requested, its uptime.
+type SummaryAverage struct {
+	ResponseTime SummaryResponseTime `json:"responsetime"`
+	Status       *SummaryStatus      `json:"status,omitempty"`
+}
+
+// SummaryResponseTime is the average response time over a time window.  When
+// broken down by country or probe, ByCountry or ByProbe is set instead of
+// AvgResponse.
+type SummaryResponseTime struct {
+	From        int64
+	To          int64
+	AvgResponse int
+	ByCountry   []SummaryCountryAverage
+	ByProbe     []SummaryProbeAverage
+}
+
+// SummaryCountryAverage is the average response time measured from a country.
+type SummaryCountryAverage struct {
+	CountryISO  string `json:"countryiso"`
+	AvgResponse int    `json:"avgresponse"`
+}
+
+// SummaryProbeAverage is the average response time measured by a probe.
+type SummaryProbeAverage struct {
+	ProbeID     int `json:"probeid"`
+	AvgResponse int `json:"avgresponse"`
+	N           int `json:"n"`
+}
+
+// UnmarshalJSON decodes avgresponse, which Pingdom returns either as a
+// number or as a list broken down by country or probe.
+func (s *SummaryResponseTime) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		From        int64           `json:"from"`
+		To          int64           `json:"to"`
+		AvgResponse json.RawMessage `json:"avgresponse"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	s.From, s.To = raw.From, raw.To
+
+	avg := bytes.TrimSpace(raw.AvgResponse)
+	if len(avg) == 0 {
+		return nil
+	}
+	if avg[0] != '[' {
+		return json.Unmarshal(avg, &s.AvgResponse)
+	}
+	if bytes.Contains(avg, []byte(`"countryiso"`)) {
+		return json.Unmarshal(avg, &s.ByCountry)
+	}
+	return json.Unmarshal(avg, &s.ByProbe)
+}
+
+// SummaryStatus is the total time, in seconds, a check spent in each state.
+type SummaryStatus struct {
+	TotalUp      int `json:"totalup"`
+	TotalDown    int `json:"totaldown"`
+	TotalUnknown int `json:"totalunknown"`
+}
+
+// SummaryOutageResponse represents the JSON response for a summary outage from the Pingdom API.
+type SummaryOutageResponse struct {
+	Summary SummaryOutageStates `json:"summary"`
+}
+
+// SummaryOutageStates is the list of states of a check over a time window.
+type SummaryOutageStates struct {
+	States []SummaryOutageState `json:"states"`
+}
+
+// SummaryOutageState is a period of time during which a check had the same status.
+type SummaryOutageState struct {
+	Status   string `json:"status"`
+	TimeFrom int64  `json:"timefrom"`
+	TimeTo   int64  `json:"timeto"`
+}
+
+// AlertEntry represents the JSON response for an alert sent by Pingdom.
+type AlertEntry struct {
+	ContactName  string `json:"contactname"`
+	ContactID    int    `json:"contactid"`
+	CheckID      int    `json:"checkid"`
+	Time         int64  `json:"time"`
+	Via          string `json:"via"`
+	Status       string `json:"status"`
+	MessageShort string `json:"messageshort"`
+	MessageFull  string `json:"messagefull"`
+	SentTo       string `json:"sentto"`
+	Charged      bool   `json:"charged"`
+	// Severity is the severity of the notification target the alert was
+	// sent to, SeverityHigh or SeverityLow.  Pingdom does not return it, it
+	// is only set when the request asks for it, see ActionsRequest.
+	Severity string `json:"severity,omitempty"`
+}
+
+// AnalysisResponse represents the JSON response for a root cause analysis entry.
+type AnalysisResponse struct {
+	ID              int   `json:"id"`
+	TimeFirstTest   int64 `json:"timefirsttest"`
+	TimeConfirmTest int64 `json:"timeconfirmtest"`
+}
+
+// AnalysisDetails represents the JSON response for a raw root cause analysis.
+// Pingdom does not document the payload, so only the fields shared with
+// AnalysisResponse are typed, Raw holds the whole payload.
+type AnalysisDetails struct {
+	AnalysisResponse
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the common fields of the analysis and keeps a copy of
+// the payload in Raw.
+func (ad *AnalysisDetails) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &ad.AnalysisResponse); err != nil {
+		return err
+	}
+	ad.Raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
 // ResultsResponse represents the JSON response for detailed check results from the Pingdom API.
 type ResultsResponse struct {
 	ActiveProbes []int    `json:"activeprobes"`
@@ -135,6 +367,7 @@ type Result struct {
 	ResponseTime   int    `json:"responsetime"`
 	StatusDesc     string `json:"statusdesc"`
 	StatusDescLong string `json:"statusdesclong"`
+	AnalysisID     int    `json:"analysisid,omitempty"`
 }
 
 // UserSmsResponse represents the JSON response for a user SMS contact.
@@ -167,6 +400,44 @@ type UsersResponse struct {
 	Email    []UserEmailResponse `json:"email,omitempty"`
 }
 
+// ContactResponse represents the JSON response for an alerting contact.
+type ContactResponse struct {
+	ID                  int                        `json:"id"`
+	Name                string                     `json:"name"`
+	Paused              bool                       `json:"paused"`
+	Type                string                     `json:"type,omitempty"`
+	Owner               bool                       `json:"owner,omitempty"`
+	NotificationTargets ContactNotificationTargets `json:"notification_targets"`
+	Teams               []ContactTeamResponse      `json:"teams,omitempty"`
+}
+
+// ContactNotificationTargets are the addresses an alerting contact is
+// notified on.
+type ContactNotificationTargets struct {
+	Email []UserEmailResponse `json:"email,omitempty"`
+	SMS   []UserSmsResponse   `json:"sms,omitempty"`
+}
+
+// ContactTeamResponse is a Team returned inside of a Contact instance.
+type ContactTeamResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TeamResponse represents the JSON response for an alerting team.
+type TeamResponse struct {
+	ID      int                  `json:"id"`
+	Name    string               `json:"name"`
+	Members []TeamMemberResponse `json:"members,omitempty"`
+}
+
+// TeamMemberResponse is a contact which belongs to a team.
+type TeamMemberResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
 // UnmarshalJSON converts a byte array into a CheckResponseType.
 func (c *CheckResponseType) UnmarshalJSON(b []byte) error {
 	var raw interface{}
@@ -202,6 +473,18 @@ func (c *CheckResponseType) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON converts a CheckResponseType into the same representation
+// Pingdom uses, so that it survives a round trip through UnmarshalJSON.
+func (c CheckResponseType) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.HTTP != nil:
+		return json.Marshal(map[string]interface{}{c.Name: c.HTTP})
+	case c.TCP != nil:
+		return json.Marshal(map[string]interface{}{c.Name: c.TCP})
+	}
+	return json.Marshal(c.Name)
+}
+
 // CheckResponseHTTPDetails represents the details specific to HTTP checks.
 type CheckResponseHTTPDetails struct {
 	Url               string            `json:"url,omitempty"`
@@ -226,13 +509,35 @@ type CheckResponseTCPDetails struct {
 
 // Return string representation of the PingdomError.
 func (r *PingdomError) Error() string {
-	return fmt.Sprintf("%d %v: %v", r.StatusCode, r.StatusDesc, r.Message)
+	msg := fmt.Sprintf("%d %v: %v", r.StatusCode, r.StatusDesc, r.Message)
+	for _, d := range r.Details {
+		msg = fmt.Sprintf("%v; %v", msg, d.String())
+	}
+	if r.RequestID != "" {
+		msg = fmt.Sprintf("%v (request id: %v)", msg, r.RequestID)
+	}
+	return msg
+}
+
+// Return string representation of the PingdomErrorDetail.
+func (d PingdomErrorDetail) String() string {
+	if d.Field == "" {
+		return d.Message
+	}
+	return fmt.Sprintf("%v: %v", d.Field, d.Message)
 }
 
 // private types used to unmarshall JSON responses from Pingdom.
 
 type listChecksJSONResponse struct {
-	Checks []CheckResponse `json:"checks"`
+	Checks []CheckResponse         `json:"checks"`
+	Counts *listCountsJSONResponse `json:"counts,omitempty"`
+}
+
+type listCountsJSONResponse struct {
+	Total    int  `json:"total"`
+	Limited  int  `json:"limited"`
+	Filtered *int `json:"filtered"`
 }
 
 type listMaintenanceJSONResponse struct {
@@ -243,6 +548,16 @@ type listProbesJSONResponse struct {
 	Probes []ProbeResponse `json:"probes"`
 }
 
+type listActionsJSONResponse struct {
+	Actions struct {
+		Alerts []AlertEntry `json:"alerts"`
+	} `json:"actions"`
+}
+
+type listAnalysisJSONResponse struct {
+	Analysis []AnalysisResponse `json:"analysis"`
+}
+
 type checkDetailsJSONResponse struct {
 	Check *CheckResponse `json:"check"`
 }
@@ -263,6 +578,46 @@ type listUsersJSONResponse struct {
 	Users []UsersResponse `json:"users"`
 }
 
+type listContactsJSONResponse struct {
+	Contacts []ContactResponse `json:"contacts"`
+}
+
+type listTeamsJSONResponse struct {
+	Teams []TeamResponse `json:"teams"`
+}
+
+type contactDetailsJSONResponse struct {
+	Contact *ContactResponse `json:"contact"`
+}
+
+type teamDetailsJSONResponse struct {
+	Team *TeamResponse `json:"team"`
+}
+
+type creditsJSONResponse struct {
+	Credits CreditsResponse `json:"credits"`
+}
+
+type listOccurrencesJSONResponse struct {
+	Occurrences []OccurrenceResponse `json:"occurrences"`
+}
+
+type occurrenceDetailsJSONResponse struct {
+	Occurrence OccurrenceResponse `json:"occurrence"`
+}
+
+type singleJSONResponse struct {
+	Result SingleResult `json:"result"`
+}
+
+type tracerouteJSONResponse struct {
+	Traceroute TracerouteResponse `json:"traceroute"`
+}
+
+type listSummaryProbesJSONResponse struct {
+	Probes []int `json:"probes"`
+}
+
 type errorJSONResponse struct {
 	Error *PingdomError `json:"error"`
 }
@@ -40,6 +40,10 @@ func TestPingdomError(t *testing.T) {
 	pe := PingdomError{StatusCode: 400, StatusDesc: "Bad Request", Message: "Missing param foo"}
 	want := "400 Bad Request: Missing param foo"
 	assert.Equal(t, want, pe.Error())
+
+	pe.RequestID = "abc-123"
+	want = "400 Bad Request: Missing param foo (request id: abc-123)"
+	assert.Equal(t, want, pe.Error())
 }
 
 func TestCheckResponseUnmarshal(t *testing.T) {
@@ -51,3 +55,20 @@ func TestCheckResponseUnmarshal(t *testing.T) {
 	assert.Equal(t, 2, len(ck.Type.HTTP.RequestHeaders))
 	assert.Equal(t, "HIGH", ck.SeverityLevel)
 }
+
+func TestCheckResponseTypeMarshal(t *testing.T) {
+	var ck CheckResponse
+	assert.NoError(t, json.Unmarshal([]byte(detailedCheckJSON), &ck))
+
+	b, err := json.Marshal(ck)
+	assert.NoError(t, err)
+
+	var roundTrip CheckResponse
+	assert.NoError(t, json.Unmarshal(b, &roundTrip))
+	assert.Equal(t, ck.Type, roundTrip.Type)
+	assert.Equal(t, ck.ID, roundTrip.ID)
+
+	b, err = json.Marshal(CheckResponseType{Name: "ping"})
+	assert.NoError(t, err)
+	assert.Equal(t, `"ping"`, string(b))
+}
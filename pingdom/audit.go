@@ -0,0 +1,230 @@
+package pingdom
+
+import (
+	"sort"
+	"time"
+)
+
+// Kinds of references a check can hold to other resources.
+const (
+	ReferenceUser        = "userids"
+	ReferenceTeam        = "teamids"
+	ReferenceIntegration = "integrationids"
+)
+
+// DanglingReference is a reference from a check to a resource which does
+// not exist in the account.  Alerts routed through such a reference are
+// silently dropped by Pingdom.
+type DanglingReference struct {
+	CheckID   int
+	CheckName string
+	Kind      string
+	ID        int
+}
+
+// ReferenceReport is the result of Client.AuditReferences.
+type ReferenceReport struct {
+	ChecksAudited int
+	Dangling      []DanglingReference
+}
+
+// OK returns true when no dangling references were found.
+func (r *ReferenceReport) OK() bool {
+	return len(r.Dangling) == 0
+}
+
+// OrphanReport is the result of Client.FindOrphans.
+type OrphanReport struct {
+	// UnusedContacts are contacts which no check alerts, neither directly
+	// nor through one of their teams.
+	UnusedContacts []ContactResponse
+	// UnusedTeams are teams which no check alerts.
+	UnusedTeams []TeamResponse
+	// OrphanedMaintenances are maintenance windows none of whose checks
+	// exist anymore.
+	OrphanedMaintenances []MaintenanceResponse
+	// StalePausedChecks are checks which have been paused for longer than
+	// the requested duration.
+	StalePausedChecks []CheckResponse
+}
+
+// Empty returns true when nothing was found.
+func (r *OrphanReport) Empty() bool {
+	return len(r.UnusedContacts) == 0 && len(r.UnusedTeams) == 0 &&
+		len(r.OrphanedMaintenances) == 0 && len(r.StalePausedChecks) == 0
+}
+
+// auditInventory is the account state the audit helpers work on.
+type auditInventory struct {
+	contacts []ContactResponse
+	teams    []TeamResponse
+	checks   []*CheckResponse
+}
+
+// loadAuditInventory fetches contacts, teams and the details of every check.
+// Each check is read individually since the check list does not include its
+// references.
+func (pc *Client) loadAuditInventory() (*auditInventory, error) {
+	contacts, err := pc.Contacts.List()
+	if err != nil {
+		return nil, err
+	}
+	teams, err := pc.Teams.List()
+	if err != nil {
+		return nil, err
+	}
+	checks, err := pc.Checks.List()
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &auditInventory{contacts: contacts, teams: teams}
+	for _, summary := range checks {
+		check, err := pc.Checks.Read(summary.ID)
+		if err != nil {
+			return nil, err
+		}
+		inv.checks = append(inv.checks, check)
+	}
+	return inv, nil
+}
+
+// AuditReferences verifies that every contact (userids) and team referenced
+// by the checks of the account exists.  The Pingdom API offers no way to list
+// integrations, so integration references are only verified when the valid
+// integration ids are passed in; a nil slice skips that verification.
+func (pc *Client) AuditReferences(integrationIDs []int) (*ReferenceReport, error) {
+	inv, err := pc.loadAuditInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	contactIDs := map[int]bool{}
+	for _, c := range inv.contacts {
+		contactIDs[c.ID] = true
+	}
+	teamIDs := map[int]bool{}
+	for _, t := range inv.teams {
+		teamIDs[t.ID] = true
+	}
+	var knownIntegrations map[int]bool
+	if integrationIDs != nil {
+		knownIntegrations = map[int]bool{}
+		for _, id := range integrationIDs {
+			knownIntegrations[id] = true
+		}
+	}
+
+	report := &ReferenceReport{}
+	for _, check := range inv.checks {
+		report.ChecksAudited++
+
+		report.Dangling = append(report.Dangling, danglingReferences(check, ReferenceUser, check.UserIds, contactIDs)...)
+		report.Dangling = append(report.Dangling, danglingReferences(check, ReferenceTeam, check.TeamIds, teamIDs)...)
+		if knownIntegrations != nil {
+			report.Dangling = append(report.Dangling, danglingReferences(check, ReferenceIntegration, check.IntegrationIds, knownIntegrations)...)
+		}
+	}
+
+	sort.SliceStable(report.Dangling, func(i, j int) bool {
+		return report.Dangling[i].CheckID < report.Dangling[j].CheckID
+	})
+	return report, nil
+}
+
+// FindOrphans looks for resources which serve no purpose anymore: contacts
+// and teams no check alerts, maintenance windows whose checks are all gone
+// and checks which have been paused for longer than pausedFor.  A zero
+// pausedFor skips the paused check detection.
+//
+// Maintenance windows covering transaction checks are never reported since
+// those checks cannot be listed through this client.
+func (pc *Client) FindOrphans(pausedFor time.Duration) (*OrphanReport, error) {
+	inv, err := pc.loadAuditInventory()
+	if err != nil {
+		return nil, err
+	}
+	maintenances, err := pc.Maintenances.List()
+	if err != nil {
+		return nil, err
+	}
+
+	checkIDs := map[int]bool{}
+	usedContacts := map[int]bool{}
+	usedTeams := map[int]bool{}
+	for _, check := range inv.checks {
+		checkIDs[check.ID] = true
+		for _, id := range check.UserIds {
+			usedContacts[id] = true
+		}
+		for _, id := range check.TeamIds {
+			usedTeams[id] = true
+		}
+	}
+	for _, team := range inv.teams {
+		if !usedTeams[team.ID] {
+			continue
+		}
+		for _, member := range team.Members {
+			usedContacts[member.ID] = true
+		}
+	}
+
+	report := &OrphanReport{}
+	for _, c := range inv.contacts {
+		if !usedContacts[c.ID] {
+			report.UnusedContacts = append(report.UnusedContacts, c)
+		}
+	}
+	for _, t := range inv.teams {
+		if !usedTeams[t.ID] {
+			report.UnusedTeams = append(report.UnusedTeams, t)
+		}
+	}
+	for _, m := range maintenances {
+		if len(m.Checks.Tms) > 0 {
+			continue
+		}
+		orphaned := true
+		for _, id := range m.Checks.Uptime {
+			if checkIDs[id] {
+				orphaned = false
+				break
+			}
+		}
+		if orphaned {
+			report.OrphanedMaintenances = append(report.OrphanedMaintenances, m)
+		}
+	}
+	if pausedFor > 0 {
+		cutoff := time.Now().Add(-pausedFor).Unix()
+		for _, check := range inv.checks {
+			// Paused checks are no longer tested, so the last test time
+			// tells for how long the check has been paused.
+			if isPaused(check) && check.LastTestTime < cutoff {
+				report.StalePausedChecks = append(report.StalePausedChecks, *check)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func isPaused(check *CheckResponse) bool {
+	return check.Paused || check.Status == "paused"
+}
+
+func danglingReferences(check *CheckResponse, kind string, ids []int, known map[int]bool) []DanglingReference {
+	var dangling []DanglingReference
+	for _, id := range ids {
+		if !known[id] {
+			dangling = append(dangling, DanglingReference{
+				CheckID:   check.ID,
+				CheckName: check.Name,
+				Kind:      kind,
+				ID:        id,
+			})
+		}
+	}
+	return dangling
+}
@@ -0,0 +1,90 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditReferences(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "name": "John Doe"}]}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"teams": [{"id": 7, "name": "Ops"}]}`)
+	})
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks": [{"id": 100, "name": "Healthy"}, {"id": 200, "name": "Broken"}]}`)
+	})
+	mux.HandleFunc("/checks/100", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 100, "name": "Healthy", "userids": [1], "teams": [{"id": 7, "name": "Ops"}], "integrationids": [55]}}`)
+	})
+	mux.HandleFunc("/checks/200", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 200, "name": "Broken", "userids": [1, 2], "teams": [{"id": 8, "name": "Gone"}], "integrationids": [66]}}`)
+	})
+
+	report, err := client.AuditReferences(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.ChecksAudited)
+	assert.False(t, report.OK())
+	assert.Equal(t, []DanglingReference{
+		{CheckID: 200, CheckName: "Broken", Kind: ReferenceUser, ID: 2},
+		{CheckID: 200, CheckName: "Broken", Kind: ReferenceTeam, ID: 8},
+	}, report.Dangling)
+
+	report, err = client.AuditReferences([]int{55})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(report.Dangling))
+	assert.Equal(t, DanglingReference{CheckID: 200, CheckName: "Broken", Kind: ReferenceIntegration, ID: 66}, report.Dangling[2])
+}
+
+func TestFindOrphans(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "name": "Direct"}, {"id": 2, "name": "Via team"}, {"id": 3, "name": "Unused"}]}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"teams": [{"id": 7, "name": "Ops", "members": [{"id": 2}]}, {"id": 8, "name": "Idle", "members": [{"id": 3}]}]}`)
+	})
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks": [{"id": 100, "name": "Active"}, {"id": 200, "name": "Paused"}]}`)
+	})
+	mux.HandleFunc("/checks/100", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 100, "name": "Active", "status": "up", "userids": [1], "teams": [{"id": 7, "name": "Ops"}]}}`)
+	})
+	mux.HandleFunc("/checks/200", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 200, "name": "Paused", "status": "paused", "lasttesttime": 1000}}`)
+	})
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"maintenance": [
+			{"id": 1, "description": "Live", "checks": {"uptime": [100, 999], "tms": []}},
+			{"id": 2, "description": "Orphaned", "checks": {"uptime": [999], "tms": []}},
+			{"id": 3, "description": "Transaction", "checks": {"uptime": [], "tms": [5]}}
+		]}`)
+	})
+
+	report, err := client.FindOrphans(24 * time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, report.Empty())
+
+	assert.Equal(t, 1, len(report.UnusedContacts))
+	assert.Equal(t, 3, report.UnusedContacts[0].ID)
+	assert.Equal(t, 1, len(report.UnusedTeams))
+	assert.Equal(t, 8, report.UnusedTeams[0].ID)
+	assert.Equal(t, 1, len(report.OrphanedMaintenances))
+	assert.Equal(t, 2, report.OrphanedMaintenances[0].ID)
+	assert.Equal(t, 1, len(report.StalePausedChecks))
+	assert.Equal(t, 200, report.StalePausedChecks[0].ID)
+
+	report, err = client.FindOrphans(0)
+	assert.NoError(t, err)
+	assert.Empty(t, report.StalePausedChecks)
+}
@@ -0,0 +1,145 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultBulkWorkers is the number of concurrent requests made by the bulk
+// methods when no worker count is given.
+const DefaultBulkWorkers = 4
+
+// BulkItemError is the error of a single item of a bulk operation.
+type BulkItemError struct {
+	// Index is the position of the item in the input of the operation.
+	Index int
+	// ID is the id of the check, zero when creating.
+	ID  int
+	Err error
+}
+
+func (e BulkItemError) Error() string {
+	if e.ID != 0 {
+		return fmt.Sprintf("item %d (id %d): %v", e.Index, e.ID, e.Err)
+	}
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the item.
+func (e BulkItemError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError is returned by the bulk methods when some of the items failed.
+// The other items were processed.
+type BulkError struct {
+	Errors []BulkItemError
+}
+
+func (e *BulkError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d of the bulk operations failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// CheckUpdate is an update of a check in a bulk update.
+type CheckUpdate struct {
+	ID    int
+	Check Check
+}
+
+// bulk calls fn for each index in [0, n) from the given number of workers and
+// collects the errors, sorted by index.  Items which were not started when ctx
+// is done fail with the context error.  Requests still go through the client,
+// so they are throttled and retried as usual.
+func bulk(ctx context.Context, n, workers int, id func(i int) int, fn func(ctx context.Context, i int) error) error {
+	if workers <= 0 {
+		workers = DefaultBulkWorkers
+	}
+
+	errs := make([]error, n)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				errs[i] = fn(ctx, i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var be BulkError
+	for i, err := range errs {
+		if err != nil {
+			be.Errors = append(be.Errors, BulkItemError{Index: i, ID: id(i), Err: err})
+		}
+	}
+	if len(be.Errors) > 0 {
+		return &be
+	}
+	return nil
+}
+
+// BulkCreate creates the given checks concurrently from workers goroutines,
+// DefaultBulkWorkers when workers is 0.  The responses are in the order of
+// checks, nil for the checks which failed.  When some failed the error is a
+// *BulkError.
+func (cs *CheckService) BulkCreate(checks []Check, workers int) ([]*CheckResponse, error) {
+	return cs.BulkCreateWithContext(context.Background(), checks, workers)
+}
+
+// BulkCreateWithContext is like BulkCreate, the requests are bound to ctx so
+// they can be canceled or given a deadline.
+func (cs *CheckService) BulkCreateWithContext(ctx context.Context, checks []Check, workers int) ([]*CheckResponse, error) {
+	responses := make([]*CheckResponse, len(checks))
+	err := bulk(ctx, len(checks), workers, func(int) int { return 0 }, func(ctx context.Context, i int) error {
+		r, err := cs.CreateWithContext(ctx, checks[i])
+		responses[i] = r
+		return err
+	})
+	return responses, err
+}
+
+// BulkUpdate updates the given checks concurrently, see BulkCreate.
+func (cs *CheckService) BulkUpdate(updates []CheckUpdate, workers int) error {
+	return cs.BulkUpdateWithContext(context.Background(), updates, workers)
+}
+
+// BulkUpdateWithContext is like BulkUpdate, the requests are bound to ctx so
+// they can be canceled or given a deadline.
+func (cs *CheckService) BulkUpdateWithContext(ctx context.Context, updates []CheckUpdate, workers int) error {
+	return bulk(ctx, len(updates), workers, func(i int) int { return updates[i].ID }, func(ctx context.Context, i int) error {
+		_, err := cs.UpdateWithContext(ctx, updates[i].ID, updates[i].Check)
+		return err
+	})
+}
+
+// BulkDelete deletes the checks with the given ids concurrently, see
+// BulkCreate.
+func (cs *CheckService) BulkDelete(ids []int, workers int) error {
+	return cs.BulkDeleteWithContext(context.Background(), ids, workers)
+}
+
+// BulkDeleteWithContext is like BulkDelete, the requests are bound to ctx so
+// they can be canceled or given a deadline.
+func (cs *CheckService) BulkDeleteWithContext(ctx context.Context, ids []int, workers int) error {
+	return bulk(ctx, len(ids), workers, func(i int) int { return ids[i] }, func(ctx context.Context, i int) error {
+		_, err := cs.DeleteWithContext(ctx, ids[i])
+		return err
+	})
+}
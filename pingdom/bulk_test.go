@@ -0,0 +1,114 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckServiceBulkCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		fmt.Fprintf(w, `{"check": {"id": 1%s, "name": %q}}`, strings.TrimPrefix(r.FormValue("name"), "check "), r.FormValue("name"))
+	})
+
+	var checks []Check
+	for i := 0; i < 6; i++ {
+		checks = append(checks, &HttpCheck{Name: fmt.Sprintf("check %d", i), Hostname: "example.com", Resolution: 5})
+	}
+	checks[3] = &HttpCheck{Name: "invalid", Hostname: "example.com"}
+
+	responses, err := client.Checks.BulkCreate(checks, 2)
+	assert.IsType(t, &BulkError{}, err)
+	assert.Len(t, err.(*BulkError).Errors, 1)
+	assert.Equal(t, 3, err.(*BulkError).Errors[0].Index)
+
+	assert.Len(t, responses, 6)
+	assert.Nil(t, responses[3])
+	assert.Equal(t, &CheckResponse{ID: 15, Name: "check 5"}, responses[5])
+	assert.True(t, maxActive <= 2)
+}
+
+func TestCheckServiceBulkUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var updated []string
+	mux.HandleFunc("/checks/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		mu.Lock()
+		updated = append(updated, r.URL.Path)
+		mu.Unlock()
+		fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+	})
+
+	err := client.Checks.BulkUpdate([]CheckUpdate{
+		{ID: 1, Check: &HttpCheck{Name: "one", Hostname: "example.com", Resolution: 5}},
+		{ID: 2, Check: &HttpCheck{Name: "two", Hostname: "example.com", Resolution: 5}},
+	}, 0)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"/checks/1", "/checks/2"}, updated)
+}
+
+func TestCheckServiceBulkDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		if r.URL.Path == "/checks/2" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"statuscode": 404, "statusdesc": "Not Found", "errormessage": "Check not found"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"message":"Deletion of check was successful!"}`)
+	})
+
+	err := client.Checks.BulkDelete([]int{1, 2, 3}, 3)
+	be, ok := err.(*BulkError)
+	assert.True(t, ok)
+	assert.Len(t, be.Errors, 1)
+	assert.Equal(t, 1, be.Errors[0].Index)
+	assert.Equal(t, 2, be.Errors[0].ID)
+	assert.Contains(t, err.Error(), "item 1 (id 2)")
+}
+
+func TestCheckServiceBulkDeleteCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/checks/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"message":"Deletion of check was successful!"}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.Checks.BulkDeleteWithContext(ctx, []int{1, 2}, 1)
+	assert.Len(t, err.(*BulkError).Errors, 2)
+	assert.Equal(t, context.Canceled, err.(*BulkError).Errors[0].Err)
+	assert.Equal(t, 0, calls)
+}
@@ -0,0 +1,146 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache is a pluggable key/value store used to keep data fetched from
+// Pingdom.  Implementations must be safe for concurrent use.  MemoryCache and
+// FileCache are provided, others such as bbolt or SQLite backed stores can be
+// plugged in by implementing this interface.
+type Cache interface {
+	// Get returns the entry for the key, or nil if there is none.
+	Get(key string) (*CacheEntry, error)
+	Set(key string, entry *CacheEntry) error
+	Delete(key string) error
+}
+
+// CacheEntry is a value stored in a Cache.
+type CacheEntry struct {
+	Data     []byte    `json:"data"`
+	StoredAt time.Time `json:"stored_at"`
+	// ETag and LastModified are the validators of a cached response, if any.
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// Age returns for how long the entry has been stored.
+func (e *CacheEntry) Age() time.Duration {
+	return time.Since(e.StoredAt)
+}
+
+// MemoryCache is a Cache keeping its entries in memory.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]CacheEntry
+}
+
+// NewMemoryCache returns an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]CacheEntry{}}
+}
+
+// Get returns the entry for the key, or nil if there is none.
+func (c *MemoryCache) Get(key string) (*CacheEntry, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+// Set stores the entry under the key.
+func (c *MemoryCache) Set(key string, entry *CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = *entry
+	return nil
+}
+
+// Delete removes the entry for the key.
+func (c *MemoryCache) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	return nil
+}
+
+// FileCache is a Cache persisting each entry as a JSON file in a directory,
+// so that the cached data survives restarts.
+type FileCache struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileCache returns a FileCache storing its entries in dir, which is
+// created if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (c *FileCache) path(key string) string {
+	return filepath.Join(c.dir, url.PathEscape(key)+".json")
+}
+
+// Get returns the entry for the key, or nil if there is none.
+func (c *FileCache) Get(key string) (*CacheEntry, error) {
+	b, err := ioutil.ReadFile(c.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	e := &CacheEntry{}
+	if err := json.Unmarshal(b, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Set stores the entry under the key.  The file is replaced atomically so
+// that readers never see a partial entry.
+func (c *FileCache) Set(key string, entry *CacheEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := ioutil.TempFile(c.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), c.path(key))
+}
+
+// Delete removes the entry for the key.
+func (c *FileCache) Delete(key string) error {
+	err := os.Remove(c.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
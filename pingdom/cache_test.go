@@ -0,0 +1,53 @@
+package pingdom
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCache(t *testing.T, c Cache) {
+	e, err := c.Get("inventory/checks")
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+
+	stored := time.Unix(1600000000, 0).UTC()
+	assert.NoError(t, c.Set("inventory/checks", &CacheEntry{Data: []byte(`[1,2]`), StoredAt: stored, ETag: `"abc"`}))
+
+	e, err = c.Get("inventory/checks")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`[1,2]`), e.Data)
+	assert.True(t, stored.Equal(e.StoredAt))
+	assert.Equal(t, `"abc"`, e.ETag)
+
+	assert.NoError(t, c.Delete("inventory/checks"))
+	assert.NoError(t, c.Delete("inventory/checks"))
+	e, err = c.Get("inventory/checks")
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+}
+
+func TestMemoryCache(t *testing.T) {
+	testCache(t, NewMemoryCache())
+}
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pingdom-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := NewFileCache(dir)
+	assert.NoError(t, err)
+	testCache(t, c)
+
+	// Entries survive a new cache on the same directory.
+	assert.NoError(t, c.Set("inventory/probes", &CacheEntry{Data: []byte(`[]`), StoredAt: time.Now()}))
+	c2, err := NewFileCache(dir)
+	assert.NoError(t, err)
+	e, err := c2.Get("inventory/probes")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`[]`), e.Data)
+}
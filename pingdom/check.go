@@ -1,8 +1,9 @@
 package pingdom
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
+	"fmt"
+	"net/url"
 	"strconv"
 )
 
@@ -19,10 +20,48 @@ type Check interface {
 	Valid() error
 }
 
+// CheckConfig is implemented by every check type of this package.  It allows
+// generic tooling to handle collections of different check types without
+// switching on the concrete type: Valid validates the check and PostParams
+// renders the parameters sent to Pingdom.
+//
+// Check definitions carry no id, Pingdom assigns one on creation.
+type CheckConfig interface {
+	Check
+	// CheckName returns the name of the check.
+	CheckName() string
+	// Kind returns the Pingdom type of the check, one of the CheckKind
+	// constants.
+	Kind() string
+}
+
 // List returns a list of checks from Pingdom.
 // This returns type CheckResponse rather than Check since the
 // Pingdom API does not return a complete representation of a check.
 func (cs *CheckService) List(params ...map[string]string) ([]CheckResponse, error) {
+	return cs.ListWithContext(context.Background(), params...)
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *CheckService) ListWithContext(ctx context.Context, params ...map[string]string) ([]CheckResponse, error) {
+	l, err := cs.ListWithMetaWithContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	return l.Checks, nil
+}
+
+// ListWithMeta returns a page of checks from Pingdom along with the total
+// number of checks and the paging parameters, see List.  Pass
+// "include_teams": "true" to have TeamIds filled in.
+func (cs *CheckService) ListWithMeta(params ...map[string]string) (*CheckList, error) {
+	return cs.ListWithMetaWithContext(context.Background(), params...)
+}
+
+// ListWithMetaWithContext is like ListWithMeta, the request is bound to ctx so
+// it can be canceled or given a deadline.
+func (cs *CheckService) ListWithMetaWithContext(ctx context.Context, params ...map[string]string) (*CheckList, error) {
 	param := map[string]string{}
 	if len(params) == 1 {
 		param = params[0]
@@ -31,23 +70,33 @@ func (cs *CheckService) List(params ...map[string]string) ([]CheckResponse, erro
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	resp, err := cs.client.client.Do(req)
+	m := &listChecksJSONResponse{}
+	resp, err := cs.client.Do(req, m)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
+	for i := range m.Checks {
+		if len(m.Checks[i].Teams) > 0 {
+			m.Checks[i].TeamIds = teamIDs(m.Checks[i].Teams)
+		}
 	}
 
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
-	m := &listChecksJSONResponse{}
-	err = json.Unmarshal([]byte(bodyString), &m)
-
-	return m.Checks, err
+	l := &CheckList{
+		Checks: m.Checks,
+		Meta:   newListMeta(resp, param, len(m.Checks)),
+	}
+	if m.Counts != nil {
+		// Total counts every check of the account, Filtered only those
+		// matching the filters such as tags.
+		l.Meta.Total = m.Counts.Total
+		if m.Counts.Filtered != nil {
+			l.Meta.Total = *m.Counts.Filtered
+		}
+	}
+	return l, nil
 }
 
 // Create a new check. This function will validate the given check param
@@ -56,14 +105,21 @@ func (cs *CheckService) List(params ...map[string]string) ([]CheckResponse, erro
 // Note that Pingdom does not return a full check object so in the returned
 // object you should only use the ID field.
 func (cs *CheckService) Create(check Check) (*CheckResponse, error) {
+	return cs.CreateWithContext(context.Background(), check)
+}
+
+// CreateWithContext is like Create, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *CheckService) CreateWithContext(ctx context.Context, check Check) (*CheckResponse, error) {
 	if err := check.Valid(); err != nil {
 		return nil, err
 	}
 
-	req, err := cs.client.NewRequest("POST", "/checks", check.PostParams())
+	req, err := cs.client.NewRequest("POST", "/checks", cs.client.managedParams(check.PostParams()))
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &checkDetailsJSONResponse{}
 	_, err = cs.client.Do(req, m)
@@ -77,36 +133,60 @@ func (cs *CheckService) Create(check Check) (*CheckResponse, error) {
 // This returns type CheckResponse rather than Check since the
 // pingdom API does not return a complete representation of a check.
 func (cs *CheckService) Read(id int) (*CheckResponse, error) {
+	return cs.ReadWithContext(context.Background(), id)
+}
+
+// ReadWithContext is like Read, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *CheckService) ReadWithContext(ctx context.Context, id int) (*CheckResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/checks/"+strconv.Itoa(id)+"?include_teams=true", nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &checkDetailsJSONResponse{}
 	_, err = cs.client.Do(req, m)
 	if err != nil {
 		return nil, err
 	}
-	m.Check.TeamIds = make([]int, len(m.Check.Teams))
-	for i := range m.Check.Teams {
-		m.Check.TeamIds[i] = m.Check.Teams[i].ID
-	}
+	m.Check.TeamIds = teamIDs(m.Check.Teams)
 
 	return m.Check, err
 }
 
+// teamIDs returns the ids of the teams assigned to a check.
+func teamIDs(teams []CheckTeamResponse) []int {
+	ids := make([]int, len(teams))
+	for i := range teams {
+		ids[i] = teams[i].ID
+	}
+	return ids
+}
+
 // Update will update the check represented by the given ID with the values
 // in the given check.  You should submit the complete list of values in
-// the given check parameter, not just those that have changed.
+// the given check parameter, not just those that have changed.  With a
+// ClientConfig.ManagedTag, checks lacking it are not updated, see Force.
 func (cs *CheckService) Update(id int, check Check) (*PingdomResponse, error) {
+	return cs.UpdateWithContext(context.Background(), id, check)
+}
+
+// UpdateWithContext is like Update, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *CheckService) UpdateWithContext(ctx context.Context, id int, check Check) (*PingdomResponse, error) {
 	if err := check.Valid(); err != nil {
 		return nil, err
 	}
+	if err := cs.guardManaged(ctx, id); err != nil {
+		return nil, err
+	}
 
-	req, err := cs.client.NewRequest("PUT", "/checks/"+strconv.Itoa(id), check.PutParams())
+	req, err := cs.client.NewRequest("PUT", "/checks/"+strconv.Itoa(id), cs.client.managedParams(check.PutParams()))
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &PingdomResponse{}
 	_, err = cs.client.Do(req, m)
@@ -116,12 +196,24 @@ func (cs *CheckService) Update(id int, check Check) (*PingdomResponse, error) {
 	return m, err
 }
 
-// Delete will delete the check for the given ID.
+// Delete will delete the check for the given ID.  With a
+// ClientConfig.ManagedTag, checks lacking it are not deleted, see Force.
 func (cs *CheckService) Delete(id int) (*PingdomResponse, error) {
+	return cs.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *CheckService) DeleteWithContext(ctx context.Context, id int) (*PingdomResponse, error) {
+	if err := cs.guardManaged(ctx, id); err != nil {
+		return nil, err
+	}
+
 	req, err := cs.client.NewRequest("DELETE", "/checks/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &PingdomResponse{}
 	_, err = cs.client.Do(req, m)
@@ -131,50 +223,81 @@ func (cs *CheckService) Delete(id int) (*PingdomResponse, error) {
 	return m, err
 }
 
-// SummaryPerformance returns a performance summary from Pingdom.
-func (cs *CheckService) SummaryPerformance(request SummaryPerformanceRequest) (*SummaryPerformanceResponse, error) {
-	if err := request.Valid(); err != nil {
+// SetPaused pauses, or resumes when paused is false, the checks with the
+// given ids in a single request.  With a ClientConfig.ManagedTag, no check
+// is changed when one of them lacks the tag, see Force.
+func (cs *CheckService) SetPaused(paused bool, ids ...int) (*PingdomResponse, error) {
+	return cs.SetPausedWithContext(context.Background(), paused, ids...)
+}
+
+// SetPausedWithContext is like SetPaused, the request is bound to ctx so it
+// can be canceled or given a deadline.
+func (cs *CheckService) SetPausedWithContext(ctx context.Context, paused bool, ids ...int) (*PingdomResponse, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("Invalid value for `ids`.  Must contain at least one check id")
+	}
+	if err := cs.guardManagedIDs(ctx, ids); err != nil {
 		return nil, err
 	}
 
-	req, err := cs.client.NewRequest("GET", "/summary.performance/"+strconv.Itoa(request.Id), request.GetParams())
+	req, err := cs.client.NewRequest("PUT", "/checks", map[string]string{
+		"checkids": intListToCDString(ids),
+		"paused":   strconv.FormatBool(paused),
+	})
 	if err != nil {
 		return nil, err
 	}
-	m := &SummaryPerformanceResponse{}
+	req = req.WithContext(ctx)
+
+	m := &PingdomResponse{}
 	_, err = cs.client.Do(req, m)
 	if err != nil {
 		return nil, err
 	}
+	return m, err
+}
 
-	return m, nil
+// SummaryPerformance returns a performance summary from Pingdom, see
+// SummaryService.Performance.
+func (cs *CheckService) SummaryPerformance(request SummaryPerformanceRequest) (*SummaryPerformanceResponse, error) {
+	return cs.SummaryPerformanceWithContext(context.Background(), request)
 }
 
-// Results returns raw check results and the list of associated probe IDs used from Pingdom.
-func (cs *CheckService) Results(id int, params ...map[string]string) (*ResultsResponse, error) {
-	param := map[string]string{}
-	if len(params) == 1 {
-		param = params[0]
-	}
-	req, err := cs.client.NewRequest("GET", "/results/"+strconv.Itoa(id), param)
-	if err != nil {
-		return nil, err
-	}
+// SummaryPerformanceWithContext is like SummaryPerformance, the request is
+// bound to ctx so it can be canceled or given a deadline.
+func (cs *CheckService) SummaryPerformanceWithContext(ctx context.Context, request SummaryPerformanceRequest) (*SummaryPerformanceResponse, error) {
+	return cs.client.Summary.PerformanceWithContext(ctx, request)
+}
 
-	resp, err := cs.client.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+// SummaryOutage returns the list of states (up, down, unknown) a check had
+// over a time window, see SummaryService.Outage.
+func (cs *CheckService) SummaryOutage(request SummaryOutageRequest) (*SummaryOutageResponse, error) {
+	return cs.SummaryOutageWithContext(context.Background(), request)
+}
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
-	}
+// SummaryOutageWithContext is like SummaryOutage, the request is bound to ctx
+// so it can be canceled or given a deadline.
+func (cs *CheckService) SummaryOutageWithContext(ctx context.Context, request SummaryOutageRequest) (*SummaryOutageResponse, error) {
+	return cs.client.Summary.OutageWithContext(ctx, request)
+}
 
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
-	m := &ResultsResponse{}
-	err = json.Unmarshal([]byte(bodyString), &m)
+// Results returns raw check results and the list of associated probe IDs used from Pingdom.
+//
+// Deprecated: use ResultsService.List, which takes a typed ResultsRequest.
+func (cs *CheckService) Results(id int, params ...map[string]string) (*ResultsResponse, error) {
+	return cs.ResultsWithContext(context.Background(), id, params...)
+}
 
-	return m, err
+// ResultsWithContext is like Results, the request is bound to ctx so it can be
+// canceled or given a deadline.
+//
+// Deprecated: use ResultsService.ListWithContext.
+func (cs *CheckService) ResultsWithContext(ctx context.Context, id int, params ...map[string]string) (*ResultsResponse, error) {
+	values := url.Values{}
+	if len(params) == 1 {
+		for k, v := range params[0] {
+			values.Set(k, v)
+		}
+	}
+	return cs.client.Results.list(ctx, id, values)
 }
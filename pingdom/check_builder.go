@@ -0,0 +1,761 @@
+package pingdom
+
+import "strings"
+
+// HTTPCheckBuilder builds an HttpCheck.  There is a builder per check type,
+// they set up checks with chained calls so that parameters which don't
+// apply to the type can't be set:
+//
+//	check, err := NewHTTPCheck("web", "example.com").
+//		WithURL("/health").
+//		WithShouldContain("ok").
+//		WithTags("prod").
+//		Build()
+//
+// Build validates the check with its Valid method, as Create does, and
+// returns the error of the latest WithTags call when a tag is empty or holds
+// a comma.  A builder can be reused, each Build returns a new check which
+// doesn't share anything with the builder or the checks built before.
+type HTTPCheckBuilder struct {
+	checkBuilder
+	check *HttpCheck
+}
+
+// NewHTTPCheck starts an HTTP check of host, testing / every 5 minutes.
+func NewHTTPCheck(name, host string) *HTTPCheckBuilder {
+	ck := &HttpCheck{Name: name, Hostname: host, Resolution: 5}
+	return &HTTPCheckBuilder{checkBuilder: newCheckBuilder(&ck.Resolution, &ck.Tags, &ck.ProbeFilters, &ck.UserIds, &ck.TeamIds, &ck.IntegrationIds,
+		&ck.SendNotificationWhenDown, &ck.NotifyAgainEvery, &ck.NotifyWhenBackup, &ck.Paused), check: ck}
+}
+
+// WithResolution sets the minutes between tests, 1, 5, 15, 30 or 60.
+func (b *HTTPCheckBuilder) WithResolution(minutes int) *HTTPCheckBuilder {
+	b.setResolution(minutes)
+	return b
+}
+
+// WithTags sets the tags of the check.
+func (b *HTTPCheckBuilder) WithTags(tags ...string) *HTTPCheckBuilder {
+	b.setTags(tags)
+	return b
+}
+
+// WithProbeFilters restricts the probes, e.g. "region: EU".
+func (b *HTTPCheckBuilder) WithProbeFilters(filters ...string) *HTTPCheckBuilder {
+	b.setProbeFilters(filters)
+	return b
+}
+
+// WithUserIDs sets the contacts alerted.
+func (b *HTTPCheckBuilder) WithUserIDs(ids ...int) *HTTPCheckBuilder {
+	*b.userIDs = ids
+	return b
+}
+
+// WithTeamIDs sets the teams alerted.
+func (b *HTTPCheckBuilder) WithTeamIDs(ids ...int) *HTTPCheckBuilder {
+	*b.teamIDs = ids
+	return b
+}
+
+// WithIntegrationIDs sets the integrations notified.
+func (b *HTTPCheckBuilder) WithIntegrationIDs(ids ...int) *HTTPCheckBuilder {
+	*b.integrationIDs = ids
+	return b
+}
+
+// WithNotifications alerts after whenDown failed tests, again every
+// againEvery tests while down, and when back up if whenBackUp is set.
+func (b *HTTPCheckBuilder) WithNotifications(whenDown, againEvery int, whenBackUp bool) *HTTPCheckBuilder {
+	b.setNotifications(whenDown, againEvery, whenBackUp)
+	return b
+}
+
+// Paused creates the check paused.
+func (b *HTTPCheckBuilder) Paused() *HTTPCheckBuilder {
+	*b.paused = true
+	return b
+}
+
+// WithURL sets the path tested, e.g. "/health?full=1".
+func (b *HTTPCheckBuilder) WithURL(path string) *HTTPCheckBuilder {
+	b.check.Url = path
+	return b
+}
+
+// WithPort sets a custom port.
+func (b *HTTPCheckBuilder) WithPort(port int) *HTTPCheckBuilder {
+	b.check.Port = port
+	return b
+}
+
+// WithEncryption tests over HTTPS.
+func (b *HTTPCheckBuilder) WithEncryption() *HTTPCheckBuilder {
+	b.check.Encryption = true
+	return b
+}
+
+// WithBasicAuth sets the credentials of HTTP basic authentication.
+func (b *HTTPCheckBuilder) WithBasicAuth(username, password string) *HTTPCheckBuilder {
+	b.check.Username, b.check.Password = username, password
+	return b
+}
+
+// WithShouldContain fails the check when the body does not contain s.
+func (b *HTTPCheckBuilder) WithShouldContain(s string) *HTTPCheckBuilder {
+	b.check.ShouldContain = s
+	return b
+}
+
+// WithShouldNotContain fails the check when the body contains s.
+func (b *HTTPCheckBuilder) WithShouldNotContain(s string) *HTTPCheckBuilder {
+	b.check.ShouldNotContain = s
+	return b
+}
+
+// WithPostData POSTs data instead of making a GET request.
+func (b *HTTPCheckBuilder) WithPostData(data string) *HTTPCheckBuilder {
+	b.check.PostData = data
+	return b
+}
+
+// WithResponseTimeThreshold fails the check when responses take longer
+// than ms milliseconds.
+func (b *HTTPCheckBuilder) WithResponseTimeThreshold(ms int) *HTTPCheckBuilder {
+	b.check.ResponseTimeThreshold = ms
+	return b
+}
+
+// WithHeader adds a request header.
+func (b *HTTPCheckBuilder) WithHeader(name, value string) *HTTPCheckBuilder {
+	if b.check.RequestHeaders == nil {
+		b.check.RequestHeaders = map[string]string{}
+	}
+	b.check.RequestHeaders[name] = value
+	return b
+}
+
+// WithVerifyCertificate sets whether the certificate must be valid.
+func (b *HTTPCheckBuilder) WithVerifyCertificate(verify bool) *HTTPCheckBuilder {
+	b.check.VerifyCertificate = &verify
+	return b
+}
+
+// WithSSLDownDaysBefore fails the check when the certificate expires
+// within days.
+func (b *HTTPCheckBuilder) WithSSLDownDaysBefore(days int) *HTTPCheckBuilder {
+	b.check.SSLDownDaysBefore = &days
+	return b
+}
+
+// Build returns the check, or the error found by WithTags or by its Valid
+// method.
+func (b *HTTPCheckBuilder) Build() (*HttpCheck, error) {
+	ck := *b.check
+	cloneIDs(&ck.UserIds, &ck.TeamIds, &ck.IntegrationIds)
+	if ck.RequestHeaders != nil {
+		ck.RequestHeaders = make(map[string]string, len(b.check.RequestHeaders))
+		for k, v := range b.check.RequestHeaders {
+			ck.RequestHeaders[k] = v
+		}
+	}
+	if ck.VerifyCertificate != nil {
+		verify := *ck.VerifyCertificate
+		ck.VerifyCertificate = &verify
+	}
+	if ck.SSLDownDaysBefore != nil {
+		days := *ck.SSLDownDaysBefore
+		ck.SSLDownDaysBefore = &days
+	}
+	if err := b.valid(&ck); err != nil {
+		return nil, err
+	}
+	return &ck, nil
+}
+
+// PingCheckBuilder builds a PingCheck, see HTTPCheckBuilder.
+type PingCheckBuilder struct {
+	checkBuilder
+	check *PingCheck
+}
+
+// NewPingCheck starts a ping check of host, tested every 5 minutes.
+func NewPingCheck(name, host string) *PingCheckBuilder {
+	ck := &PingCheck{Name: name, Hostname: host, Resolution: 5}
+	return &PingCheckBuilder{checkBuilder: newCheckBuilder(&ck.Resolution, &ck.Tags, &ck.ProbeFilters, &ck.UserIds, &ck.TeamIds, &ck.IntegrationIds,
+		&ck.SendNotificationWhenDown, &ck.NotifyAgainEvery, &ck.NotifyWhenBackup, &ck.Paused), check: ck}
+}
+
+// WithResolution sets the minutes between tests, 1, 5, 15, 30 or 60.
+func (b *PingCheckBuilder) WithResolution(minutes int) *PingCheckBuilder {
+	b.setResolution(minutes)
+	return b
+}
+
+// WithTags sets the tags of the check.
+func (b *PingCheckBuilder) WithTags(tags ...string) *PingCheckBuilder {
+	b.setTags(tags)
+	return b
+}
+
+// WithProbeFilters restricts the probes, e.g. "region: EU".
+func (b *PingCheckBuilder) WithProbeFilters(filters ...string) *PingCheckBuilder {
+	b.setProbeFilters(filters)
+	return b
+}
+
+// WithUserIDs sets the contacts alerted.
+func (b *PingCheckBuilder) WithUserIDs(ids ...int) *PingCheckBuilder {
+	*b.userIDs = ids
+	return b
+}
+
+// WithTeamIDs sets the teams alerted.
+func (b *PingCheckBuilder) WithTeamIDs(ids ...int) *PingCheckBuilder {
+	*b.teamIDs = ids
+	return b
+}
+
+// WithIntegrationIDs sets the integrations notified.
+func (b *PingCheckBuilder) WithIntegrationIDs(ids ...int) *PingCheckBuilder {
+	*b.integrationIDs = ids
+	return b
+}
+
+// WithNotifications alerts after whenDown failed tests, again every
+// againEvery tests while down, and when back up if whenBackUp is set.
+func (b *PingCheckBuilder) WithNotifications(whenDown, againEvery int, whenBackUp bool) *PingCheckBuilder {
+	b.setNotifications(whenDown, againEvery, whenBackUp)
+	return b
+}
+
+// Paused creates the check paused.
+func (b *PingCheckBuilder) Paused() *PingCheckBuilder {
+	*b.paused = true
+	return b
+}
+
+// WithResponseTimeThreshold fails the check when responses take longer
+// than ms milliseconds.
+func (b *PingCheckBuilder) WithResponseTimeThreshold(ms int) *PingCheckBuilder {
+	b.check.ResponseTimeThreshold = ms
+	return b
+}
+
+// Build returns the check, or the error found by WithTags or by its Valid
+// method.
+func (b *PingCheckBuilder) Build() (*PingCheck, error) {
+	ck := *b.check
+	cloneIDs(&ck.UserIds, &ck.TeamIds, &ck.IntegrationIds)
+	if err := b.valid(&ck); err != nil {
+		return nil, err
+	}
+	return &ck, nil
+}
+
+// TCPCheckBuilder builds a TCPCheck, see HTTPCheckBuilder.
+type TCPCheckBuilder struct {
+	checkBuilder
+	check *TCPCheck
+}
+
+// NewTCPCheck starts a TCP check of port on host, tested every 5 minutes.
+func NewTCPCheck(name, host string, port int) *TCPCheckBuilder {
+	ck := &TCPCheck{Name: name, Hostname: host, Resolution: 5, Port: port}
+	return &TCPCheckBuilder{checkBuilder: newCheckBuilder(&ck.Resolution, &ck.Tags, &ck.ProbeFilters, &ck.UserIds, &ck.TeamIds, &ck.IntegrationIds,
+		&ck.SendNotificationWhenDown, &ck.NotifyAgainEvery, &ck.NotifyWhenBackup, &ck.Paused), check: ck}
+}
+
+// WithResolution sets the minutes between tests, 1, 5, 15, 30 or 60.
+func (b *TCPCheckBuilder) WithResolution(minutes int) *TCPCheckBuilder {
+	b.setResolution(minutes)
+	return b
+}
+
+// WithTags sets the tags of the check.
+func (b *TCPCheckBuilder) WithTags(tags ...string) *TCPCheckBuilder {
+	b.setTags(tags)
+	return b
+}
+
+// WithProbeFilters restricts the probes, e.g. "region: EU".
+func (b *TCPCheckBuilder) WithProbeFilters(filters ...string) *TCPCheckBuilder {
+	b.setProbeFilters(filters)
+	return b
+}
+
+// WithUserIDs sets the contacts alerted.
+func (b *TCPCheckBuilder) WithUserIDs(ids ...int) *TCPCheckBuilder {
+	*b.userIDs = ids
+	return b
+}
+
+// WithTeamIDs sets the teams alerted.
+func (b *TCPCheckBuilder) WithTeamIDs(ids ...int) *TCPCheckBuilder {
+	*b.teamIDs = ids
+	return b
+}
+
+// WithIntegrationIDs sets the integrations notified.
+func (b *TCPCheckBuilder) WithIntegrationIDs(ids ...int) *TCPCheckBuilder {
+	*b.integrationIDs = ids
+	return b
+}
+
+// WithNotifications alerts after whenDown failed tests, again every
+// againEvery tests while down, and when back up if whenBackUp is set.
+func (b *TCPCheckBuilder) WithNotifications(whenDown, againEvery int, whenBackUp bool) *TCPCheckBuilder {
+	b.setNotifications(whenDown, againEvery, whenBackUp)
+	return b
+}
+
+// Paused creates the check paused.
+func (b *TCPCheckBuilder) Paused() *TCPCheckBuilder {
+	*b.paused = true
+	return b
+}
+
+// WithStringToSend sends s once connected.
+func (b *TCPCheckBuilder) WithStringToSend(s string) *TCPCheckBuilder {
+	b.check.StringToSend = s
+	return b
+}
+
+// WithStringToExpect fails the check when s is not received.
+func (b *TCPCheckBuilder) WithStringToExpect(s string) *TCPCheckBuilder {
+	b.check.StringToExpect = s
+	return b
+}
+
+// Build returns the check, or the error found by WithTags or by its Valid
+// method.
+func (b *TCPCheckBuilder) Build() (*TCPCheck, error) {
+	ck := *b.check
+	cloneIDs(&ck.UserIds, &ck.TeamIds, &ck.IntegrationIds)
+	if err := b.valid(&ck); err != nil {
+		return nil, err
+	}
+	return &ck, nil
+}
+
+// DNSCheckBuilder builds a DNSCheck, see HTTPCheckBuilder.
+type DNSCheckBuilder struct {
+	checkBuilder
+	check *DNSCheck
+}
+
+// NewDNSCheck starts a DNS check resolving host on nameServer and expecting
+// expectedIP, tested every 5 minutes.
+func NewDNSCheck(name, host, nameServer, expectedIP string) *DNSCheckBuilder {
+	ck := &DNSCheck{Name: name, Hostname: host, Resolution: 5, NameServer: nameServer, ExpectedIP: expectedIP}
+	return &DNSCheckBuilder{checkBuilder: newCheckBuilder(&ck.Resolution, &ck.Tags, &ck.ProbeFilters, &ck.UserIds, &ck.TeamIds, &ck.IntegrationIds,
+		&ck.SendNotificationWhenDown, &ck.NotifyAgainEvery, &ck.NotifyWhenBackup, &ck.Paused), check: ck}
+}
+
+// WithResolution sets the minutes between tests, 1, 5, 15, 30 or 60.
+func (b *DNSCheckBuilder) WithResolution(minutes int) *DNSCheckBuilder {
+	b.setResolution(minutes)
+	return b
+}
+
+// WithTags sets the tags of the check.
+func (b *DNSCheckBuilder) WithTags(tags ...string) *DNSCheckBuilder {
+	b.setTags(tags)
+	return b
+}
+
+// WithProbeFilters restricts the probes, e.g. "region: EU".
+func (b *DNSCheckBuilder) WithProbeFilters(filters ...string) *DNSCheckBuilder {
+	b.setProbeFilters(filters)
+	return b
+}
+
+// WithUserIDs sets the contacts alerted.
+func (b *DNSCheckBuilder) WithUserIDs(ids ...int) *DNSCheckBuilder {
+	*b.userIDs = ids
+	return b
+}
+
+// WithTeamIDs sets the teams alerted.
+func (b *DNSCheckBuilder) WithTeamIDs(ids ...int) *DNSCheckBuilder {
+	*b.teamIDs = ids
+	return b
+}
+
+// WithIntegrationIDs sets the integrations notified.
+func (b *DNSCheckBuilder) WithIntegrationIDs(ids ...int) *DNSCheckBuilder {
+	*b.integrationIDs = ids
+	return b
+}
+
+// WithNotifications alerts after whenDown failed tests, again every
+// againEvery tests while down, and when back up if whenBackUp is set.
+func (b *DNSCheckBuilder) WithNotifications(whenDown, againEvery int, whenBackUp bool) *DNSCheckBuilder {
+	b.setNotifications(whenDown, againEvery, whenBackUp)
+	return b
+}
+
+// Paused creates the check paused.
+func (b *DNSCheckBuilder) Paused() *DNSCheckBuilder {
+	*b.paused = true
+	return b
+}
+
+// Build returns the check, or the error found by WithTags or by its Valid
+// method.
+func (b *DNSCheckBuilder) Build() (*DNSCheck, error) {
+	ck := *b.check
+	cloneIDs(&ck.UserIds, &ck.TeamIds, &ck.IntegrationIds)
+	if err := b.valid(&ck); err != nil {
+		return nil, err
+	}
+	return &ck, nil
+}
+
+// SMTPCheckBuilder builds an SMTPCheck, see HTTPCheckBuilder.
+type SMTPCheckBuilder struct {
+	checkBuilder
+	check *SMTPCheck
+}
+
+// NewSMTPCheck starts an SMTP check of host, tested every 5 minutes.
+func NewSMTPCheck(name, host string) *SMTPCheckBuilder {
+	ck := &SMTPCheck{Name: name, Hostname: host, Resolution: 5}
+	return &SMTPCheckBuilder{checkBuilder: newCheckBuilder(&ck.Resolution, &ck.Tags, &ck.ProbeFilters, &ck.UserIds, &ck.TeamIds, &ck.IntegrationIds,
+		&ck.SendNotificationWhenDown, &ck.NotifyAgainEvery, &ck.NotifyWhenBackup, &ck.Paused), check: ck}
+}
+
+// WithResolution sets the minutes between tests, 1, 5, 15, 30 or 60.
+func (b *SMTPCheckBuilder) WithResolution(minutes int) *SMTPCheckBuilder {
+	b.setResolution(minutes)
+	return b
+}
+
+// WithTags sets the tags of the check.
+func (b *SMTPCheckBuilder) WithTags(tags ...string) *SMTPCheckBuilder {
+	b.setTags(tags)
+	return b
+}
+
+// WithProbeFilters restricts the probes, e.g. "region: EU".
+func (b *SMTPCheckBuilder) WithProbeFilters(filters ...string) *SMTPCheckBuilder {
+	b.setProbeFilters(filters)
+	return b
+}
+
+// WithUserIDs sets the contacts alerted.
+func (b *SMTPCheckBuilder) WithUserIDs(ids ...int) *SMTPCheckBuilder {
+	*b.userIDs = ids
+	return b
+}
+
+// WithTeamIDs sets the teams alerted.
+func (b *SMTPCheckBuilder) WithTeamIDs(ids ...int) *SMTPCheckBuilder {
+	*b.teamIDs = ids
+	return b
+}
+
+// WithIntegrationIDs sets the integrations notified.
+func (b *SMTPCheckBuilder) WithIntegrationIDs(ids ...int) *SMTPCheckBuilder {
+	*b.integrationIDs = ids
+	return b
+}
+
+// WithNotifications alerts after whenDown failed tests, again every
+// againEvery tests while down, and when back up if whenBackUp is set.
+func (b *SMTPCheckBuilder) WithNotifications(whenDown, againEvery int, whenBackUp bool) *SMTPCheckBuilder {
+	b.setNotifications(whenDown, againEvery, whenBackUp)
+	return b
+}
+
+// Paused creates the check paused.
+func (b *SMTPCheckBuilder) Paused() *SMTPCheckBuilder {
+	*b.paused = true
+	return b
+}
+
+// WithPort sets a custom port.
+func (b *SMTPCheckBuilder) WithPort(port int) *SMTPCheckBuilder {
+	b.check.Port = port
+	return b
+}
+
+// WithEncryption connects over TLS.
+func (b *SMTPCheckBuilder) WithEncryption() *SMTPCheckBuilder {
+	b.check.Encryption = true
+	return b
+}
+
+// WithAuth sets the credentials to log in with.
+func (b *SMTPCheckBuilder) WithAuth(username, password string) *SMTPCheckBuilder {
+	b.check.Username, b.check.Password = username, password
+	return b
+}
+
+// WithStringToExpect fails the check when s is not received.
+func (b *SMTPCheckBuilder) WithStringToExpect(s string) *SMTPCheckBuilder {
+	b.check.StringToExpect = s
+	return b
+}
+
+// Build returns the check, or the error found by WithTags or by its Valid
+// method.
+func (b *SMTPCheckBuilder) Build() (*SMTPCheck, error) {
+	ck := *b.check
+	cloneIDs(&ck.UserIds, &ck.TeamIds, &ck.IntegrationIds)
+	if err := b.valid(&ck); err != nil {
+		return nil, err
+	}
+	return &ck, nil
+}
+
+// POP3CheckBuilder builds a POP3Check, see HTTPCheckBuilder.
+type POP3CheckBuilder struct {
+	checkBuilder
+	check *POP3Check
+}
+
+// NewPOP3Check starts a POP3 check of host, tested every 5 minutes.
+func NewPOP3Check(name, host string) *POP3CheckBuilder {
+	ck := &POP3Check{Name: name, Hostname: host, Resolution: 5}
+	return &POP3CheckBuilder{checkBuilder: newCheckBuilder(&ck.Resolution, &ck.Tags, &ck.ProbeFilters, &ck.UserIds, &ck.TeamIds, &ck.IntegrationIds,
+		&ck.SendNotificationWhenDown, &ck.NotifyAgainEvery, &ck.NotifyWhenBackup, &ck.Paused), check: ck}
+}
+
+// WithResolution sets the minutes between tests, 1, 5, 15, 30 or 60.
+func (b *POP3CheckBuilder) WithResolution(minutes int) *POP3CheckBuilder {
+	b.setResolution(minutes)
+	return b
+}
+
+// WithTags sets the tags of the check.
+func (b *POP3CheckBuilder) WithTags(tags ...string) *POP3CheckBuilder {
+	b.setTags(tags)
+	return b
+}
+
+// WithProbeFilters restricts the probes, e.g. "region: EU".
+func (b *POP3CheckBuilder) WithProbeFilters(filters ...string) *POP3CheckBuilder {
+	b.setProbeFilters(filters)
+	return b
+}
+
+// WithUserIDs sets the contacts alerted.
+func (b *POP3CheckBuilder) WithUserIDs(ids ...int) *POP3CheckBuilder {
+	*b.userIDs = ids
+	return b
+}
+
+// WithTeamIDs sets the teams alerted.
+func (b *POP3CheckBuilder) WithTeamIDs(ids ...int) *POP3CheckBuilder {
+	*b.teamIDs = ids
+	return b
+}
+
+// WithIntegrationIDs sets the integrations notified.
+func (b *POP3CheckBuilder) WithIntegrationIDs(ids ...int) *POP3CheckBuilder {
+	*b.integrationIDs = ids
+	return b
+}
+
+// WithNotifications alerts after whenDown failed tests, again every
+// againEvery tests while down, and when back up if whenBackUp is set.
+func (b *POP3CheckBuilder) WithNotifications(whenDown, againEvery int, whenBackUp bool) *POP3CheckBuilder {
+	b.setNotifications(whenDown, againEvery, whenBackUp)
+	return b
+}
+
+// Paused creates the check paused.
+func (b *POP3CheckBuilder) Paused() *POP3CheckBuilder {
+	*b.paused = true
+	return b
+}
+
+// WithPort sets a custom port.
+func (b *POP3CheckBuilder) WithPort(port int) *POP3CheckBuilder {
+	b.check.Port = port
+	return b
+}
+
+// WithEncryption connects over TLS.
+func (b *POP3CheckBuilder) WithEncryption() *POP3CheckBuilder {
+	b.check.Encryption = true
+	return b
+}
+
+// WithStringToExpect fails the check when s is not received.
+func (b *POP3CheckBuilder) WithStringToExpect(s string) *POP3CheckBuilder {
+	b.check.StringToExpect = s
+	return b
+}
+
+// Build returns the check, or the error found by WithTags or by its Valid
+// method.
+func (b *POP3CheckBuilder) Build() (*POP3Check, error) {
+	ck := *b.check
+	cloneIDs(&ck.UserIds, &ck.TeamIds, &ck.IntegrationIds)
+	if err := b.valid(&ck); err != nil {
+		return nil, err
+	}
+	return &ck, nil
+}
+
+// IMAPCheckBuilder builds an IMAPCheck, see HTTPCheckBuilder.
+type IMAPCheckBuilder struct {
+	checkBuilder
+	check *IMAPCheck
+}
+
+// NewIMAPCheck starts an IMAP check of host, tested every 5 minutes.
+func NewIMAPCheck(name, host string) *IMAPCheckBuilder {
+	ck := &IMAPCheck{Name: name, Hostname: host, Resolution: 5}
+	return &IMAPCheckBuilder{checkBuilder: newCheckBuilder(&ck.Resolution, &ck.Tags, &ck.ProbeFilters, &ck.UserIds, &ck.TeamIds, &ck.IntegrationIds,
+		&ck.SendNotificationWhenDown, &ck.NotifyAgainEvery, &ck.NotifyWhenBackup, &ck.Paused), check: ck}
+}
+
+// WithResolution sets the minutes between tests, 1, 5, 15, 30 or 60.
+func (b *IMAPCheckBuilder) WithResolution(minutes int) *IMAPCheckBuilder {
+	b.setResolution(minutes)
+	return b
+}
+
+// WithTags sets the tags of the check.
+func (b *IMAPCheckBuilder) WithTags(tags ...string) *IMAPCheckBuilder {
+	b.setTags(tags)
+	return b
+}
+
+// WithProbeFilters restricts the probes, e.g. "region: EU".
+func (b *IMAPCheckBuilder) WithProbeFilters(filters ...string) *IMAPCheckBuilder {
+	b.setProbeFilters(filters)
+	return b
+}
+
+// WithUserIDs sets the contacts alerted.
+func (b *IMAPCheckBuilder) WithUserIDs(ids ...int) *IMAPCheckBuilder {
+	*b.userIDs = ids
+	return b
+}
+
+// WithTeamIDs sets the teams alerted.
+func (b *IMAPCheckBuilder) WithTeamIDs(ids ...int) *IMAPCheckBuilder {
+	*b.teamIDs = ids
+	return b
+}
+
+// WithIntegrationIDs sets the integrations notified.
+func (b *IMAPCheckBuilder) WithIntegrationIDs(ids ...int) *IMAPCheckBuilder {
+	*b.integrationIDs = ids
+	return b
+}
+
+// WithNotifications alerts after whenDown failed tests, again every
+// againEvery tests while down, and when back up if whenBackUp is set.
+func (b *IMAPCheckBuilder) WithNotifications(whenDown, againEvery int, whenBackUp bool) *IMAPCheckBuilder {
+	b.setNotifications(whenDown, againEvery, whenBackUp)
+	return b
+}
+
+// Paused creates the check paused.
+func (b *IMAPCheckBuilder) Paused() *IMAPCheckBuilder {
+	*b.paused = true
+	return b
+}
+
+// WithPort sets a custom port.
+func (b *IMAPCheckBuilder) WithPort(port int) *IMAPCheckBuilder {
+	b.check.Port = port
+	return b
+}
+
+// WithEncryption connects over TLS.
+func (b *IMAPCheckBuilder) WithEncryption() *IMAPCheckBuilder {
+	b.check.Encryption = true
+	return b
+}
+
+// WithStringToExpect fails the check when s is not received.
+func (b *IMAPCheckBuilder) WithStringToExpect(s string) *IMAPCheckBuilder {
+	b.check.StringToExpect = s
+	return b
+}
+
+// Build returns the check, or the error found by WithTags or by its Valid
+// method.
+func (b *IMAPCheckBuilder) Build() (*IMAPCheck, error) {
+	ck := *b.check
+	cloneIDs(&ck.UserIds, &ck.TeamIds, &ck.IntegrationIds)
+	if err := b.valid(&ck); err != nil {
+		return nil, err
+	}
+	return &ck, nil
+}
+
+// checkBuilder sets the parameters shared by every check type, through
+// pointers to the fields of the check being built.  It keeps the error of
+// the latest WithTags call so that Build can return it.
+type checkBuilder struct {
+	resolution                       *int
+	tags, probeFilters               *string
+	userIDs, teamIDs, integrationIDs *[]int
+	whenDown, againEvery             *int
+	whenBackUp, paused               *bool
+	err                              error
+}
+
+func newCheckBuilder(resolution *int, tags, probeFilters *string, userIDs, teamIDs, integrationIDs *[]int,
+	whenDown, againEvery *int, whenBackUp, paused *bool) checkBuilder {
+	return checkBuilder{
+		resolution:     resolution,
+		tags:           tags,
+		probeFilters:   probeFilters,
+		userIDs:        userIDs,
+		teamIDs:        teamIDs,
+		integrationIDs: integrationIDs,
+		whenDown:       whenDown,
+		againEvery:     againEvery,
+		whenBackUp:     whenBackUp,
+		paused:         paused,
+	}
+}
+
+func (b *checkBuilder) setResolution(minutes int) {
+	*b.resolution = minutes
+}
+
+// setTags joins tags with JoinTags, whose error is returned by Build unless
+// a later call sets valid tags.
+func (b *checkBuilder) setTags(tags []string) {
+	joined, err := JoinTags(tags...)
+	b.err = err
+	if err == nil {
+		*b.tags = joined
+	}
+}
+
+func (b *checkBuilder) setProbeFilters(filters []string) {
+	*b.probeFilters = strings.Join(filters, ",")
+}
+
+func (b *checkBuilder) setNotifications(whenDown, againEvery int, whenBackUp bool) {
+	*b.whenDown, *b.againEvery, *b.whenBackUp = whenDown, againEvery, whenBackUp
+}
+
+// valid returns the error found while building, if any, or the error found
+// by the Valid method of ck.
+func (b *checkBuilder) valid(ck interface{ Valid() error }) error {
+	if b.err != nil {
+		return b.err
+	}
+	return ck.Valid()
+}
+
+// cloneIDs replaces each list of ids with a copy, so that a built check
+// doesn't share them with its builder.
+func cloneIDs(lists ...*[]int) {
+	for _, ids := range lists {
+		if *ids != nil {
+			*ids = append([]int(nil), *ids...)
+		}
+	}
+}
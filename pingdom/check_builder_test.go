@@ -0,0 +1,119 @@
+package pingdom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPCheckBuilder(t *testing.T) {
+	check, err := NewHTTPCheck("web", "example.com").
+		WithURL("/health").
+		WithEncryption().
+		WithShouldContain("ok").
+		WithHeader("Accept", "application/json").
+		WithVerifyCertificate(true).
+		WithResolution(1).
+		WithTags("prod", "web").
+		WithUserIDs(1, 2).
+		WithNotifications(2, 10, true).
+		Build()
+	assert.NoError(t, err)
+
+	verify := true
+	assert.Equal(t, &HttpCheck{
+		Name:                     "web",
+		Hostname:                 "example.com",
+		Resolution:               1,
+		Url:                      "/health",
+		Encryption:               true,
+		ShouldContain:            "ok",
+		RequestHeaders:           map[string]string{"Accept": "application/json"},
+		VerifyCertificate:        &verify,
+		Tags:                     "prod,web",
+		UserIds:                  []int{1, 2},
+		SendNotificationWhenDown: 2,
+		NotifyAgainEvery:         10,
+		NotifyWhenBackup:         true,
+	}, check)
+
+	_, err = NewHTTPCheck("web", "example.com").WithShouldContain("ok").WithShouldNotContain("error").Build()
+	assert.Error(t, err)
+	_, err = NewHTTPCheck("web", "example.com").WithResolution(2).Build()
+	assert.Error(t, err)
+	_, err = NewHTTPCheck("web", "example.com").WithTags("prod,web").Build()
+	assert.EqualError(t, err, "Invalid value \"prod,web\" for `Tags`.  Must not contain commas")
+}
+
+func TestCheckBuilders(t *testing.T) {
+	ping, err := NewPingCheck("ping", "example.com").Paused().Build()
+	assert.NoError(t, err)
+	assert.Equal(t, &PingCheck{Name: "ping", Hostname: "example.com", Resolution: 5, Paused: true}, ping)
+
+	tcp, err := NewTCPCheck("redis", "db.example.com", 6379).WithStringToSend("PING").WithStringToExpect("PONG").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "tcp", tcp.Kind())
+	assert.Equal(t, 6379, tcp.Port)
+	_, err = NewTCPCheck("redis", "db.example.com", 0).Build()
+	assert.Error(t, err)
+
+	dns, err := NewDNSCheck("dns", "example.com", "ns1.example.com", "192.0.2.10").WithProbeFilters("region: EU").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "region: EU", dns.ProbeFilters)
+	_, err = NewDNSCheck("dns", "example.com", "", "192.0.2.10").Build()
+	assert.Error(t, err)
+
+	smtp, err := NewSMTPCheck("smtp", "mail.example.com").WithPort(587).WithAuth("monitor", "secret").WithTeamIDs(3).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "monitor:secret", smtp.PostParams()["auth"])
+	assert.Equal(t, []int{3}, smtp.TeamIds)
+
+	pop3, err := NewPOP3Check("pop3", "mail.example.com").WithEncryption().WithStringToExpect("+OK").Build()
+	assert.NoError(t, err)
+	assert.True(t, pop3.Encryption)
+
+	imap, err := NewIMAPCheck("imap", "mail.example.com").WithIntegrationIDs(7).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "imap", imap.Kind())
+	_, err = NewIMAPCheck("", "mail.example.com").Build()
+	assert.Error(t, err)
+	_, err = NewIMAPCheck("imap", "mail.example.com").WithTags("mail", " ").Build()
+	assert.Error(t, err)
+}
+
+func TestCheckBuilderImplementsCheckConfig(t *testing.T) {
+	var checks []CheckConfig
+	http, _ := NewHTTPCheck("web", "example.com").Build()
+	smtp, _ := NewSMTPCheck("smtp", "mail.example.com").Build()
+	checks = append(checks, http, smtp)
+	for _, c := range checks {
+		assert.NoError(t, c.Valid())
+	}
+}
+
+func TestCheckBuilderReuse(t *testing.T) {
+	b := NewHTTPCheck("web", "example.com").WithHeader("Accept", "text/html").WithUserIDs(1).WithVerifyCertificate(true)
+	first, err := b.Build()
+	assert.NoError(t, err)
+
+	second, err := b.WithHeader("Accept", "application/json").WithUserIDs(2).WithVerifyCertificate(false).Build()
+	assert.NoError(t, err)
+	second.UserIds[0] = 3
+	second.RequestHeaders["X-Test"] = "1"
+
+	assert.Equal(t, map[string]string{"Accept": "text/html"}, first.RequestHeaders)
+	assert.Equal(t, []int{1}, first.UserIds)
+	assert.True(t, *first.VerifyCertificate)
+
+	third, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Accept": "application/json"}, third.RequestHeaders)
+	assert.Equal(t, []int{2}, third.UserIds)
+
+	// The error of WithTags is cleared by a later valid call.
+	_, err = b.WithTags("prod,web").Build()
+	assert.Error(t, err)
+	fixed, err := b.WithTags("prod", "web").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "prod,web", fixed.Tags)
+}
@@ -0,0 +1,89 @@
+package pingdom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Config converts the check back into the definition it was created from,
+// e.g. to compare it with a desired definition or to copy it.  The details
+// of HTTP and TCP checks are only returned when reading a single check, a
+// check taken from a list loses them.  Fields Pingdom doesn't return, such
+// as the alerting settings of legacy users, are left empty.  DNS, SMTP, POP3
+// and IMAP checks can't be converted, their details are not returned.
+func (c CheckResponse) Config() (CheckConfig, error) {
+	tags := strings.Join(c.TagNames(), ",")
+	probeFilters := strings.Join(c.ProbeFilters, ",")
+
+	switch c.Type.Name {
+	case CheckKindHTTP:
+		ck := &HttpCheck{
+			Name:                     c.Name,
+			Hostname:                 c.Hostname,
+			Resolution:               c.Resolution,
+			Paused:                   c.Paused,
+			SendNotificationWhenDown: c.SendNotificationWhenDown,
+			NotifyAgainEvery:         c.NotifyAgainEvery,
+			NotifyWhenBackup:         c.NotifyWhenBackup,
+			IntegrationIds:           c.IntegrationIds,
+			ResponseTimeThreshold:    c.ResponseTimeThreshold,
+			Tags:                     tags,
+			ProbeFilters:             probeFilters,
+			UserIds:                  c.UserIds,
+			TeamIds:                  c.TeamIds,
+		}
+		if d := c.Type.HTTP; d != nil {
+			ck.Url = d.Url
+			ck.Encryption = d.Encryption
+			ck.Port = d.Port
+			ck.Username = d.Username
+			ck.Password = d.Password
+			ck.ShouldContain = d.ShouldContain
+			ck.ShouldNotContain = d.ShouldNotContain
+			ck.PostData = d.PostData
+			ck.RequestHeaders = d.RequestHeaders
+			verify, days := d.VerifyCertificate, d.SSLDownDaysBefore
+			ck.VerifyCertificate = &verify
+			ck.SSLDownDaysBefore = &days
+		}
+		return ck, nil
+	case CheckKindPing:
+		return &PingCheck{
+			Name:                     c.Name,
+			Hostname:                 c.Hostname,
+			Resolution:               c.Resolution,
+			Paused:                   c.Paused,
+			SendNotificationWhenDown: c.SendNotificationWhenDown,
+			NotifyAgainEvery:         c.NotifyAgainEvery,
+			NotifyWhenBackup:         c.NotifyWhenBackup,
+			IntegrationIds:           c.IntegrationIds,
+			Tags:                     tags,
+			ResponseTimeThreshold:    c.ResponseTimeThreshold,
+			ProbeFilters:             probeFilters,
+			UserIds:                  c.UserIds,
+			TeamIds:                  c.TeamIds,
+		}, nil
+	case CheckKindTCP:
+		ck := &TCPCheck{
+			Name:                     c.Name,
+			Hostname:                 c.Hostname,
+			Resolution:               c.Resolution,
+			Paused:                   c.Paused,
+			SendNotificationWhenDown: c.SendNotificationWhenDown,
+			NotifyAgainEvery:         c.NotifyAgainEvery,
+			NotifyWhenBackup:         c.NotifyWhenBackup,
+			IntegrationIds:           c.IntegrationIds,
+			Tags:                     tags,
+			ProbeFilters:             probeFilters,
+			UserIds:                  c.UserIds,
+			TeamIds:                  c.TeamIds,
+		}
+		if d := c.Type.TCP; d != nil {
+			ck.Port = d.Port
+			ck.StringToSend = d.StringToSend
+			ck.StringToExpect = d.StringToExpect
+		}
+		return ck, nil
+	}
+	return nil, fmt.Errorf("check %d has type %q, whose details are not returned by Pingdom", c.ID, c.Type.Name)
+}
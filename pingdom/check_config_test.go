@@ -0,0 +1,74 @@
+package pingdom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResponseConfig(t *testing.T) {
+	verify, days := true, 10
+	tests := []struct {
+		name  string
+		check CheckResponse
+		want  CheckConfig
+	}{
+		{
+			name: "http",
+			check: CheckResponse{
+				ID:           1,
+				Name:         "web",
+				Hostname:     "example.com",
+				Resolution:   5,
+				Tags:         []CheckResponseTag{{Name: "prod"}, {Name: "web"}},
+				ProbeFilters: []string{"region: EU"},
+				TeamIds:      []int{7},
+				Type: CheckResponseType{Name: "http", HTTP: &CheckResponseHTTPDetails{
+					Url:               "/health",
+					Encryption:        true,
+					ShouldContain:     "ok",
+					RequestHeaders:    map[string]string{"User-Agent": "pingdom"},
+					VerifyCertificate: true,
+					SSLDownDaysBefore: 10,
+				}},
+			},
+			want: &HttpCheck{
+				Name:              "web",
+				Hostname:          "example.com",
+				Resolution:        5,
+				Tags:              "prod,web",
+				ProbeFilters:      "region: EU",
+				TeamIds:           []int{7},
+				Url:               "/health",
+				Encryption:        true,
+				ShouldContain:     "ok",
+				RequestHeaders:    map[string]string{"User-Agent": "pingdom"},
+				VerifyCertificate: &verify,
+				SSLDownDaysBefore: &days,
+			},
+		},
+		{
+			name:  "ping",
+			check: CheckResponse{Name: "gw", Hostname: "10.0.0.1", Resolution: 1, Paused: true, Type: CheckResponseType{Name: "ping"}},
+			want:  &PingCheck{Name: "gw", Hostname: "10.0.0.1", Resolution: 1, Paused: true},
+		},
+		{
+			name: "tcp",
+			check: CheckResponse{Name: "smtp", Hostname: "mail.example.com", Resolution: 15, Type: CheckResponseType{
+				Name: "tcp",
+				TCP:  &CheckResponseTCPDetails{Port: 25, StringToExpect: "220"},
+			}},
+			want: &TCPCheck{Name: "smtp", Hostname: "mail.example.com", Resolution: 15, Port: 25, StringToExpect: "220"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.check.Config()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := CheckResponse{ID: 3, Type: CheckResponseType{Name: "dns"}}.Config()
+	assert.EqualError(t, err, `check 3 has type "dns", whose details are not returned by Pingdom`)
+}
@@ -0,0 +1,91 @@
+package pingdom
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// CheckSnapshot records the configuration of the checks of an account at a
+// point in time, see CheckService.ListModifiedSince.
+type CheckSnapshot struct {
+	Taken  time.Time
+	Checks map[int]CheckResponse
+}
+
+// CheckDelta is the difference between the live checks and a snapshot.
+type CheckDelta struct {
+	Created  []CheckResponse
+	Modified []CheckResponse
+	Deleted  []CheckResponse
+	// Snapshot reflects the live checks, pass it to the next call.
+	Snapshot *CheckSnapshot
+}
+
+// Empty returns true when no check changed.
+func (d *CheckDelta) Empty() bool {
+	return len(d.Created) == 0 && len(d.Modified) == 0 && len(d.Deleted) == 0
+}
+
+// NewCheckSnapshot returns a snapshot of the given checks taken now.
+func NewCheckSnapshot(checks []CheckResponse) *CheckSnapshot {
+	s := &CheckSnapshot{Taken: time.Now(), Checks: make(map[int]CheckResponse, len(checks))}
+	for _, c := range checks {
+		s.Checks[c.ID] = c
+	}
+	return s
+}
+
+// ListModifiedSince returns the checks created, modified or deleted since the
+// snapshot was taken.  The Pingdom API has no server-side filter for modified
+// checks, so the checks are listed and compared with the snapshot; fields
+// which change on every test run (status, last test, response and error
+// times) are ignored.  A nil snapshot reports every check as created.
+//
+// Frequent reconcile loops can keep the returned snapshot and only process
+// the changes instead of every check.
+func (cs *CheckService) ListModifiedSince(snapshot *CheckSnapshot, params ...map[string]string) (*CheckDelta, error) {
+	return cs.ListModifiedSinceWithContext(context.Background(), snapshot, params...)
+}
+
+// ListModifiedSinceWithContext is like ListModifiedSince, the request is bound
+// to ctx so it can be canceled or given a deadline.
+func (cs *CheckService) ListModifiedSinceWithContext(ctx context.Context, snapshot *CheckSnapshot, params ...map[string]string) (*CheckDelta, error) {
+	checks, err := cs.ListWithContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := &CheckDelta{Snapshot: NewCheckSnapshot(checks)}
+	var previous map[int]CheckResponse
+	if snapshot != nil {
+		previous = snapshot.Checks
+	}
+
+	for _, c := range checks {
+		old, ok := previous[c.ID]
+		switch {
+		case !ok:
+			delta.Created = append(delta.Created, c)
+		case !reflect.DeepEqual(checkFingerprint(old), checkFingerprint(c)):
+			delta.Modified = append(delta.Modified, c)
+		}
+	}
+	for id, old := range previous {
+		if _, ok := delta.Snapshot.Checks[id]; !ok {
+			delta.Deleted = append(delta.Deleted, old)
+		}
+	}
+
+	return delta, nil
+}
+
+// checkFingerprint clears the fields which change without the check being
+// modified.
+func checkFingerprint(c CheckResponse) CheckResponse {
+	c.Status = ""
+	c.LastErrorTime = 0
+	c.LastTestTime = 0
+	c.LastResponseTime = 0
+	return c
+}
@@ -0,0 +1,49 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckServiceListModifiedSince(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Now().Unix()
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"checks": [
+			{"id": 1, "name": "Unchanged", "status": "down", "lasttesttime": %d},
+			{"id": 2, "name": "Renamed", "resolution": 5},
+			{"id": 4, "name": "New", "created": %d}
+		]}`, now, now+10)
+	})
+
+	snapshot := &CheckSnapshot{
+		Taken: time.Unix(now, 0),
+		Checks: map[int]CheckResponse{
+			1: {ID: 1, Name: "Unchanged", Status: "up", LastTestTime: now - 60},
+			2: {ID: 2, Name: "Original", Resolution: 5},
+			3: {ID: 3, Name: "Deleted"},
+		},
+	}
+
+	delta, err := client.Checks.ListModifiedSince(snapshot)
+	assert.NoError(t, err)
+	assert.False(t, delta.Empty())
+	assert.Equal(t, []CheckResponse{{ID: 4, Name: "New", Created: now + 10}}, delta.Created)
+	assert.Equal(t, []CheckResponse{{ID: 2, Name: "Renamed", Resolution: 5}}, delta.Modified)
+	assert.Equal(t, []CheckResponse{{ID: 3, Name: "Deleted"}}, delta.Deleted)
+	assert.Equal(t, 3, len(delta.Snapshot.Checks))
+
+	delta, err = client.Checks.ListModifiedSince(delta.Snapshot)
+	assert.NoError(t, err)
+	assert.True(t, delta.Empty())
+
+	delta, err = client.Checks.ListModifiedSince(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(delta.Created))
+}
@@ -1,9 +1,72 @@
 package pingdom
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+)
 
 // ErrMissingId is an error for when a required Id field is missing.
 var ErrMissingId = errors.New("required field 'Id' missing")
 
 // ErrBadResolution is an error for when an invalid resolution is specified.
 var ErrBadResolution = errors.New("resolution must be either 'hour', 'day' or 'week'")
+
+// ErrBadOrder is an error for when an invalid order is specified.
+var ErrBadOrder = errors.New("order must be either 'asc' or 'desc'")
+
+// ErrBadTimeRange is an error for when From is after To.
+var ErrBadTimeRange = errors.New("'From' must not be after 'To'")
+
+// ErrBadStatus is an error for when an invalid result status is specified.
+var ErrBadStatus = errors.New("status must be either 'up', 'down', 'unconfirmed' or 'unknown'")
+
+// ErrBadLimit is an error for when a limit outside of the allowed range is specified.
+var ErrBadLimit = errors.New("limit must be between 0 and 1000")
+
+// ErrAmbiguousCheck is an error for when several checks match the name of a
+// check to create or update, so the one meant can't be told.
+var ErrAmbiguousCheck = errors.New("several checks have the same name and type")
+
+// ErrUnmanagedCheck is an error for when a check to update or delete lacks
+// the ClientConfig.ManagedTag, see Force.
+var ErrUnmanagedCheck = errors.New("check is not managed by this client")
+
+// StatusCode returns the HTTP status code of a *PingdomError, possibly
+// wrapped, or 0 for any other error.
+func StatusCode(err error) int {
+	var pe *PingdomError
+	if errors.As(err, &pe) {
+		return pe.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a Pingdom 404 error, e.g. for a check
+// deleted out-of-band.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is a Pingdom 401 error, the API token
+// is missing or invalid.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is a Pingdom 403 error, the API token
+// lacks the permission for the request.
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsRateLimited reports whether err is a Pingdom 429 error, left after the
+// client gave up retrying.
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+// IsServerError reports whether err is a Pingdom 5xx error.
+func IsServerError(err error) bool {
+	code := StatusCode(err)
+	return code >= 500 && code <= 599
+}
@@ -0,0 +1,46 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorStatusHelpers(t *testing.T) {
+	notFound := &PingdomError{StatusCode: 404}
+	assert.Equal(t, 404, StatusCode(notFound))
+	assert.True(t, IsNotFound(notFound))
+	assert.False(t, IsRateLimited(notFound))
+
+	assert.True(t, IsUnauthorized(&PingdomError{StatusCode: 401}))
+	assert.True(t, IsForbidden(&PingdomError{StatusCode: 403}))
+	assert.True(t, IsRateLimited(&PingdomError{StatusCode: 429}))
+	assert.True(t, IsServerError(&PingdomError{StatusCode: 503}))
+	assert.False(t, IsServerError(&PingdomError{StatusCode: 400}))
+
+	assert.Equal(t, 0, StatusCode(errors.New("connection refused")))
+	assert.Equal(t, 0, StatusCode(nil))
+	assert.False(t, IsNotFound(ErrMissingId))
+
+	wrapped := BulkItemError{Index: 1, ID: 2, Err: notFound}
+	assert.True(t, IsNotFound(wrapped))
+}
+
+func TestErrorStatusHelpersFromService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"statuscode": 404, "statusdesc": "Not Found", "errormessage": "Check not found"}}`)
+	})
+
+	_, err := client.Checks.Read(12345)
+	assert.True(t, IsNotFound(err))
+
+	err = client.Checks.BulkDelete([]int{12345}, 1)
+	assert.True(t, IsNotFound(err.(*BulkError).Errors[0]))
+}
@@ -0,0 +1,190 @@
+package pingdom
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CheckListOption is a filter of CheckService.ListWithOptions, built with
+// CheckFilter.
+type CheckListOption func(params map[string]string)
+
+// ResultsOption is a filter of CheckService.ResultsWithOptions, built with
+// ResultFilter.  It sets a field of the ResultsRequest sent.
+type ResultsOption func(request *ResultsRequest)
+
+// ProbeListOption is a filter of ProbeService.ListWithOptions, built with
+// ProbeFilter.
+type ProbeListOption func(o *probeListOptions)
+
+// CheckFilter builds the filters supported when listing checks, e.g.
+// CheckFilter.WithTags("prod").  Since each endpoint has its own option type,
+// filters can only be passed to the endpoints supporting them.
+var CheckFilter checkFilter
+
+// ResultFilter builds the filters supported when reading check results, e.g.
+// ResultFilter.WithProbes(42).
+var ResultFilter resultFilter
+
+// ProbeFilter builds the filters supported when listing probes, e.g.
+// ProbeFilter.OnlyActive().
+var ProbeFilter probeFilter
+
+type checkFilter struct{}
+
+// WithTags lists only the checks with any of the given tags.
+func (checkFilter) WithTags(tags ...string) CheckListOption {
+	return func(params map[string]string) { params["tags"] = strings.Join(tags, ",") }
+}
+
+// WithLimit limits the number of checks returned.
+func (checkFilter) WithLimit(limit int) CheckListOption {
+	return func(params map[string]string) { params["limit"] = strconv.Itoa(limit) }
+}
+
+// WithOffset skips the given number of checks, for pagination.
+func (checkFilter) WithOffset(offset int) CheckListOption {
+	return func(params map[string]string) { params["offset"] = strconv.Itoa(offset) }
+}
+
+// IncludeTags includes the tags of each check in the response.
+func (checkFilter) IncludeTags() CheckListOption {
+	return func(params map[string]string) { params["include_tags"] = "true" }
+}
+
+// IncludeSeverity includes the severity level of each check in the response.
+func (checkFilter) IncludeSeverity() CheckListOption {
+	return func(params map[string]string) { params["include_severity"] = "true" }
+}
+
+// IncludeTeams includes the teams of each check, filling TeamIds.
+func (checkFilter) IncludeTeams() CheckListOption {
+	return func(params map[string]string) { params["include_teams"] = "true" }
+}
+
+type resultFilter struct{}
+
+// WithProbes returns only the results of the given probes.
+func (resultFilter) WithProbes(ids ...int) ResultsOption {
+	return func(r *ResultsRequest) { r.Probes = ids }
+}
+
+// WithStatus returns only the results with any of the given statuses, e.g.
+// "down" or "unconfirmed".
+func (resultFilter) WithStatus(statuses ...string) ResultsOption {
+	return func(r *ResultsRequest) { r.Status = statuses }
+}
+
+// WithFrom returns only the results from the given time on.
+func (resultFilter) WithFrom(from time.Time) ResultsOption {
+	return func(r *ResultsRequest) { r.From = from.Unix() }
+}
+
+// WithTo returns only the results until the given time.
+func (resultFilter) WithTo(to time.Time) ResultsOption {
+	return func(r *ResultsRequest) { r.To = to.Unix() }
+}
+
+// WithLimit limits the number of results returned.
+func (resultFilter) WithLimit(limit int) ResultsOption {
+	return func(r *ResultsRequest) { r.Limit = limit }
+}
+
+// WithOffset skips the given number of results, for pagination.
+func (resultFilter) WithOffset(offset int) ResultsOption {
+	return func(r *ResultsRequest) { r.Offset = offset }
+}
+
+// IncludeAnalysis includes the root cause analysis id of each result.
+func (resultFilter) IncludeAnalysis() ResultsOption {
+	return func(r *ResultsRequest) { r.IncludeAnalysis = true }
+}
+
+type probeFilter struct{}
+
+// probeListOptions holds the parameters sent to Pingdom and the filters
+// applied to the response, which the API doesn't support.
+type probeListOptions struct {
+	params    map[string]string
+	regions   []string
+	countries []string
+}
+
+// OnlyActive lists only the probes currently in use.
+func (probeFilter) OnlyActive() ProbeListOption {
+	return func(o *probeListOptions) { o.params["onlyactive"] = "true" }
+}
+
+// IncludeDeleted also lists the probes which were removed, to resolve the
+// probe ids of old results.
+func (probeFilter) IncludeDeleted() ProbeListOption {
+	return func(o *probeListOptions) { o.params["includedeleted"] = "true" }
+}
+
+// WithLimit limits the number of probes returned.
+func (probeFilter) WithLimit(limit int) ProbeListOption {
+	return func(o *probeListOptions) { o.params["limit"] = strconv.Itoa(limit) }
+}
+
+// WithOffset skips the given number of probes, for pagination.
+func (probeFilter) WithOffset(offset int) ProbeListOption {
+	return func(o *probeListOptions) { o.params["offset"] = strconv.Itoa(offset) }
+}
+
+// WithRegions lists only the probes in any of the given regions, e.g.
+// RegionEurope.  The filter is applied to the response, after any limit.
+func (probeFilter) WithRegions(regions ...Region) ProbeListOption {
+	return func(o *probeListOptions) {
+		for _, r := range regions {
+			o.regions = append(o.regions, string(r))
+		}
+	}
+}
+
+// WithCountries lists only the probes in any of the given countries, by ISO
+// code, e.g. "US".  The filter is applied to the response, after any limit.
+func (probeFilter) WithCountries(countries ...string) ProbeListOption {
+	return func(o *probeListOptions) { o.countries = append(o.countries, countries...) }
+}
+
+// match returns whether the probe passes the region and country filters.
+func (o *probeListOptions) match(p ProbeResponse) bool {
+	return matchesAny(p.Region, o.regions) && matchesAny(p.CountryISO, o.countries)
+}
+
+// matchesAny returns whether s is one of values, ignoring case, or values is
+// empty.
+func matchesAny(s string, values []string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListWithOptions returns a page of checks like ListWithMeta, taking typed
+// filters instead of raw parameters.
+func (cs *CheckService) ListWithOptions(opts ...CheckListOption) (*CheckList, error) {
+	params := map[string]string{}
+	for _, opt := range opts {
+		opt(params)
+	}
+	return cs.ListWithMeta(params)
+}
+
+// ResultsWithOptions returns the results of a check, taking typed filters.
+//
+// Deprecated: use ResultsService.List, which takes the same filters as a
+// ResultsRequest.
+func (cs *CheckService) ResultsWithOptions(id int, opts ...ResultsOption) (*ResultsResponse, error) {
+	request := ResultsRequest{Id: id}
+	for _, opt := range opts {
+		opt(&request)
+	}
+	return cs.client.Results.List(request)
+}
@@ -0,0 +1,72 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckServiceListWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, url.Values{
+			"tags":          {"prod,web"},
+			"limit":         {"10"},
+			"offset":        {"20"},
+			"include_tags":  {"true"},
+			"include_teams": {"true"},
+		}, r.URL.Query())
+		fmt.Fprint(w, `{"checks": [{"id": 1, "name": "one", "teams": [{"id": 7}]}], "counts": {"total": 21}}`)
+	})
+
+	l, err := client.Checks.ListWithOptions(
+		CheckFilter.WithTags("prod", "web"),
+		CheckFilter.WithLimit(10),
+		CheckFilter.WithOffset(20),
+		CheckFilter.IncludeTags(),
+		CheckFilter.IncludeTeams(),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 21, l.Meta.Total)
+	assert.Equal(t, 10, l.Meta.Limit)
+	assert.Equal(t, []int{7}, l.Checks[0].TeamIds)
+}
+
+func TestCheckServiceResultsWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, url.Values{
+			"probes":          {"42,43"},
+			"status":          {"down"},
+			"from":            {"1600000000"},
+			"to":              {"1600003600"},
+			"limit":           {"100"},
+			"offset":          {"20"},
+			"includeanalysis": {"true"},
+		}, r.URL.Query())
+		fmt.Fprint(w, `{"activeprobes": [42], "results": [{"probeid": 42, "time": 1600000060, "status": "down"}]}`)
+	})
+
+	results, err := client.Checks.ResultsWithOptions(12345,
+		ResultFilter.WithProbes(42, 43),
+		ResultFilter.WithStatus("down"),
+		ResultFilter.WithFrom(time.Unix(1600000000, 0)),
+		ResultFilter.WithTo(time.Unix(1600003600, 0)),
+		ResultFilter.WithLimit(100),
+		ResultFilter.WithOffset(20),
+		ResultFilter.IncludeAnalysis(),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{42}, results.ActiveProbes)
+	assert.Equal(t, "down", results.Results[0].Status)
+}
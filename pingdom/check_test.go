@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -299,6 +300,25 @@ func TestCheckServiceDelete(t *testing.T) {
 	assert.Equal(t, want, msg)
 }
 
+func TestCheckServiceSetPaused(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		assert.Equal(t, "1,2", r.FormValue("checkids"))
+		assert.Equal(t, "true", r.FormValue("paused"))
+		fmt.Fprint(w, `{"message":"Modification of 2 checks was successful!"}`)
+	})
+
+	msg, err := client.Checks.SetPaused(true, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Modification of 2 checks was successful!"}, msg)
+
+	_, err = client.Checks.SetPaused(false)
+	assert.Error(t, err)
+}
+
 func TestCheckServiceSummaryPerformance(t *testing.T) {
 	id := 1337
 	t.Run("passes on error from API", func(t *testing.T) {
@@ -458,3 +478,82 @@ func TestCheckServiceResults(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, results)
 }
+
+func TestCheckServiceListWithMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		assert.Equal(t, "4", r.URL.Query().Get("offset"))
+		w.Header().Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+		fmt.Fprint(w, `{
+			"checks": [
+				{"id": 85975, "name": "My check 1"},
+				{"id": 161748, "name": "My check 2"}
+			],
+			"counts": {
+				"total": 12,
+				"limited": 2,
+				"filtered": 12
+			}
+		}`)
+	})
+
+	want := &CheckList{
+		Checks: []CheckResponse{
+			{ID: 85975, Name: "My check 1"},
+			{ID: 161748, Name: "My check 2"},
+		},
+		Meta: ListMeta{
+			Total:     12,
+			Limit:     2,
+			Offset:    4,
+			RateLimit: &RateLimit{ShortRemaining: 394, ShortReset: 3589 * time.Second},
+		},
+	}
+
+	list, err := client.Checks.ListWithMeta(map[string]string{"limit": "2", "offset": "4"})
+	assert.NoError(t, err)
+	assert.Equal(t, want, list)
+}
+
+func TestCheckServiceListWithMetaFiltered(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "prod", r.URL.Query().Get("tags"))
+		fmt.Fprint(w, `{
+			"checks": [{"id": 85975, "name": "My check 1"}],
+			"counts": {"total": 12, "limited": 1, "filtered": 3}
+		}`)
+	})
+
+	list, err := client.Checks.ListWithMeta(map[string]string{"tags": "prod", "limit": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, list.Meta.Total)
+}
+
+func TestCheckServiceListIncludeTeams(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "true", r.URL.Query().Get("include_teams"))
+		fmt.Fprint(w, `{
+			"checks": [
+				{"id": 85975, "name": "My check 1", "teams": [{"id": 123, "name": "Ops"}, {"id": 456, "name": "Web"}]},
+				{"id": 161748, "name": "My check 2"}
+			]
+		}`)
+	})
+
+	checks, err := client.Checks.List(map[string]string{"include_teams": "true"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{123, 456}, checks[0].TeamIds)
+	assert.Nil(t, checks[1].TeamIds)
+}
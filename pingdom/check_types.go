@@ -2,10 +2,22 @@ package pingdom
 
 import (
 	"fmt"
+	"net/url"
 	"sort"
 	"strconv"
 )
 
+// Kinds of checks, as used in the `type` parameter of the Pingdom API.
+const (
+	CheckKindHTTP = "http"
+	CheckKindPing = "ping"
+	CheckKindTCP  = "tcp"
+	CheckKindDNS  = "dns"
+	CheckKindSMTP = "smtp"
+	CheckKindPOP3 = "pop3"
+	CheckKindIMAP = "imap"
+)
+
 // HttpCheck represents a Pingdom HTTP check.
 type HttpCheck struct {
 	Name                     string            `json:"name"`
@@ -70,6 +82,87 @@ type TCPCheck struct {
 	StringToExpect           string `json:"stringtoexpect,omitempty"`
 }
 
+// DNSCheck represents a Pingdom DNS check, which resolves Hostname on
+// NameServer and expects ExpectedIP among the answers.
+type DNSCheck struct {
+	Name                     string `json:"name"`
+	Hostname                 string `json:"hostname,omitempty"`
+	Resolution               int    `json:"resolution,omitempty"`
+	Paused                   bool   `json:"paused,omitempty"`
+	SendNotificationWhenDown int    `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int    `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
+	IntegrationIds           []int  `json:"integrationids,omitempty"`
+	Tags                     string `json:"tags,omitempty"`
+	ProbeFilters             string `json:"probe_filters,omitempty"`
+	UserIds                  []int  `json:"userids,omitempty"`
+	TeamIds                  []int  `json:"teamids,omitempty"`
+	NameServer               string `json:"nameserver"`
+	ExpectedIP               string `json:"expectedip"`
+}
+
+// SMTPCheck represents a Pingdom SMTP check.  Port defaults to 25, or 465
+// with Encryption.
+type SMTPCheck struct {
+	Name                     string `json:"name"`
+	Hostname                 string `json:"hostname,omitempty"`
+	Resolution               int    `json:"resolution,omitempty"`
+	Paused                   bool   `json:"paused,omitempty"`
+	SendNotificationWhenDown int    `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int    `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
+	IntegrationIds           []int  `json:"integrationids,omitempty"`
+	Tags                     string `json:"tags,omitempty"`
+	ProbeFilters             string `json:"probe_filters,omitempty"`
+	UserIds                  []int  `json:"userids,omitempty"`
+	TeamIds                  []int  `json:"teamids,omitempty"`
+	Port                     int    `json:"port,omitempty"`
+	Username                 string `json:"username,omitempty"`
+	Password                 string `json:"password,omitempty"`
+	StringToExpect           string `json:"stringtoexpect,omitempty"`
+	Encryption               bool   `json:"encryption,omitempty"`
+}
+
+// POP3Check represents a Pingdom POP3 check.  Port defaults to 110, or 995
+// with Encryption.
+type POP3Check struct {
+	Name                     string `json:"name"`
+	Hostname                 string `json:"hostname,omitempty"`
+	Resolution               int    `json:"resolution,omitempty"`
+	Paused                   bool   `json:"paused,omitempty"`
+	SendNotificationWhenDown int    `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int    `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
+	IntegrationIds           []int  `json:"integrationids,omitempty"`
+	Tags                     string `json:"tags,omitempty"`
+	ProbeFilters             string `json:"probe_filters,omitempty"`
+	UserIds                  []int  `json:"userids,omitempty"`
+	TeamIds                  []int  `json:"teamids,omitempty"`
+	Port                     int    `json:"port,omitempty"`
+	StringToExpect           string `json:"stringtoexpect,omitempty"`
+	Encryption               bool   `json:"encryption,omitempty"`
+}
+
+// IMAPCheck represents a Pingdom IMAP check.  Port defaults to 143, or 993
+// with Encryption.
+type IMAPCheck struct {
+	Name                     string `json:"name"`
+	Hostname                 string `json:"hostname,omitempty"`
+	Resolution               int    `json:"resolution,omitempty"`
+	Paused                   bool   `json:"paused,omitempty"`
+	SendNotificationWhenDown int    `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int    `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
+	IntegrationIds           []int  `json:"integrationids,omitempty"`
+	Tags                     string `json:"tags,omitempty"`
+	ProbeFilters             string `json:"probe_filters,omitempty"`
+	UserIds                  []int  `json:"userids,omitempty"`
+	TeamIds                  []int  `json:"teamids,omitempty"`
+	Port                     int    `json:"port,omitempty"`
+	StringToExpect           string `json:"stringtoexpect,omitempty"`
+	Encryption               bool   `json:"encryption,omitempty"`
+}
+
 // SummaryPerformanceRequest is the API request to Pingdom for a SummaryPerformance.
 type SummaryPerformanceRequest struct {
 	Id            int
@@ -81,6 +174,14 @@ type SummaryPerformanceRequest struct {
 	Order         string
 }
 
+// SummaryOutageRequest is the API request to Pingdom for a SummaryOutage.
+type SummaryOutageRequest struct {
+	Id    int
+	From  int64
+	To    int64
+	Order string
+}
+
 // PutParams returns a map of parameters for an HttpCheck that can be sent along
 // with an HTTP PUT request.
 func (ck *HttpCheck) PutParams() map[string]string {
@@ -159,32 +260,31 @@ func (ck *HttpCheck) PostParams() map[string]string {
 			delete(params, k)
 		}
 	}
-	params["type"] = "http"
+	params["type"] = ck.Kind()
 
 	return params
 }
 
+// CheckName returns the name of the HttpCheck.
+func (ck *HttpCheck) CheckName() string {
+	return ck.Name
+}
+
+// Kind returns the Pingdom type of the HttpCheck.
+func (ck *HttpCheck) Kind() string {
+	return CheckKindHTTP
+}
+
 // Valid determines whether the HttpCheck contains valid fields.  This can be
 // used to guard against sending illegal values to the Pingdom API.
 func (ck *HttpCheck) Valid() error {
-	if ck.Name == "" {
-		return fmt.Errorf("Invalid value for `Name`.  Must contain non-empty string")
-	}
-
-	if ck.Hostname == "" {
-		return fmt.Errorf("Invalid value for `Hostname`.  Must contain non-empty string")
-	}
-
-	if ck.Resolution != 1 && ck.Resolution != 5 && ck.Resolution != 15 &&
-		ck.Resolution != 30 && ck.Resolution != 60 {
-		return fmt.Errorf("invalid value %v for `Resolution`, allowed values are [1,5,15,30,60]", ck.Resolution)
-	}
+	errs := validCheck(ck.Name, ck.Hostname, ck.Resolution, ck.ProbeFilters)
 
 	if ck.ShouldContain != "" && ck.ShouldNotContain != "" {
-		return fmt.Errorf("`ShouldContain` and `ShouldNotContain` must not be declared at the same time")
+		errs.add(fmt.Errorf("`ShouldContain` and `ShouldNotContain` must not be declared at the same time"))
 	}
 
-	return nil
+	return errs.err()
 }
 
 // PutParams returns a map of parameters for a PingCheck that can be sent along
@@ -203,6 +303,10 @@ func (ck *PingCheck) PutParams() map[string]string {
 		"teamids":          intListToCDString(ck.TeamIds),
 	}
 
+	if ck.Tags != "" {
+		m["tags"] = ck.Tags
+	}
+
 	if ck.SendNotificationWhenDown != 0 {
 		m["sendnotificationwhendown"] = strconv.Itoa(ck.SendNotificationWhenDown)
 	}
@@ -225,26 +329,24 @@ func (ck *PingCheck) PostParams() map[string]string {
 		}
 	}
 
-	params["type"] = "ping"
+	params["type"] = ck.Kind()
 	return params
 }
 
+// CheckName returns the name of the PingCheck.
+func (ck *PingCheck) CheckName() string {
+	return ck.Name
+}
+
+// Kind returns the Pingdom type of the PingCheck.
+func (ck *PingCheck) Kind() string {
+	return CheckKindPing
+}
+
 // Valid determines whether the PingCheck contains valid fields.  This can be
 // used to guard against sending illegal values to the Pingdom API.
 func (ck *PingCheck) Valid() error {
-	if ck.Name == "" {
-		return fmt.Errorf("Invalid value for `Name`.  Must contain non-empty string")
-	}
-
-	if ck.Hostname == "" {
-		return fmt.Errorf("Invalid value for `Hostname`.  Must contain non-empty string")
-	}
-
-	if ck.Resolution != 1 && ck.Resolution != 5 && ck.Resolution != 15 &&
-		ck.Resolution != 30 && ck.Resolution != 60 {
-		return fmt.Errorf("invalid value %v for `Resolution`, allowed values are [1,5,15,30,60]", ck.Resolution)
-	}
-	return nil
+	return validCheck(ck.Name, ck.Hostname, ck.Resolution, ck.ProbeFilters).err()
 }
 
 // PutParams returns a map of parameters for a TCPCheck that can be sent along
@@ -291,33 +393,257 @@ func (ck *TCPCheck) PostParams() map[string]string {
 		}
 	}
 
-	params["type"] = "tcp"
+	params["type"] = ck.Kind()
 	return params
 }
 
+// CheckName returns the name of the TCPCheck.
+func (ck *TCPCheck) CheckName() string {
+	return ck.Name
+}
+
+// Kind returns the Pingdom type of the TCPCheck.
+func (ck *TCPCheck) Kind() string {
+	return CheckKindTCP
+}
+
 // Valid determines whether the TCPCheck contains valid fields.  This can be
 // used to guard against sending illegal values to the Pingdom API.
 func (ck *TCPCheck) Valid() error {
-	if ck.Name == "" {
-		return fmt.Errorf("invalid value for `Name`, must contain non-empty string")
+	errs := validCheck(ck.Name, ck.Hostname, ck.Resolution, ck.ProbeFilters)
+
+	if ck.Port < 1 {
+		errs.add(fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1"))
 	}
 
-	if ck.Hostname == "" {
-		return fmt.Errorf("invalid value for `Hostname`, must contain non-empty string")
+	return errs.err()
+}
+
+// PutParams returns a map of parameters for a DNSCheck that can be sent along
+// with an HTTP PUT request.
+func (ck *DNSCheck) PutParams() map[string]string {
+	m := checkParams(ck.Name, ck.Hostname, ck.Resolution, ck.Paused, ck.SendNotificationWhenDown, ck.NotifyAgainEvery,
+		ck.NotifyWhenBackup, ck.IntegrationIds, ck.UserIds, ck.TeamIds, ck.Tags, ck.ProbeFilters)
+	m["nameserver"] = ck.NameServer
+	m["expectedip"] = ck.ExpectedIP
+
+	return m
+}
+
+// PostParams returns a map of parameters for a DNSCheck that can be sent along
+// with an HTTP POST request. Same as PUT, without the empty values.
+func (ck *DNSCheck) PostParams() map[string]string {
+	return postParams(ck)
+}
+
+// CheckName returns the name of the DNSCheck.
+func (ck *DNSCheck) CheckName() string {
+	return ck.Name
+}
+
+// Kind returns the Pingdom type of the DNSCheck.
+func (ck *DNSCheck) Kind() string {
+	return CheckKindDNS
+}
+
+// Valid determines whether the DNSCheck contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *DNSCheck) Valid() error {
+	errs := validCheck(ck.Name, ck.Hostname, ck.Resolution, ck.ProbeFilters)
+
+	if ck.NameServer == "" {
+		errs.add(fmt.Errorf("Invalid value for `NameServer`.  Must contain non-empty string"))
 	}
 
-	if ck.Resolution != 1 && ck.Resolution != 5 && ck.Resolution != 15 &&
-		ck.Resolution != 30 && ck.Resolution != 60 {
-		return fmt.Errorf("invalid value %v for `Resolution`, allowed values are [1,5,15,30,60]", ck.Resolution)
+	if ck.ExpectedIP == "" {
+		errs.add(fmt.Errorf("Invalid value for `ExpectedIP`.  Must contain non-empty string"))
 	}
 
-	if ck.Port < 1 {
-		return fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1")
+	return errs.err()
+}
+
+// PutParams returns a map of parameters for a SMTPCheck that can be sent along
+// with an HTTP PUT request.
+func (ck *SMTPCheck) PutParams() map[string]string {
+	m := checkParams(ck.Name, ck.Hostname, ck.Resolution, ck.Paused, ck.SendNotificationWhenDown, ck.NotifyAgainEvery,
+		ck.NotifyWhenBackup, ck.IntegrationIds, ck.UserIds, ck.TeamIds, ck.Tags, ck.ProbeFilters)
+	m["encryption"] = strconv.FormatBool(ck.Encryption)
+
+	if ck.Port != 0 {
+		m["port"] = strconv.Itoa(ck.Port)
+	}
+
+	if ck.Username != "" {
+		m["auth"] = fmt.Sprintf("%s:%s", ck.Username, ck.Password)
+	}
+
+	if ck.StringToExpect != "" {
+		m["stringtoexpect"] = ck.StringToExpect
 	}
 
+	return m
+}
+
+// PostParams returns a map of parameters for a SMTPCheck that can be sent along
+// with an HTTP POST request. Same as PUT, without the empty values.
+func (ck *SMTPCheck) PostParams() map[string]string {
+	return postParams(ck)
+}
+
+// CheckName returns the name of the SMTPCheck.
+func (ck *SMTPCheck) CheckName() string {
+	return ck.Name
+}
+
+// Kind returns the Pingdom type of the SMTPCheck.
+func (ck *SMTPCheck) Kind() string {
+	return CheckKindSMTP
+}
+
+// Valid determines whether the SMTPCheck contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *SMTPCheck) Valid() error {
+	errs := validCheck(ck.Name, ck.Hostname, ck.Resolution, ck.ProbeFilters)
+	errs.add(validPort(ck.Port))
+
+	if ck.Password != "" && ck.Username == "" {
+		errs.add(fmt.Errorf("Invalid value for `Username`.  Must be set along with `Password`"))
+	}
+
+	return errs.err()
+}
+
+// PutParams returns a map of parameters for a POP3Check that can be sent along
+// with an HTTP PUT request.
+func (ck *POP3Check) PutParams() map[string]string {
+	m := checkParams(ck.Name, ck.Hostname, ck.Resolution, ck.Paused, ck.SendNotificationWhenDown, ck.NotifyAgainEvery,
+		ck.NotifyWhenBackup, ck.IntegrationIds, ck.UserIds, ck.TeamIds, ck.Tags, ck.ProbeFilters)
+	m["encryption"] = strconv.FormatBool(ck.Encryption)
+
+	if ck.Port != 0 {
+		m["port"] = strconv.Itoa(ck.Port)
+	}
+
+	if ck.StringToExpect != "" {
+		m["stringtoexpect"] = ck.StringToExpect
+	}
+
+	return m
+}
+
+// PostParams returns a map of parameters for a POP3Check that can be sent along
+// with an HTTP POST request. Same as PUT, without the empty values.
+func (ck *POP3Check) PostParams() map[string]string {
+	return postParams(ck)
+}
+
+// CheckName returns the name of the POP3Check.
+func (ck *POP3Check) CheckName() string {
+	return ck.Name
+}
+
+// Kind returns the Pingdom type of the POP3Check.
+func (ck *POP3Check) Kind() string {
+	return CheckKindPOP3
+}
+
+// Valid determines whether the POP3Check contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *POP3Check) Valid() error {
+	errs := validCheck(ck.Name, ck.Hostname, ck.Resolution, ck.ProbeFilters)
+	errs.add(validPort(ck.Port))
+	return errs.err()
+}
+
+// PutParams returns a map of parameters for a IMAPCheck that can be sent along
+// with an HTTP PUT request.
+func (ck *IMAPCheck) PutParams() map[string]string {
+	m := checkParams(ck.Name, ck.Hostname, ck.Resolution, ck.Paused, ck.SendNotificationWhenDown, ck.NotifyAgainEvery,
+		ck.NotifyWhenBackup, ck.IntegrationIds, ck.UserIds, ck.TeamIds, ck.Tags, ck.ProbeFilters)
+	m["encryption"] = strconv.FormatBool(ck.Encryption)
+
+	if ck.Port != 0 {
+		m["port"] = strconv.Itoa(ck.Port)
+	}
+
+	if ck.StringToExpect != "" {
+		m["stringtoexpect"] = ck.StringToExpect
+	}
+
+	return m
+}
+
+// PostParams returns a map of parameters for a IMAPCheck that can be sent along
+// with an HTTP POST request. Same as PUT, without the empty values.
+func (ck *IMAPCheck) PostParams() map[string]string {
+	return postParams(ck)
+}
+
+// CheckName returns the name of the IMAPCheck.
+func (ck *IMAPCheck) CheckName() string {
+	return ck.Name
+}
+
+// Kind returns the Pingdom type of the IMAPCheck.
+func (ck *IMAPCheck) Kind() string {
+	return CheckKindIMAP
+}
+
+// Valid determines whether the IMAPCheck contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *IMAPCheck) Valid() error {
+	errs := validCheck(ck.Name, ck.Hostname, ck.Resolution, ck.ProbeFilters)
+	errs.add(validPort(ck.Port))
+	return errs.err()
+}
+
+// validPort validates an optional port.
+func validPort(port int) error {
+	if port < 0 || port > 65535 {
+		return fmt.Errorf("Invalid value for `Port`.  Must be between 1 and 65535")
+	}
 	return nil
 }
 
+// checkParams returns the parameters shared by every check type.
+func checkParams(name, host string, resolution int, paused bool, whenDown, againEvery int, whenBackUp bool,
+	integrationIDs, userIDs, teamIDs []int, tags, probeFilters string) map[string]string {
+	m := map[string]string{
+		"name":             name,
+		"host":             host,
+		"resolution":       strconv.Itoa(resolution),
+		"paused":           strconv.FormatBool(paused),
+		"notifyagainevery": strconv.Itoa(againEvery),
+		"notifywhenbackup": strconv.FormatBool(whenBackUp),
+		"integrationids":   intListToCDString(integrationIDs),
+		"probe_filters":    probeFilters,
+		"tags":             tags,
+		"userids":          intListToCDString(userIDs),
+		"teamids":          intListToCDString(teamIDs),
+	}
+
+	if whenDown != 0 {
+		m["sendnotificationwhendown"] = strconv.Itoa(whenDown)
+	}
+
+	return m
+}
+
+// postParams returns the PutParams of a check without the empty values,
+// along with its type.
+func postParams(ck CheckConfig) map[string]string {
+	params := ck.PutParams()
+
+	for k, v := range params {
+		if v == "" {
+			delete(params, k)
+		}
+	}
+
+	params["type"] = ck.Kind()
+	return params
+}
+
 func intListToCDString(integers []int) string {
 	var CDString string
 	for i, item := range integers {
@@ -339,19 +665,70 @@ func (csr SummaryPerformanceRequest) Valid() error {
 	if csr.Resolution != "" && csr.Resolution != "hour" && csr.Resolution != "day" && csr.Resolution != "week" {
 		return ErrBadResolution
 	}
+
+	if csr.Order != "" && csr.Order != "asc" && csr.Order != "desc" {
+		return ErrBadOrder
+	}
 	return nil
 }
 
-// GetParams returns a map of params for a Pingdom SummaryPerformanceRequest.
-func (csr SummaryPerformanceRequest) GetParams() (params map[string]string) {
-	params = make(map[string]string)
+// GetParams returns the query params for a Pingdom SummaryPerformanceRequest.
+func (csr SummaryPerformanceRequest) GetParams() (params url.Values) {
+	params = url.Values{}
+
+	if csr.From != 0 {
+		params.Set("from", strconv.Itoa(csr.From))
+	}
+
+	if csr.To != 0 {
+		params.Set("to", strconv.Itoa(csr.To))
+	}
 
 	if csr.Resolution != "" {
-		params["resolution"] = csr.Resolution
+		params.Set("resolution", csr.Resolution)
 	}
 
 	if csr.IncludeUptime {
-		params["includeuptime"] = "true"
+		params.Set("includeuptime", "true")
+	}
+
+	if csr.Probes != "" {
+		params.Set("probes", csr.Probes)
+	}
+
+	if csr.Order != "" {
+		params.Set("order", csr.Order)
+	}
+
+	return
+}
+
+// Valid determines whether a SummaryOutageRequest contains valid fields for the Pingdom API.
+func (csr SummaryOutageRequest) Valid() error {
+	if csr.Id == 0 {
+		return ErrMissingId
+	}
+
+	if csr.Order != "" && csr.Order != "asc" && csr.Order != "desc" {
+		return ErrBadOrder
+	}
+	return nil
+}
+
+// GetParams returns the query params for a Pingdom SummaryOutageRequest.
+func (csr SummaryOutageRequest) GetParams() (params url.Values) {
+	params = url.Values{}
+
+	if csr.From != 0 {
+		params.Set("from", strconv.FormatInt(csr.From, 10))
+	}
+
+	if csr.To != 0 {
+		params.Set("to", strconv.FormatInt(csr.To, 10))
+	}
+
+	if csr.Order != "" {
+		params.Set("order", csr.Order)
 	}
 
 	return
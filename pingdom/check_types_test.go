@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -298,7 +299,7 @@ func TestSummaryPerformanceRequestValid(t *testing.T) {
 func TestSummaryPerformanceRequestGetParams(t *testing.T) {
 	id := 1337
 	t.Run("empty request", func(t *testing.T) {
-		want := map[string]string{}
+		want := url.Values{}
 
 		params := SummaryPerformanceRequest{
 			Id: id,
@@ -308,9 +309,9 @@ func TestSummaryPerformanceRequestGetParams(t *testing.T) {
 	})
 
 	t.Run("with some params", func(t *testing.T) {
-		want := map[string]string{
-			"resolution":    "week",
-			"includeuptime": "true",
+		want := url.Values{
+			"resolution":    {"week"},
+			"includeuptime": {"true"},
 		}
 
 		params := SummaryPerformanceRequest{
@@ -321,4 +322,112 @@ func TestSummaryPerformanceRequestGetParams(t *testing.T) {
 
 		assert.Equal(t, want, params)
 	})
+
+	t.Run("with all params", func(t *testing.T) {
+		want := url.Values{
+			"from":          {"10"},
+			"to":            {"20"},
+			"resolution":    {"day"},
+			"includeuptime": {"true"},
+			"probes":        {"42,43"},
+			"order":         {"desc"},
+		}
+
+		params := SummaryPerformanceRequest{
+			Id:            id,
+			From:          10,
+			To:            20,
+			Resolution:    "day",
+			IncludeUptime: true,
+			Probes:        "42,43",
+			Order:         "desc",
+		}.GetParams()
+
+		assert.Equal(t, want, params)
+	})
+}
+
+func TestCheckConfig(t *testing.T) {
+	checks := []CheckConfig{
+		&HttpCheck{Name: "http check", Hostname: "example.com", Resolution: 5},
+		&PingCheck{Name: "ping check", Hostname: "example.com", Resolution: 5},
+		&TCPCheck{Name: "tcp check", Hostname: "example.com", Resolution: 5, Port: 25},
+	}
+	wantKinds := []string{CheckKindHTTP, CheckKindPing, CheckKindTCP}
+
+	for i, ck := range checks {
+		assert.NoError(t, ck.Valid())
+		assert.Equal(t, wantKinds[i], ck.Kind())
+		assert.Equal(t, wantKinds[i]+" check", ck.CheckName())
+		assert.Equal(t, ck.Kind(), ck.PostParams()["type"])
+	}
+}
+
+func TestPingCheckPutParamsTags(t *testing.T) {
+	check := PingCheck{Name: "fake check", Hostname: "example.com", Tags: "prod,web"}
+	assert.Equal(t, "prod,web", check.PutParams()["tags"])
+	assert.Equal(t, "prod,web", check.PostParams()["tags"])
+}
+
+func TestDNSCheckParams(t *testing.T) {
+	check := DNSCheck{
+		Name:       "fake check",
+		Hostname:   "example.com",
+		Resolution: 5,
+		NameServer: "ns1.example.com",
+		ExpectedIP: "192.0.2.10",
+		Tags:       "dns",
+	}
+	assert.NoError(t, check.Valid())
+	assert.Equal(t, map[string]string{
+		"name":             "fake check",
+		"host":             "example.com",
+		"resolution":       "5",
+		"paused":           "false",
+		"notifyagainevery": "0",
+		"notifywhenbackup": "false",
+		"tags":             "dns",
+		"nameserver":       "ns1.example.com",
+		"expectedip":       "192.0.2.10",
+		"type":             "dns",
+	}, check.PostParams())
+
+	check.ExpectedIP = ""
+	assert.Error(t, check.Valid())
+}
+
+func TestMailCheckParams(t *testing.T) {
+	smtp := SMTPCheck{
+		Name:           "fake check",
+		Hostname:       "mail.example.com",
+		Resolution:     5,
+		Port:           587,
+		Username:       "monitor",
+		Password:       "secret",
+		StringToExpect: "ESMTP",
+		Encryption:     true,
+	}
+	assert.NoError(t, smtp.Valid())
+	params := smtp.PostParams()
+	assert.Equal(t, "smtp", params["type"])
+	assert.Equal(t, "587", params["port"])
+	assert.Equal(t, "monitor:secret", params["auth"])
+	assert.Equal(t, "ESMTP", params["stringtoexpect"])
+	assert.Equal(t, "true", params["encryption"])
+
+	smtp.Username = ""
+	assert.Error(t, smtp.Valid())
+
+	pop3 := POP3Check{Name: "fake check", Hostname: "mail.example.com", Resolution: 5}
+	assert.NoError(t, pop3.Valid())
+	params = pop3.PostParams()
+	assert.Equal(t, "pop3", params["type"])
+	assert.NotContains(t, params, "port")
+	assert.Equal(t, "false", params["encryption"])
+
+	imap := IMAPCheck{Name: "fake check", Hostname: "mail.example.com", Resolution: 5, Port: 70000}
+	assert.Error(t, imap.Valid())
+	imap.Port = 993
+	assert.NoError(t, imap.Valid())
+	assert.Equal(t, "imap", imap.PostParams()["type"])
 }
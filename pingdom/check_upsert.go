@@ -0,0 +1,118 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateOrUpdate updates the check with the name and type of check, or
+// creates it when there is none, so that running it again doesn't create
+// duplicates.  The check returned holds the id and the name.  A check of
+// another type with the same name is an error, Pingdom can't change the type
+// of a check, as are several checks matching, see ErrAmbiguousCheck.
+func (cs *CheckService) CreateOrUpdate(check CheckConfig) (*CheckResponse, error) {
+	return cs.CreateOrUpdateWithContext(context.Background(), check)
+}
+
+// CreateOrUpdateWithContext is like CreateOrUpdate, the requests are bound to
+// ctx so they can be canceled or given a deadline.
+func (cs *CheckService) CreateOrUpdateWithContext(ctx context.Context, check CheckConfig) (*CheckResponse, error) {
+	if err := check.Valid(); err != nil {
+		return nil, err
+	}
+
+	existing, err := cs.namedLike(ctx, check)
+	if err != nil {
+		return nil, err
+	}
+	var match *CheckResponse
+	for i := range existing {
+		c := &existing[i]
+		if c.Type.Name != check.Kind() {
+			return nil, fmt.Errorf("check %q exists with type %s, not %s", check.CheckName(), c.Type.Name, check.Kind())
+		}
+		if match != nil {
+			return nil, ErrAmbiguousCheck
+		}
+		match = c
+	}
+	if match == nil {
+		return cs.CreateWithContext(ctx, check)
+	}
+
+	if _, err := cs.UpdateWithContext(ctx, match.ID, check); err != nil {
+		return nil, err
+	}
+	return &CheckResponse{ID: match.ID, Name: check.CheckName()}, nil
+}
+
+// CreateIdempotent creates a check like Create and retries once when the
+// creation failed in a way where Pingdom may have created it anyway: a
+// network error or a 5xx error.  Before retrying, it looks for a check with
+// the name, type and tags of check created since the first attempt, and
+// returns it instead of creating a duplicate.
+func (cs *CheckService) CreateIdempotent(check CheckConfig) (*CheckResponse, error) {
+	return cs.CreateIdempotentWithContext(context.Background(), check)
+}
+
+// CreateIdempotentWithContext is like CreateIdempotent, the requests are
+// bound to ctx so they can be canceled or given a deadline.
+func (cs *CheckService) CreateIdempotentWithContext(ctx context.Context, check CheckConfig) (*CheckResponse, error) {
+	if err := check.Valid(); err != nil {
+		return nil, err
+	}
+
+	// Pingdom sets the creation time of checks in seconds.
+	start := time.Now().Unix()
+	created, err := cs.CreateWithContext(ctx, check)
+	if err == nil || ctx.Err() != nil || !maybeApplied(err) {
+		return created, err
+	}
+
+	after, lookupErr := cs.namedLike(ctx, check)
+	if lookupErr != nil {
+		return nil, err
+	}
+	for _, c := range after {
+		if c.Created >= start && c.Type.Name == check.Kind() && hasTags(c, SplitTags(check.PutParams()["tags"])) {
+			return &CheckResponse{ID: c.ID, Name: c.Name}, nil
+		}
+	}
+	return cs.CreateWithContext(ctx, check)
+}
+
+// namedLike returns the checks with the name of check, with their tags.
+func (cs *CheckService) namedLike(ctx context.Context, check CheckConfig) ([]CheckResponse, error) {
+	checks, err := cs.ListAllWithContext(ctx, map[string]string{"include_tags": "true"})
+	if err != nil {
+		return nil, err
+	}
+	var named []CheckResponse
+	for _, c := range checks {
+		if c.Name == check.CheckName() {
+			named = append(named, c)
+		}
+	}
+	return named, nil
+}
+
+// maybeApplied reports whether a mutation which returned err may still have
+// been applied by Pingdom.
+func maybeApplied(err error) bool {
+	return StatusCode(err) == 0 || IsServerError(err)
+}
+
+// hasTags reports whether the check has all the tags.
+func hasTags(c CheckResponse, tags []string) bool {
+	have := map[string]bool{}
+	for _, t := range c.TagNames() {
+		have[t] = true
+	}
+	for _, t := range tags {
+		if !have[t] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeChecks serves a list of checks and records the mutations made.
+type fakeChecks struct {
+	checks []string
+	gets   int
+	posts  int
+	puts   []string
+	// postStatus is the status of the next POSTs, with whether they still
+	// create the check.
+	postStatus  int
+	postCreates bool
+}
+
+func (f *fakeChecks) handle(t *testing.T) {
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			f.gets++
+			assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+			fmt.Fprintf(w, `{"checks": [%s]}`, strings.Join(f.checks, ","))
+		case "POST":
+			f.posts++
+			id := 100 + f.posts
+			if f.postStatus == 0 || f.postCreates {
+				f.checks = append(f.checks, fmt.Sprintf(`{"id": %d, "name": %q, "type": %q, "created": %d, "tags": [{"name": "prod"}]}`,
+					id, r.URL.Query().Get("name"), r.URL.Query().Get("type"), time.Now().Unix()))
+			}
+			if f.postStatus != 0 {
+				w.WriteHeader(f.postStatus)
+				fmt.Fprint(w, `{"error": {"errormessage": "failed"}}`)
+				return
+			}
+			fmt.Fprintf(w, `{"check": {"id": %d, "name": %q}}`, id, r.URL.Query().Get("name"))
+		}
+	})
+	mux.HandleFunc("/checks/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		f.puts = append(f.puts, strings.TrimPrefix(r.URL.Path, "/checks/"))
+		fmt.Fprint(w, `{"message": "Modification of check was successful!"}`)
+	})
+}
+
+func TestCheckServiceCreateOrUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	f := &fakeChecks{checks: []string{
+		`{"id": 1, "name": "web", "type": "http"}`,
+		`{"id": 2, "name": "dup", "type": "http"}`,
+		`{"id": 3, "name": "dup", "type": "http"}`,
+		`{"id": 4, "name": "db", "type": "tcp"}`,
+	}}
+	f.handle(t)
+
+	check, err := client.Checks.CreateOrUpdate(&HttpCheck{Name: "web", Hostname: "example.com", Resolution: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, &CheckResponse{ID: 1, Name: "web"}, check)
+	assert.Equal(t, []string{"1"}, f.puts)
+	assert.Equal(t, 0, f.posts)
+
+	check, err = client.Checks.CreateOrUpdate(&HttpCheck{Name: "api", Hostname: "example.com", Resolution: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 101, check.ID)
+	assert.Equal(t, 1, f.posts)
+
+	_, err = client.Checks.CreateOrUpdate(&HttpCheck{Name: "dup", Hostname: "example.com", Resolution: 5})
+	assert.Equal(t, ErrAmbiguousCheck, err)
+
+	_, err = client.Checks.CreateOrUpdate(&HttpCheck{Name: "db", Hostname: "example.com", Resolution: 5})
+	assert.EqualError(t, err, `check "db" exists with type tcp, not http`)
+
+	_, err = client.Checks.CreateOrUpdate(&HttpCheck{Name: "web"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"1"}, f.puts)
+}
+
+func TestCheckServiceCreateIdempotent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// The check existing beforehand is not mistaken for the one created.
+	f := &fakeChecks{
+		checks:      []string{`{"id": 1, "name": "web", "type": "http", "created": 1500000000, "tags": [{"name": "prod"}]}`},
+		postStatus:  http.StatusBadGateway,
+		postCreates: true,
+	}
+	f.handle(t)
+
+	check, err := client.Checks.CreateIdempotent(&HttpCheck{Name: "web", Hostname: "example.com", Resolution: 5, Tags: "prod"})
+	assert.NoError(t, err)
+	assert.Equal(t, &CheckResponse{ID: 101, Name: "web"}, check)
+	assert.Equal(t, 1, f.posts)
+	assert.Equal(t, 1, f.gets)
+
+	// Not created by the failed attempt, so retried.
+	f.postCreates = false
+	_, err = client.Checks.CreateIdempotent(&HttpCheck{Name: "api", Hostname: "example.com", Resolution: 5})
+	assert.True(t, IsServerError(err))
+	assert.Equal(t, 3, f.posts)
+
+	// Client errors are not retried.
+	f.postStatus = http.StatusBadRequest
+	_, err = client.Checks.CreateIdempotent(&HttpCheck{Name: "api", Hostname: "example.com", Resolution: 5})
+	assert.Equal(t, http.StatusBadRequest, StatusCode(err))
+	assert.Equal(t, 4, f.posts)
+
+	f.postStatus = 0
+	check, err = client.Checks.CreateIdempotent(&HttpCheck{Name: "api", Hostname: "example.com", Resolution: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 105, check.ID)
+	assert.Equal(t, 2, f.gets, "checks are only listed after a failure")
+}
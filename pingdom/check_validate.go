@@ -0,0 +1,90 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Validate checks the check like Create would, without creating anything,
+// e.g. to lint check definitions before a deploy.  Besides the checks of
+// Valid, the contacts and teams notified must exist in the account.
+// Integrations are not verified, Pingdom does not list them.  The problems
+// found are returned as ValidationErrors, other errors come from Pingdom.
+func (cs *CheckService) Validate(check Check) error {
+	return cs.ValidateWithContext(context.Background(), check)
+}
+
+// ValidateWithContext is like Validate, the requests are bound to ctx so
+// they can be canceled or given a deadline.
+func (cs *CheckService) ValidateWithContext(ctx context.Context, check Check) error {
+	var errs ValidationErrors
+	if err := check.Valid(); err != nil {
+		if ve, ok := err.(ValidationErrors); ok {
+			errs = append(errs, ve...)
+		} else {
+			errs.add(err)
+		}
+	}
+
+	params := check.PostParams()
+	userIDs, err := cdStringToIntList(params["userids"])
+	if err != nil {
+		errs.add(fmt.Errorf("Invalid value for `UserIds`.  %v", err))
+	} else if len(userIDs) > 0 {
+		contacts, err := cs.client.Contacts.ListWithContext(ctx)
+		if err != nil {
+			return err
+		}
+		known := map[int]bool{}
+		for _, c := range contacts {
+			known[c.ID] = true
+		}
+		errs = append(errs, unknownIDs("UserIds", "contact", userIDs, known)...)
+	}
+
+	teamIDs, err := cdStringToIntList(params["teamids"])
+	if err != nil {
+		errs.add(fmt.Errorf("Invalid value for `TeamIds`.  %v", err))
+	} else if len(teamIDs) > 0 {
+		teams, err := cs.client.Teams.ListWithContext(ctx)
+		if err != nil {
+			return err
+		}
+		known := map[int]bool{}
+		for _, t := range teams {
+			known[t.ID] = true
+		}
+		errs = append(errs, unknownIDs("TeamIds", "team", teamIDs, known)...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// unknownIDs reports the ids of field which are not known.
+func unknownIDs(field, resource string, ids []int, known map[int]bool) ValidationErrors {
+	var errs ValidationErrors
+	for _, id := range ids {
+		if !known[id] {
+			errs.add(fmt.Errorf("Invalid value %d for `%s`.  No %s has this id", id, field, resource))
+		}
+	}
+	return errs
+}
+
+// cdStringToIntList parses a comma delimited list of ids, the reverse of
+// intListToCDString.
+func cdStringToIntList(s string) ([]int, error) {
+	var ids []int
+	for _, part := range SplitTags(s) {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("Must contain ids, not %q", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,59 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckServiceValidate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"contacts":[{"id":1,"name":"John"},{"id":2,"name":"Jane"}]}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"teams":[{"id":10,"name":"Ops"}]}`)
+	})
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s /checks while validating", r.Method)
+	})
+
+	assert.NoError(t, client.Checks.Validate(&HttpCheck{Name: "web", Hostname: "example.com", Resolution: 5}))
+	assert.NoError(t, client.Checks.Validate(&HttpCheck{Name: "web", Hostname: "example.com", Resolution: 5, UserIds: []int{1, 2}, TeamIds: []int{10}}))
+
+	err := client.Checks.Validate(&HttpCheck{Name: "web", Hostname: "example.com", Resolution: 5, UserIds: []int{1, 3}, TeamIds: []int{11}})
+	if assert.IsType(t, ValidationErrors{}, err) {
+		assert.Equal(t, "Invalid value 3 for `UserIds`.  No contact has this id; "+
+			"Invalid value 11 for `TeamIds`.  No team has this id", err.Error())
+	}
+
+	err = client.Checks.Validate(&PingCheck{Hostname: "example.com", Resolution: 7, UserIds: []int{4}})
+	if assert.IsType(t, ValidationErrors{}, err) {
+		assert.Len(t, err.(ValidationErrors), 3)
+		assert.Contains(t, err.Error(), "`Name`")
+		assert.Contains(t, err.Error(), "`Resolution`")
+		assert.Contains(t, err.Error(), "Invalid value 4 for `UserIds`")
+	}
+
+	err = client.Checks.Validate(&TCPCheck{Name: "smtp", Hostname: "example.com", Resolution: 5})
+	assert.Equal(t, ValidationErrors{fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1")}, err)
+}
+
+func TestCheckServiceValidateAPIError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"statuscode":403,"statusdesc":"Forbidden","errormessage":"Invalid token"}}`)
+	})
+
+	err := client.Checks.Validate(&PingCheck{Name: "gw", Hostname: "example.com", Resolution: 5, TeamIds: []int{10}})
+	assert.Equal(t, http.StatusForbidden, StatusCode(err))
+}
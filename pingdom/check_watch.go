@@ -0,0 +1,125 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// CheckEventType is the kind of change reported by CheckService.Watch.
+type CheckEventType string
+
+// Check event types.
+const (
+	CheckCreated       CheckEventType = "created"
+	CheckUpdated       CheckEventType = "updated"
+	CheckDeleted       CheckEventType = "deleted"
+	CheckStatusChanged CheckEventType = "status_changed"
+	// CheckWatchError reports a failed poll, watching goes on.
+	CheckWatchError CheckEventType = "error"
+)
+
+// CheckEvent is a change of a check detected by CheckService.Watch.
+type CheckEvent struct {
+	Type CheckEventType
+	// Check is the live check, or the last known one when deleted.
+	Check CheckResponse
+	// Previous is the check as of the previous poll, nil when created.
+	Previous *CheckResponse
+	Time     time.Time
+	// Err is set for CheckWatchError events only.
+	Err error
+}
+
+// Watch polls the checks matching params every interval and sends an event
+// for each check created, updated, deleted or whose status changed since the
+// previous poll.  The checks listed by the first poll are the baseline and are
+// not reported.  A check both updated and changing status gets both events.
+// Polls failing are reported as CheckWatchError events.
+//
+// The returned channel is closed once ctx is done.  Events are not dropped, a
+// slow receiver delays the next poll.  An interval which isn't positive is
+// reported as a single CheckWatchError event, then the channel is closed.
+func (cs *CheckService) Watch(ctx context.Context, interval time.Duration, params ...map[string]string) <-chan CheckEvent {
+	events := make(chan CheckEvent)
+	go func() {
+		defer close(events)
+
+		if interval <= 0 {
+			err := fmt.Errorf("Invalid value for `interval`.  Must be a positive duration")
+			select {
+			case events <- CheckEvent{Type: CheckWatchError, Time: time.Now(), Err: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		var snapshot *CheckSnapshot
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			delta, err := cs.ListModifiedSinceWithContext(ctx, snapshot, params...)
+			if ctx.Err() != nil {
+				return
+			}
+
+			var batch []CheckEvent
+			switch {
+			case err != nil:
+				batch = []CheckEvent{{Type: CheckWatchError, Time: time.Now(), Err: err}}
+			case snapshot != nil:
+				batch = checkEvents(snapshot, delta, time.Now())
+				snapshot = delta.Snapshot
+			default:
+				snapshot = delta.Snapshot
+			}
+
+			for _, e := range batch {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// checkEvents turns a delta against previous into events, ordered by check
+// id.
+func checkEvents(previous *CheckSnapshot, delta *CheckDelta, now time.Time) []CheckEvent {
+	var events []CheckEvent
+	for _, c := range delta.Created {
+		events = append(events, CheckEvent{Type: CheckCreated, Check: c, Time: now})
+	}
+	for _, c := range delta.Modified {
+		old := previous.Checks[c.ID]
+		events = append(events, CheckEvent{Type: CheckUpdated, Check: c, Previous: &old, Time: now})
+	}
+	for _, c := range delta.Deleted {
+		old := c
+		events = append(events, CheckEvent{Type: CheckDeleted, Check: c, Previous: &old, Time: now})
+	}
+	for id, c := range delta.Snapshot.Checks {
+		old, ok := previous.Checks[id]
+		if ok && old.Status != c.Status {
+			events = append(events, CheckEvent{Type: CheckStatusChanged, Check: c, Previous: &old, Time: now})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Check.ID != events[j].Check.ID {
+			return events[i].Check.ID < events[j].Check.ID
+		}
+		return events[i].Type == CheckUpdated && events[j].Type == CheckStatusChanged
+	})
+	return events
+}
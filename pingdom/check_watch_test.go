@@ -0,0 +1,93 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEvents(t *testing.T) {
+	now := time.Now()
+	previous := NewCheckSnapshot([]CheckResponse{
+		{ID: 1, Name: "Unchanged", Status: "up"},
+		{ID: 2, Name: "Original", Status: "up"},
+		{ID: 3, Name: "Deleted"},
+		{ID: 5, Name: "Flapping", Status: "up"},
+	})
+	current := []CheckResponse{
+		{ID: 1, Name: "Unchanged", Status: "up"},
+		{ID: 2, Name: "Renamed", Status: "down"},
+		{ID: 4, Name: "New"},
+		{ID: 5, Name: "Flapping", Status: "down"},
+	}
+	delta := &CheckDelta{
+		Created:  current[2:3],
+		Modified: current[1:2],
+		Deleted:  []CheckResponse{{ID: 3, Name: "Deleted"}},
+		Snapshot: NewCheckSnapshot(current),
+	}
+
+	events := checkEvents(previous, delta, now)
+	var got []string
+	for _, e := range events {
+		got = append(got, fmt.Sprintf("%d %s", e.Check.ID, e.Type))
+		assert.Equal(t, now, e.Time)
+	}
+	assert.Equal(t, []string{"2 updated", "2 status_changed", "3 deleted", "4 created", "5 status_changed"}, got)
+	assert.Equal(t, "Original", events[0].Previous.Name)
+	assert.Nil(t, events[3].Previous)
+	assert.Equal(t, "up", events[4].Previous.Status)
+}
+
+func TestCheckServiceWatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch polls {
+		case 1:
+			fmt.Fprint(w, `{"checks": [{"id": 1, "name": "Example", "status": "up"}]}`)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error": {"statuscode": 500, "statusdesc": "Internal Server Error", "errormessage": "oops"}}`)
+		default:
+			fmt.Fprint(w, `{"checks": [{"id": 1, "name": "Example", "status": "down"}, {"id": 2, "name": "New"}]}`)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := client.Checks.Watch(ctx, time.Millisecond)
+
+	e := <-events
+	assert.Equal(t, CheckWatchError, e.Type)
+	assert.Error(t, e.Err)
+
+	e = <-events
+	assert.Equal(t, CheckStatusChanged, e.Type)
+	assert.Equal(t, "down", e.Check.Status)
+	e = <-events
+	assert.Equal(t, CheckCreated, e.Type)
+	assert.Equal(t, 2, e.Check.ID)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestCheckServiceWatchInterval(t *testing.T) {
+	setup()
+	defer teardown()
+
+	events := client.Checks.Watch(context.Background(), 0)
+	e := <-events
+	assert.Equal(t, CheckWatchError, e.Type)
+	assert.EqualError(t, e.Err, "Invalid value for `interval`.  Must be a positive duration")
+	_, open := <-events
+	assert.False(t, open)
+}
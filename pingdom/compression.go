@@ -0,0 +1,54 @@
+package pingdom
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding returns the encodings the client asks Pingdom for.  Without
+// compression it asks for identity, or the transport would ask for gzip on its
+// own.
+func (pc *Client) acceptEncoding() string {
+	if pc.compression {
+		return "gzip"
+	}
+	return "identity"
+}
+
+// decompress replaces the body of a gzip encoded response with its decoded
+// content.  The client sets Accept-Encoding itself, so the transport leaves
+// the decoding to it.
+func decompress(resp *http.Response) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipBody decodes a gzip body, reading the gzip header on the first Read so
+// that empty bodies, e.g. of a 304 response, can still be closed.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil && b.err == nil {
+		b.zr, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
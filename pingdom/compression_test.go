@@ -0,0 +1,85 @@
+package pingdom
+
+import (
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCompression(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			fmt.Fprint(w, `{"checks":[{"id":1,"name":"plain"}]}`)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		fmt.Fprint(zw, `{"checks":[{"id":1,"name":"compressed"}]}`)
+		zw.Close()
+	})
+
+	checks, err := client.Checks.List()
+	assert.NoError(t, err)
+	assert.Equal(t, "compressed", checks[0].Name)
+
+	// A custom transport doesn't change anything.
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "key", Transport: &http.Transport{}})
+	assert.NoError(t, err)
+	c.BaseURL, _ = url.Parse(server.URL)
+	checks, err = c.Checks.List()
+	assert.NoError(t, err)
+	assert.Equal(t, "compressed", checks[0].Name)
+
+	c, err = NewClientWithConfig(ClientConfig{APIToken: "key", DisableCompression: true})
+	assert.NoError(t, err)
+	c.BaseURL, _ = url.Parse(server.URL)
+	checks, err = c.Checks.List()
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", checks[0].Name)
+}
+
+func TestClientCompressionEmptyBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.Checks.Read(1)
+	assert.Error(t, err)
+}
+
+func TestNewClientWithConfigConnections(t *testing.T) {
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken:            "key",
+		MaxIdleConnsPerHost: 200,
+		IdleConnTimeout:     time.Minute,
+	})
+	assert.NoError(t, err)
+	transport := c.client.Transport.(*http.Transport)
+	assert.Equal(t, 200, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 200, transport.MaxIdleConns)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.False(t, transport.DisableKeepAlives)
+
+	c, err = NewClientWithConfig(ClientConfig{APIToken: "key", DisableKeepAlives: true})
+	assert.NoError(t, err)
+	assert.True(t, c.client.Transport.(*http.Transport).DisableKeepAlives)
+
+	_, err = NewClientWithConfig(ClientConfig{APIToken: "key", MaxIdleConnsPerHost: -1})
+	assert.Error(t, err)
+	_, err = NewClientWithConfig(ClientConfig{APIToken: "key", Transport: &http.Transport{}, IdleConnTimeout: time.Minute})
+	assert.Error(t, err)
+	_, err = NewClientWithConfig(ClientConfig{APIToken: "key", HTTPClient: &http.Client{}, DisableKeepAlives: true})
+	assert.Error(t, err)
+}
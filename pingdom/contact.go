@@ -0,0 +1,130 @@
+package pingdom
+
+import (
+	"context"
+	"strconv"
+)
+
+// ContactService provides an interface to Pingdom alerting contacts.
+type ContactService struct {
+	client *Client
+}
+
+// List returns the alerting contacts of the account.
+func (cs *ContactService) List() ([]ContactResponse, error) {
+	return cs.ListWithContext(context.Background())
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *ContactService) ListWithContext(ctx context.Context) ([]ContactResponse, error) {
+	req, err := cs.client.NewRequest("GET", "/alerting/contacts", nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &listContactsJSONResponse{}
+	_, err = cs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Contacts, err
+}
+
+// Read returns the contact for the given ID.
+func (cs *ContactService) Read(id int) (*ContactResponse, error) {
+	return cs.ReadWithContext(context.Background(), id)
+}
+
+// ReadWithContext is like Read, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *ContactService) ReadWithContext(ctx context.Context, id int) (*ContactResponse, error) {
+	req, err := cs.client.NewRequest("GET", "/alerting/contacts/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &contactDetailsJSONResponse{}
+	_, err = cs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Contact, err
+}
+
+// Create a new contact.  Only the ID of the returned contact is set.
+func (cs *ContactService) Create(contact *Contact) (*ContactResponse, error) {
+	return cs.CreateWithContext(context.Background(), contact)
+}
+
+// CreateWithContext is like Create, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *ContactService) CreateWithContext(ctx context.Context, contact *Contact) (*ContactResponse, error) {
+	if err := contact.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewJSONRequest("POST", "/alerting/contacts", contact)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &contactDetailsJSONResponse{}
+	_, err = cs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Contact, err
+}
+
+// Update will update the contact represented by the given ID with the values
+// in the given contact.  The notification targets replace the current ones.
+func (cs *ContactService) Update(id int, contact *Contact) (*PingdomResponse, error) {
+	return cs.UpdateWithContext(context.Background(), id, contact)
+}
+
+// UpdateWithContext is like Update, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *ContactService) UpdateWithContext(ctx context.Context, id int, contact *Contact) (*PingdomResponse, error) {
+	if err := contact.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewJSONRequest("PUT", "/alerting/contacts/"+strconv.Itoa(id), contact)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &PingdomResponse{}
+	_, err = cs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, err
+}
+
+// Delete will delete the contact for the given ID.
+func (cs *ContactService) Delete(id int) (*PingdomResponse, error) {
+	return cs.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *ContactService) DeleteWithContext(ctx context.Context, id int) (*PingdomResponse, error) {
+	req, err := cs.client.NewRequest("DELETE", "/alerting/contacts/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &PingdomResponse{}
+	_, err = cs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, err
+}
@@ -0,0 +1,195 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContactDuplicates is a group of contacts which notify exactly the same
+// email addresses and phone numbers.  The contact with the lowest id is
+// proposed as the survivor.
+type ContactDuplicates struct {
+	Survivor   ContactResponse
+	Duplicates []ContactResponse
+}
+
+// ContactMergeReport describes the changes of a contact merge.
+type ContactMergeReport struct {
+	Survivor int
+	// Removed are the ids of the merged contacts.
+	Removed []int
+	// Checks maps the ids of the checks which alert a merged contact to
+	// their new user ids.
+	Checks map[int][]int
+	// Teams maps the ids of the teams with a merged contact as member to
+	// their new member ids.
+	Teams map[int][]int
+	// DryRun is true when the changes were not applied.
+	DryRun bool
+}
+
+// FindDuplicates groups contacts which have identical notification targets.
+// Email addresses are compared case-insensitively.  Contacts without any
+// target are never considered duplicates.
+func (cs *ContactService) FindDuplicates() ([]ContactDuplicates, error) {
+	contacts, err := cs.List()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string][]ContactResponse{}
+	var keys []string
+	for _, c := range contacts {
+		key := contactTargetsKey(c.NotificationTargets)
+		if key == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+
+	var dups []ContactDuplicates
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		dups = append(dups, ContactDuplicates{Survivor: group[0], Duplicates: group[1:]})
+	}
+	sort.Slice(dups, func(i, j int) bool { return dups[i].Survivor.ID < dups[j].Survivor.ID })
+	return dups, nil
+}
+
+func contactTargetsKey(t ContactNotificationTargets) string {
+	var targets []string
+	for _, e := range t.Email {
+		targets = append(targets, "email:"+strings.ToLower(strings.TrimSpace(e.Address)))
+	}
+	for _, s := range t.SMS {
+		targets = append(targets, "sms:"+s.CountryCode+s.Number)
+	}
+	sort.Strings(targets)
+	return strings.Join(targets, ";")
+}
+
+// Merge repoints every check and team using one of the duplicates to the
+// survivor, then deletes the duplicates.  With dryRun set nothing is changed
+// and the report describes what would be done; review it before merging.
+// With a ClientConfig.ManagedTag, nothing is changed when a check to repoint
+// lacks the tag, unless the context of MergeWithContext was made with Force.
+func (cs *ContactService) Merge(survivor int, duplicates []int, dryRun bool) (*ContactMergeReport, error) {
+	return cs.MergeWithContext(context.Background(), survivor, duplicates, dryRun)
+}
+
+// MergeWithContext is like Merge, the requests are bound to ctx so they can
+// be canceled or given a deadline.
+func (cs *ContactService) MergeWithContext(ctx context.Context, survivor int, duplicates []int, dryRun bool) (*ContactMergeReport, error) {
+	if len(duplicates) == 0 {
+		return nil, fmt.Errorf("Invalid value for `duplicates`.  Must contain at least one contact")
+	}
+	merged := map[int]bool{}
+	for _, id := range duplicates {
+		if id == survivor {
+			return nil, fmt.Errorf("contact %d can't be merged into itself", id)
+		}
+		merged[id] = true
+	}
+
+	report := &ContactMergeReport{
+		Survivor: survivor,
+		Removed:  duplicates,
+		Checks:   map[int][]int{},
+		Teams:    map[int][]int{},
+		DryRun:   dryRun,
+	}
+
+	guarded := cs.client.managedTag != "" && !forced(ctx)
+	var unmanaged []int
+	checks, err := cs.client.Checks.ListWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range checks {
+		check, err := cs.client.Checks.ReadWithContext(ctx, summary.ID)
+		if err != nil {
+			return nil, err
+		}
+		if ids, changed := replaceIDs(check.UserIds, merged, survivor); changed {
+			report.Checks[check.ID] = ids
+			if guarded && !hasTags(*check, []string{cs.client.managedTag}) {
+				unmanaged = append(unmanaged, check.ID)
+			}
+		}
+	}
+
+	teams, err := cs.client.Teams.ListWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	teamNames := map[int]string{}
+	for _, team := range teams {
+		members := make([]int, len(team.Members))
+		for i, m := range team.Members {
+			members[i] = m.ID
+		}
+		if ids, changed := replaceIDs(members, merged, survivor); changed {
+			report.Teams[team.ID] = ids
+			teamNames[team.ID] = team.Name
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+	if err := cs.client.unmanagedError(unmanaged); err != nil {
+		return report, err
+	}
+
+	for id, userIDs := range report.Checks {
+		params := map[string]string{"userids": intListToCDString(userIDs)}
+		req, err := cs.client.NewRequest("PUT", "/checks/"+strconv.Itoa(id), params)
+		if err != nil {
+			return report, err
+		}
+		if _, err := cs.client.Do(req.WithContext(ctx), &PingdomResponse{}); err != nil {
+			return report, err
+		}
+	}
+	for id, memberIDs := range report.Teams {
+		if _, err := cs.client.Teams.UpdateWithContext(ctx, id, &Team{Name: teamNames[id], MemberIDs: memberIDs}); err != nil {
+			return report, err
+		}
+	}
+	for _, id := range duplicates {
+		if _, err := cs.DeleteWithContext(ctx, id); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// replaceIDs replaces the merged ids with the survivor, keeping the order and
+// dropping repeated ids.
+func replaceIDs(ids []int, merged map[int]bool, survivor int) ([]int, bool) {
+	changed := false
+	seen := map[int]bool{}
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if merged[id] {
+			id = survivor
+			changed = true
+		}
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out, changed
+}
@@ -0,0 +1,93 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactServiceFindDuplicates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contacts": [
+			{"id": 3, "name": "John (old)", "notification_targets": {"email": [{"address": "John@Example.com"}]}},
+			{"id": 1, "name": "John", "notification_targets": {"email": [{"address": "john@example.com"}]}},
+			{"id": 2, "name": "Jane", "notification_targets": {"email": [{"address": "jane@example.com"}], "sms": [{"country_code": "46", "number": "5551234"}]}},
+			{"id": 4, "name": "Nobody", "notification_targets": {}},
+			{"id": 5, "name": "Nobody either", "notification_targets": {}}
+		]}`)
+	})
+
+	dups, err := client.Contacts.FindDuplicates()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(dups))
+	assert.Equal(t, 1, dups[0].Survivor.ID)
+	assert.Equal(t, 1, len(dups[0].Duplicates))
+	assert.Equal(t, 3, dups[0].Duplicates[0].ID)
+}
+
+func TestContactServiceMerge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var updatedChecks, deleted []string
+	var updatedTeam Team
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks": [{"id": 100}, {"id": 200}]}`)
+	})
+	mux.HandleFunc("/checks/100", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			updatedChecks = append(updatedChecks, r.URL.Query().Get("userids"))
+			fmt.Fprint(w, `{"message": "Modification of check was successful!"}`)
+			return
+		}
+		fmt.Fprint(w, `{"check": {"id": 100, "userids": [3, 1, 9]}}`)
+	})
+	mux.HandleFunc("/checks/200", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"check": {"id": 200, "userids": [9]}}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"teams": [{"id": 7, "name": "Ops", "members": [{"id": 3}, {"id": 8}]}]}`)
+	})
+	mux.HandleFunc("/alerting/teams/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&updatedTeam))
+		fmt.Fprint(w, `{"team": {"id": 7}}`)
+	})
+	mux.HandleFunc("/alerting/contacts/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = append(deleted, r.URL.Path)
+		fmt.Fprint(w, `{"message": "Deleted"}`)
+	})
+
+	want := &ContactMergeReport{
+		Survivor: 1,
+		Removed:  []int{3},
+		Checks:   map[int][]int{100: {1, 9}},
+		Teams:    map[int][]int{7: {1, 8}},
+		DryRun:   true,
+	}
+
+	report, err := client.Contacts.Merge(1, []int{3}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, want, report)
+	assert.Empty(t, updatedChecks)
+	assert.Empty(t, deleted)
+
+	report, err = client.Contacts.Merge(1, []int{3}, false)
+	assert.NoError(t, err)
+	assert.False(t, report.DryRun)
+	assert.Equal(t, []string{"1,9"}, updatedChecks)
+	assert.Equal(t, Team{Name: "Ops", MemberIDs: []int{1, 8}}, updatedTeam)
+	assert.Equal(t, []string{"/alerting/contacts/3"}, deleted)
+
+	_, err = client.Contacts.Merge(1, []int{1}, true)
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package pingdom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"contacts": [
+				{
+					"id": 1,
+					"name": "John Doe",
+					"paused": false,
+					"type": "user",
+					"owner": true,
+					"notification_targets": {
+						"email": [
+							{"severity": "HIGH", "address": "john@example.com"}
+						],
+						"sms": [
+							{"severity": "LOW", "country_code": "46", "number": "5551234", "provider": "nexmo"}
+						]
+					},
+					"teams": [
+						{"id": 7, "name": "Ops"}
+					]
+				}
+			]
+		}`)
+	})
+
+	want := []ContactResponse{
+		{
+			ID:     1,
+			Name:   "John Doe",
+			Paused: false,
+			Type:   "user",
+			Owner:  true,
+			NotificationTargets: ContactNotificationTargets{
+				Email: []UserEmailResponse{
+					{Severity: "HIGH", Address: "john@example.com"},
+				},
+				SMS: []UserSmsResponse{
+					{Severity: "LOW", CountryCode: "46", Number: "5551234", Provider: "nexmo"},
+				},
+			},
+			Teams: []ContactTeamResponse{
+				{ID: 7, Name: "Ops"},
+			},
+		},
+	}
+
+	contacts, err := client.Contacts.List()
+	assert.NoError(t, err)
+	assert.Equal(t, want, contacts)
+}
+
+func TestContactServiceDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"message": "Deletion of contact was successful!"}`)
+	})
+
+	want := &PingdomResponse{Message: "Deletion of contact was successful!"}
+
+	msg, err := client.Contacts.Delete(1)
+	assert.NoError(t, err)
+	assert.Equal(t, want, msg)
+}
+
+func TestContactServiceRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"contact": {"id": 1, "name": "John Doe", "notification_targets": {"email": [{"severity": "HIGH", "address": "john@example.com"}]}}}`)
+	})
+
+	contact, err := client.Contacts.Read(1)
+	assert.NoError(t, err)
+	assert.Equal(t, &ContactResponse{
+		ID:   1,
+		Name: "John Doe",
+		NotificationTargets: ContactNotificationTargets{
+			Email: []UserEmailResponse{{Severity: "HIGH", Address: "john@example.com"}},
+		},
+	}, contact)
+}
+
+func TestContactServiceCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+			"name": "John Doe",
+			"paused": false,
+			"notification_targets": {
+				"email": [{"severity": "HIGH", "address": "john@example.com"}],
+				"sms": [{"severity": "LOW", "country_code": "46", "number": "5551234", "provider": "nexmo"}]
+			}
+		}`, string(body))
+		fmt.Fprint(w, `{"contact": {"id": 3}}`)
+	})
+
+	contact, err := client.Contacts.Create(&Contact{
+		Name: "John Doe",
+		NotificationTargets: ContactTargets{
+			Email: []EmailTarget{{Severity: SeverityHigh, Address: "john@example.com"}},
+			SMS:   []SMSTarget{{Severity: SeverityLow, CountryCode: "46", Number: "5551234", Provider: "nexmo"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &ContactResponse{ID: 3}, contact)
+
+	_, err = client.Contacts.Create(&Contact{Name: "John Doe"})
+	assert.Error(t, err)
+}
+
+func TestContactServiceUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+			"name": "John Doe",
+			"paused": true,
+			"notification_targets": {"email": [{"severity": "LOW", "address": "john@example.com"}]}
+		}`, string(body))
+		fmt.Fprint(w, `{"message": "Modification of contact was successful!"}`)
+	})
+
+	msg, err := client.Contacts.Update(3, &Contact{
+		Name:                "John Doe",
+		Paused:              true,
+		NotificationTargets: ContactTargets{Email: []EmailTarget{{Severity: SeverityLow, Address: "john@example.com"}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Modification of contact was successful!"}, msg)
+}
+
+func TestContactValid(t *testing.T) {
+	email := []EmailTarget{{Severity: SeverityHigh, Address: "john@example.com"}}
+	tests := []struct {
+		name    string
+		contact Contact
+		wantErr bool
+	}{
+		{"valid", Contact{Name: "John", NotificationTargets: ContactTargets{Email: email}}, false},
+		{"no name", Contact{NotificationTargets: ContactTargets{Email: email}}, true},
+		{"no targets", Contact{Name: "John"}, true},
+		{"empty address", Contact{Name: "John", NotificationTargets: ContactTargets{Email: []EmailTarget{{Severity: SeverityHigh}}}}, true},
+		{"bad severity", Contact{Name: "John", NotificationTargets: ContactTargets{Email: []EmailTarget{{Severity: "URGENT", Address: "john@example.com"}}}}, true},
+		{"no number", Contact{Name: "John", NotificationTargets: ContactTargets{SMS: []SMSTarget{{Severity: SeverityLow, CountryCode: "46"}}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.contact.Valid()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
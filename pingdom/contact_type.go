@@ -0,0 +1,74 @@
+package pingdom
+
+import "fmt"
+
+// Severity levels of a notification target.  High severity alerts are sent
+// for every alert, low severity ones only for the checks set to notify them.
+const (
+	SeverityHigh = "HIGH"
+	SeverityLow  = "LOW"
+)
+
+// Contact represents a Pingdom alerting contact.
+type Contact struct {
+	Name                string         `json:"name"`
+	Paused              bool           `json:"paused"`
+	NotificationTargets ContactTargets `json:"notification_targets"`
+}
+
+// ContactTargets are the addresses a contact is notified on.
+type ContactTargets struct {
+	Email []EmailTarget `json:"email,omitempty"`
+	SMS   []SMSTarget   `json:"sms,omitempty"`
+}
+
+// EmailTarget is an email address notified for alerts of the given severity.
+type EmailTarget struct {
+	Severity string `json:"severity"`
+	Address  string `json:"address"`
+}
+
+// SMSTarget is a phone number notified for alerts of the given severity.
+// Provider is optional, e.g. "nexmo", "bulksms", "esendex" or "cellsynt".
+type SMSTarget struct {
+	Severity    string `json:"severity"`
+	CountryCode string `json:"country_code"`
+	Number      string `json:"number"`
+	Provider    string `json:"provider,omitempty"`
+}
+
+// Valid determines whether the Contact contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (c *Contact) Valid() error {
+	if c.Name == "" {
+		return fmt.Errorf("Invalid value for `Name`.  Must contain non-empty string")
+	}
+	t := c.NotificationTargets
+	if len(t.Email) == 0 && len(t.SMS) == 0 {
+		return fmt.Errorf("Invalid value for `NotificationTargets`.  Must contain at least one target")
+	}
+	for _, e := range t.Email {
+		if e.Address == "" {
+			return fmt.Errorf("Invalid value for `Address`.  Must contain non-empty string")
+		}
+		if err := validSeverity(e.Severity); err != nil {
+			return err
+		}
+	}
+	for _, s := range t.SMS {
+		if s.CountryCode == "" || s.Number == "" {
+			return fmt.Errorf("Invalid value for `Number`.  Must contain non-empty country code and number")
+		}
+		if err := validSeverity(s.Severity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validSeverity(severity string) error {
+	if severity != SeverityHigh && severity != SeverityLow {
+		return fmt.Errorf("Invalid value %q for `Severity`.  Must be HIGH or LOW", severity)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// DefaultTokenEnv is the environment variable the API token is read from
+// when the client is given neither an APIToken nor Credentials.
+const DefaultTokenEnv = "PINGDOM_API_TOKEN"
+
+// CredentialsProvider supplies the API token sent with each request.  It is
+// called for every request, so tokens rotated at runtime, e.g. fetched from a
+// secret store, are picked up without rebuilding the client.  Implementations
+// must be safe for concurrent use and should cache tokens they fetch
+// remotely.
+type CredentialsProvider interface {
+	APIToken() (string, error)
+}
+
+// CredentialsProviderFunc adapts a function to the CredentialsProvider
+// interface.
+type CredentialsProviderFunc func() (string, error)
+
+// APIToken calls f().
+func (f CredentialsProviderFunc) APIToken() (string, error) {
+	return f()
+}
+
+// StaticToken is a CredentialsProvider always returning the same token.
+type StaticToken string
+
+// APIToken returns the token.
+func (t StaticToken) APIToken() (string, error) {
+	return string(t), nil
+}
+
+// EnvCredentials is a CredentialsProvider reading the token from an
+// environment variable on each request, DefaultTokenEnv when Var is empty.
+type EnvCredentials struct {
+	Var string
+}
+
+// APIToken returns the value of the environment variable.
+func (e EnvCredentials) APIToken() (string, error) {
+	name := e.Var
+	if name == "" {
+		name = DefaultTokenEnv
+	}
+	token := os.Getenv(name)
+	if token == "" {
+		return "", fmt.Errorf("no Pingdom API token in $%s", name)
+	}
+	return token, nil
+}
+
+// authorize sets the Authorization header of a request.  The token comes
+// from the credentials provider when one is configured, from APIToken
+// otherwise, and from $PINGDOM_API_TOKEN when APIToken is empty.
+func (pc *Client) authorize(req *http.Request) error {
+	token := pc.APIToken
+	if pc.credentials != nil {
+		var err error
+		if token, err = pc.credentials.APIToken(); err != nil {
+			return err
+		}
+	} else if token == "" {
+		token = os.Getenv(DefaultTokenEnv)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
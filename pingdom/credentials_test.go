@@ -0,0 +1,83 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	os.Setenv("PINGDOM_TEST_TOKEN", "from-env")
+	defer os.Unsetenv("PINGDOM_TEST_TOKEN")
+
+	token, err := EnvCredentials{Var: "PINGDOM_TEST_TOKEN"}.APIToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "from-env", token)
+
+	_, err = EnvCredentials{Var: "PINGDOM_TEST_MISSING"}.APIToken()
+	assert.Error(t, err)
+}
+
+func TestClientCredentialsRotate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tokens := []string{"first", "second"}
+	calls := 0
+	client.credentials = CredentialsProviderFunc(func() (string, error) {
+		token := tokens[calls]
+		calls++
+		return token, nil
+	})
+
+	var seen []string
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"checks": []}`)
+	})
+
+	_, err := client.Checks.List()
+	assert.NoError(t, err)
+	_, err = client.Checks.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer first", "Bearer second"}, seen)
+}
+
+func TestClientCredentialsError(t *testing.T) {
+	c, err := NewClientWithConfig(ClientConfig{
+		Credentials: CredentialsProviderFunc(func() (string, error) { return "", errors.New("vault sealed") }),
+	})
+	assert.NoError(t, err)
+
+	_, err = c.NewRequest("GET", "/checks", nil)
+	assert.EqualError(t, err, "vault sealed")
+	_, err = c.NewJSONRequest("PUT", "/alerting/teams/1", &Team{Name: "ops"})
+	assert.EqualError(t, err, "vault sealed")
+}
+
+func TestClientTokenFromEnv(t *testing.T) {
+	os.Setenv(DefaultTokenEnv, "from-env")
+	defer os.Unsetenv(DefaultTokenEnv)
+
+	c, err := NewClientWithConfig(ClientConfig{})
+	assert.NoError(t, err)
+	req, err := c.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer from-env", req.Header.Get("Authorization"))
+
+	c, err = NewClientWithConfig(ClientConfig{APIToken: "configured"})
+	assert.NoError(t, err)
+	req, err = c.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer configured", req.Header.Get("Authorization"))
+
+	c, err = NewClientWithConfig(ClientConfig{APIToken: "configured", Credentials: StaticToken("provided")})
+	assert.NoError(t, err)
+	req, err = c.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer provided", req.Header.Get("Authorization"))
+}
@@ -0,0 +1,30 @@
+package pingdom
+
+import "context"
+
+// CreditsService provides an interface to the Pingdom account credits.
+type CreditsService struct {
+	client *Client
+}
+
+// Get returns the remaining check slots and SMS credits of the account.
+func (cs *CreditsService) Get() (*CreditsResponse, error) {
+	return cs.GetWithContext(context.Background())
+}
+
+// GetWithContext is like Get, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *CreditsService) GetWithContext(ctx context.Context) (*CreditsResponse, error) {
+	req, err := cs.client.NewRequest("GET", "/credits", nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &creditsJSONResponse{}
+	_, err = cs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return &m.Credits, nil
+}
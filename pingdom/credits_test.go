@@ -0,0 +1,56 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreditsServiceGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/credits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"credits": {
+				"checklimit": 100,
+				"availablechecks": 40,
+				"useddefault": 55,
+				"availabledefault": 35,
+				"usedtransaction": 5,
+				"availabletransaction": 5,
+				"availablesms": 120,
+				"availablesmstests": 10,
+				"autofillsms": true,
+				"autofillsms_amount": 50,
+				"autofillsms_when_left": 20,
+				"max_sms_overage": 0,
+				"availablerumsites": 3,
+				"usedrumsites": 1
+			}
+		}`)
+	})
+
+	want := &CreditsResponse{
+		CheckLimit:           100,
+		AvailableChecks:      40,
+		UsedDefault:          55,
+		AvailableDefault:     35,
+		UsedTransaction:      5,
+		AvailableTransaction: 5,
+		AvailableSMS:         120,
+		AvailableSMSTests:    10,
+		AutoFillSMS:          true,
+		AutoFillSMSAmount:    50,
+		AutoFillSMSWhenLeft:  20,
+		AvailableRUMSites:    3,
+		UsedRUMSites:         1,
+	}
+
+	credits, err := client.Credits.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, want, credits)
+}
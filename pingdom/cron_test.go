@@ -0,0 +1,73 @@
+package pingdom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr  string
+		valid bool
+	}{
+		{"* * * * *", true},
+		{"*/15 2-4 1,15 * 1-5", true},
+		{"0 3 * * 7", true},
+		{"@weekly", true},
+		{"0 0 * *", false},
+		{"60 * * * *", false},
+		{"*/0 * * * *", false},
+		{"5-1 * * * *", false},
+		{"a * * * *", false},
+	}
+
+	for _, tc := range tests {
+		_, err := ParseCron(tc.expr)
+		if tc.valid {
+			assert.NoError(t, err, tc.expr)
+		} else {
+			assert.Error(t, err, tc.expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	base := time.Date(2021, time.March, 10, 14, 7, 30, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2021, time.March, 10, 14, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2021, time.March, 10, 14, 15, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2021, time.March, 11, 2, 0, 0, 0, time.UTC)},
+		{"30 1 * * 0", time.Date(2021, time.March, 14, 1, 30, 0, 0, time.UTC)},
+		{"30 1 * * 7", time.Date(2021, time.March, 14, 1, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2021, time.March, 12, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tc := range tests {
+		s, err := ParseCron(tc.expr)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, s.Next(base), tc.expr)
+	}
+}
+
+func TestCronScheduleBetween(t *testing.T) {
+	s, err := ParseCron("0 */6 * * *")
+	assert.NoError(t, err)
+
+	from := time.Date(2021, time.March, 10, 0, 0, 0, 0, time.UTC)
+	times := s.Between(from, from.Add(24*time.Hour))
+	assert.Equal(t, []time.Time{
+		from.Add(6 * time.Hour),
+		from.Add(12 * time.Hour),
+		from.Add(18 * time.Hour),
+		from.Add(24 * time.Hour),
+	}, times)
+}
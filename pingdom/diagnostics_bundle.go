@@ -0,0 +1,234 @@
+package pingdom
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxRecordedBody is the number of bytes of a response body kept in the
+// request history.
+const maxRecordedBody = 4096
+
+// RequestRecord describes a request recently made by the client.  It never
+// contains the API token, and the secrets of its URL and response body are
+// masked.
+type RequestRecord struct {
+	Time       time.Time     `json:"time"`
+	Method     string        `json:"method"`
+	URL        string        `json:"url"`
+	StatusCode int           `json:"status_code,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	RequestID  string        `json:"request_id,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	// ResponseBody is only kept with ClientConfig.RequestHistoryBodies.
+	ResponseBody string `json:"response_body,omitempty"`
+}
+
+// requestHistory keeps the last requests made by the client in a ring buffer.
+type requestHistory struct {
+	mu      sync.Mutex
+	records []RequestRecord
+	next    int
+	full    bool
+}
+
+func newRequestHistory(size int) *requestHistory {
+	return &requestHistory{records: make([]RequestRecord, size)}
+}
+
+func (h *requestHistory) add(rec RequestRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records[h.next] = rec
+	h.next = (h.next + 1) % len(h.records)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// list returns the recorded requests, oldest first.
+func (h *requestHistory) list() []RequestRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.full {
+		return append([]RequestRecord{}, h.records[:h.next]...)
+	}
+	return append(append([]RequestRecord{}, h.records[h.next:]...), h.records[:h.next]...)
+}
+
+// recordRequest adds the outcome of a request to the history.  When bodies
+// are kept, the response body is buffered so callers can still read it.
+func (pc *Client) recordRequest(req *http.Request, start time.Time, resp *http.Response, err error) {
+	rec := RequestRecord{
+		Time:     start,
+		Method:   req.Method,
+		URL:      pc.redactURL(req.URL),
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	} else {
+		rec.StatusCode = resp.StatusCode
+		rec.RequestID = Diagnostics(resp).RequestID
+		if pc.historyBodies {
+			b, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+			if b = redactJSON(b); len(b) > maxRecordedBody {
+				b = b[:maxRecordedBody]
+			}
+			rec.ResponseBody = string(b)
+		}
+	}
+	pc.history.add(rec)
+}
+
+// RecentRequests returns the last requests made by the client, oldest
+// first.  It is empty unless ClientConfig.RequestHistory is set.
+func (pc *Client) RecentRequests() []RequestRecord {
+	if pc.history == nil {
+		return nil
+	}
+	return pc.history.list()
+}
+
+// DiagnosticsOptions configures WriteDiagnosticsBundle.
+type DiagnosticsOptions struct {
+	// Inventory adds the number of checks, contacts, probes and teams of
+	// the account to the bundle.  This makes a request per resource.
+	Inventory bool
+}
+
+// diagnosticsConfig is the sanitized client configuration of a bundle.
+type diagnosticsConfig struct {
+	LibraryVersion   string        `json:"library_version"`
+	BaseURL          string        `json:"base_url"`
+	UserAgent        string        `json:"user_agent"`
+	APIToken         string        `json:"api_token"`
+	NotFoundTTL      time.Duration `json:"not_found_ttl,omitempty"`
+	Throttle         *Throttle     `json:"throttle,omitempty"`
+	Retry            *RetryPolicy  `json:"retry,omitempty"`
+	MutationSink     bool          `json:"mutation_sink"`
+	RequestHistory   int           `json:"request_history"`
+	BundleCreatedAt  time.Time     `json:"bundle_created_at"`
+	RateLimit        *RateLimit    `json:"rate_limit,omitempty"`
+	RateLimitUpdated *time.Time    `json:"rate_limit_updated,omitempty"`
+}
+
+// diagnosticsInventory summarizes the resources of the account.
+type diagnosticsInventory struct {
+	Checks         int            `json:"checks"`
+	ChecksByStatus map[string]int `json:"checks_by_status"`
+	Contacts       int            `json:"contacts"`
+	Probes         int            `json:"probes"`
+	Teams          int            `json:"teams"`
+	Errors         []string       `json:"errors,omitempty"`
+}
+
+// WriteDiagnosticsBundle writes a zip archive to w holding the sanitized
+// client configuration and rate limit state, the recent requests and,
+// optionally, a summary of the account.  Attach it to support tickets with
+// Pingdom or with the maintainers of this library.  The API token and the
+// secrets of the requests are masked, see RequestRecord.
+func (pc *Client) WriteDiagnosticsBundle(w io.Writer, opts DiagnosticsOptions) error {
+	// Summarize the account first so its requests appear in the bundle.
+	var inv *diagnosticsInventory
+	if opts.Inventory {
+		s := pc.inventorySummary()
+		inv = &s
+	}
+
+	config := diagnosticsConfig{
+		LibraryVersion:  Version,
+		BaseURL:         pc.BaseURL.String(),
+		UserAgent:       pc.userAgent,
+		APIToken:        maskToken(pc.APIToken),
+		Throttle:        pc.throttle,
+		Retry:           pc.retry,
+		MutationSink:    pc.mutationSink != nil,
+		BundleCreatedAt: time.Now(),
+	}
+	if pc.notFound != nil {
+		config.NotFoundTTL = pc.notFound.ttl
+	}
+	if pc.history != nil {
+		config.RequestHistory = len(pc.history.records)
+	}
+	if rl, recorded := pc.rateLimit.get(); rl != nil {
+		config.RateLimit = rl
+		config.RateLimitUpdated = &recorded
+	}
+
+	zw := zip.NewWriter(w)
+	if err := writeBundleFile(zw, "config.json", config); err != nil {
+		return err
+	}
+	if err := writeBundleFile(zw, "requests.json", pc.RecentRequests()); err != nil {
+		return err
+	}
+	if inv != nil {
+		if err := writeBundleFile(zw, "inventory.json", inv); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeBundleFile adds v to the archive as an indented JSON file.
+func writeBundleFile(zw *zip.Writer, name string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(b)
+	return err
+}
+
+// inventorySummary counts the resources of the account.  Failures are
+// recorded in the summary since a partial bundle is still useful.
+func (pc *Client) inventorySummary() diagnosticsInventory {
+	inv := diagnosticsInventory{ChecksByStatus: map[string]int{}}
+
+	if checks, err := pc.Checks.List(); err != nil {
+		inv.Errors = append(inv.Errors, "checks: "+err.Error())
+	} else {
+		inv.Checks = len(checks)
+		for _, check := range checks {
+			inv.ChecksByStatus[check.Status]++
+		}
+	}
+	if contacts, err := pc.Contacts.List(); err != nil {
+		inv.Errors = append(inv.Errors, "contacts: "+err.Error())
+	} else {
+		inv.Contacts = len(contacts)
+	}
+	if probes, err := pc.Probes.List(); err != nil {
+		inv.Errors = append(inv.Errors, "probes: "+err.Error())
+	} else {
+		inv.Probes = len(probes)
+	}
+	if teams, err := pc.Teams.List(); err != nil {
+		inv.Errors = append(inv.Errors, "teams: "+err.Error())
+	} else {
+		inv.Teams = len(teams)
+	}
+	return inv
+}
+
+// maskToken hides all but the last four characters of a token.
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return "****"
+	}
+	return "****" + token[len(token)-4:]
+}
@@ -0,0 +1,168 @@
+package pingdom
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestHistory(t *testing.T) {
+	h := newRequestHistory(2)
+	assert.Empty(t, h.list())
+
+	h.add(RequestRecord{URL: "a"})
+	assert.Equal(t, []RequestRecord{{URL: "a"}}, h.list())
+
+	h.add(RequestRecord{URL: "b"})
+	h.add(RequestRecord{URL: "c"})
+	assert.Equal(t, []RequestRecord{{URL: "b"}, {URL: "c"}}, h.list())
+}
+
+func TestRecentRequests(t *testing.T) {
+	setup()
+	defer teardown()
+	client.history = newRequestHistory(10)
+
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		fmt.Fprint(w, `{"check": {"id": 1, "name": "one"}}`)
+	})
+
+	check, err := client.Checks.Read(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "one", check.Name)
+
+	records := client.RecentRequests()
+	assert.Len(t, records, 1)
+	assert.Equal(t, "GET", records[0].Method)
+	assert.Equal(t, 200, records[0].StatusCode)
+	assert.Equal(t, "req-1", records[0].RequestID)
+	assert.Empty(t, records[0].ResponseBody)
+	assert.True(t, strings.HasSuffix(records[0].URL, "/checks/1?include_teams=true"))
+}
+
+func TestWriteDiagnosticsBundle(t *testing.T) {
+	setup()
+	defer teardown()
+	client.history = newRequestHistory(10)
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+		fmt.Fprint(w, `{"checks": [{"id": 1, "status": "up"}, {"id": 2, "status": "down"}, {"id": 3, "status": "up"}]}`)
+	})
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contacts": [{"id": 1}]}`)
+	})
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"probes": [{"id": 1}, {"id": 2}]}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"statuscode": 403, "statusdesc": "Forbidden", "errormessage": "Nope"}}`)
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, client.WriteDiagnosticsBundle(&buf, DiagnosticsOptions{Inventory: true}))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		files[f.Name], _ = ioutil.ReadAll(rc)
+		rc.Close()
+	}
+	assert.Len(t, files, 3)
+
+	var config diagnosticsConfig
+	assert.NoError(t, json.Unmarshal(files["config.json"], &config))
+	assert.Equal(t, "****_key", config.APIToken)
+	assert.Equal(t, Version, config.LibraryVersion)
+	assert.Equal(t, 10, config.RequestHistory)
+	assert.Equal(t, 394, config.RateLimit.ShortRemaining)
+	assert.NotContains(t, string(files["config.json"]), "my_api_key")
+
+	var inv diagnosticsInventory
+	assert.NoError(t, json.Unmarshal(files["inventory.json"], &inv))
+	assert.Equal(t, 3, inv.Checks)
+	assert.Equal(t, map[string]int{"up": 2, "down": 1}, inv.ChecksByStatus)
+	assert.Equal(t, 1, inv.Contacts)
+	assert.Equal(t, 2, inv.Probes)
+	assert.Len(t, inv.Errors, 1)
+
+	var records []RequestRecord
+	assert.NoError(t, json.Unmarshal(files["requests.json"], &records))
+	assert.Len(t, records, 4)
+}
+
+func TestDiagnosticsBundleRedactsCheckSecrets(t *testing.T) {
+	setup()
+	defer teardown()
+	client.history = newRequestHistory(10)
+	client.historyBodies = true
+
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			fmt.Fprint(w, `{"message": "Modification of check was successful!"}`)
+			return
+		}
+		fmt.Fprint(w, `{"check": {"id": 1, "name": "web", "type": {"http": {
+			"url": "/", "username": "admin", "password": "hunter2",
+			"requestheaders": {"X-Api-Key": "key123"}}}}}`)
+	})
+
+	check := &HttpCheck{
+		Name:           "web",
+		Hostname:       "example.com",
+		Resolution:     5,
+		Username:       "admin",
+		Password:       "hunter2",
+		RequestHeaders: map[string]string{"X-Api-Key": "key123"},
+	}
+	_, err := client.Checks.Update(1, check)
+	assert.NoError(t, err)
+	_, err = client.Checks.Read(1)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, client.WriteDiagnosticsBundle(&buf, DiagnosticsOptions{}))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	var requests string
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		b, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		assert.NotContains(t, string(b), "hunter2")
+		assert.NotContains(t, string(b), "key123")
+		if f.Name == "requests.json" {
+			requests = string(b)
+		}
+	}
+	assert.Contains(t, requests, "X-Api-Key")
+	assert.Contains(t, requests, `"name\":\"web\"`)
+}
+
+func TestRedactJSON(t *testing.T) {
+	assert.Equal(t,
+		`{"contact":{"id":12345678901,"notification_targets":{"email":[{"address":"REDACTED","severity":"HIGH"}],"sms":[{"number":"REDACTED"}]}}}`,
+		string(redactJSON([]byte(`{"contact": {"id": 12345678901, "notification_targets": {
+			"email": [{"address": "a@example.com", "severity": "HIGH"}],
+			"sms": [{"number": "5555555"}]}}}`))))
+	assert.Empty(t, redactJSON([]byte("<html>")))
+}
+
+func TestMaskToken(t *testing.T) {
+	assert.Equal(t, "****", maskToken(""))
+	assert.Equal(t, "****", maskToken("abcd"))
+	assert.Equal(t, "****cdef", maskToken("0123456789abcdef"))
+}
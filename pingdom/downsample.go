@@ -0,0 +1,120 @@
+package pingdom
+
+import (
+	"math"
+	"sort"
+)
+
+// Point is a single value of a time series, Time is a unix timestamp.
+type Point struct {
+	Time  int64
+	Value float64
+}
+
+// PerformancePoints returns the average response times of a performance
+// summary as a time series sorted by time.
+func PerformancePoints(summaries []SummaryPerformanceSummary) []Point {
+	points := make([]Point, len(summaries))
+	for i, s := range summaries {
+		points[i] = Point{Time: int64(s.StartTime), Value: float64(s.AvgResponse)}
+	}
+	sortPoints(points)
+	return points
+}
+
+// ResultPoints returns the response times of check results as a time series
+// sorted by time.  Pingdom returns results newest first.
+func ResultPoints(results []Result) []Point {
+	points := make([]Point, len(results))
+	for i, r := range results {
+		points[i] = Point{Time: int64(r.Time), Value: float64(r.ResponseTime)}
+	}
+	sortPoints(points)
+	return points
+}
+
+func sortPoints(points []Point) {
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Time < points[j].Time })
+}
+
+// DownsampleLTTB reduces points, sorted by time, to at most threshold points
+// using the Largest-Triangle-Three-Buckets algorithm.  It keeps the first and
+// last points and the points which best preserve the visual shape of the
+// series, which suits charts.  The points are returned unchanged when there
+// are no more than threshold of them or threshold is below 3.
+func DownsampleLTTB(points []Point, threshold int) []Point {
+	if threshold >= len(points) || threshold < 3 {
+		return points
+	}
+
+	sampled := make([]Point, 0, threshold)
+	sampled = append(sampled, points[0])
+
+	// The first and last points are kept, the others are split in
+	// threshold-2 buckets.
+	every := float64(len(points)-2) / float64(threshold-2)
+	a := 0
+	for i := 0; i < threshold-2; i++ {
+		// Average of the next bucket, the third point of the triangle.
+		nextStart := int(float64(i+1)*every) + 1
+		nextEnd := int(float64(i+2)*every) + 1
+		if nextEnd > len(points) {
+			nextEnd = len(points)
+		}
+		var avgTime, avgValue float64
+		for _, p := range points[nextStart:nextEnd] {
+			avgTime += float64(p.Time)
+			avgValue += p.Value
+		}
+		n := float64(nextEnd - nextStart)
+		avgTime /= n
+		avgValue /= n
+
+		// Pick the point of the current bucket forming the largest
+		// triangle with the previously selected point and the average.
+		start := int(float64(i)*every) + 1
+		end := int(float64(i+1)*every) + 1
+		pa := points[a]
+		maxArea := -1.0
+		next := start
+		for j := start; j < end; j++ {
+			area := math.Abs((float64(pa.Time)-avgTime)*(points[j].Value-pa.Value) -
+				(float64(pa.Time)-float64(points[j].Time))*(avgValue-pa.Value))
+			if area > maxArea {
+				maxArea = area
+				next = j
+			}
+		}
+		sampled = append(sampled, points[next])
+		a = next
+	}
+
+	return append(sampled, points[len(points)-1])
+}
+
+// DownsampleMean reduces points, sorted by time, to at most buckets points
+// by splitting them into buckets of equal size and averaging each one.  The
+// time of a bucket is the time of its first point.  Unlike DownsampleLTTB
+// it smooths out spikes.  The points are returned unchanged when there are
+// no more than buckets of them or buckets is below 1.
+func DownsampleMean(points []Point, buckets int) []Point {
+	if buckets >= len(points) || buckets < 1 {
+		return points
+	}
+
+	sampled := make([]Point, buckets)
+	every := float64(len(points)) / float64(buckets)
+	for i := range sampled {
+		start := int(float64(i) * every)
+		end := int(float64(i+1) * every)
+		if i == buckets-1 {
+			end = len(points)
+		}
+		var sum float64
+		for _, p := range points[start:end] {
+			sum += p.Value
+		}
+		sampled[i] = Point{Time: points[start].Time, Value: sum / float64(end-start)}
+	}
+	return sampled
+}
@@ -0,0 +1,48 @@
+package pingdom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerformancePoints(t *testing.T) {
+	points := PerformancePoints([]SummaryPerformanceSummary{
+		{StartTime: 7200, AvgResponse: 300},
+		{StartTime: 3600, AvgResponse: 200},
+	})
+	assert.Equal(t, []Point{{3600, 200}, {7200, 300}}, points)
+
+	points = ResultPoints([]Result{{Time: 120, ResponseTime: 80}, {Time: 60, ResponseTime: 40}})
+	assert.Equal(t, []Point{{60, 40}, {120, 80}}, points)
+}
+
+func TestDownsampleLTTB(t *testing.T) {
+	var points []Point
+	for i := 0; i < 100; i++ {
+		points = append(points, Point{Time: int64(i), Value: 10})
+	}
+	// A spike in a flat series must survive.
+	points[42].Value = 500
+
+	sampled := DownsampleLTTB(points, 10)
+	assert.Len(t, sampled, 10)
+	assert.Equal(t, points[0], sampled[0])
+	assert.Equal(t, points[99], sampled[9])
+	assert.Contains(t, sampled, Point{Time: 42, Value: 500})
+	for i := 1; i < len(sampled); i++ {
+		assert.True(t, sampled[i-1].Time < sampled[i].Time)
+	}
+
+	assert.Equal(t, points, DownsampleLTTB(points, 100))
+	assert.Equal(t, points, DownsampleLTTB(points, 2))
+}
+
+func TestDownsampleMean(t *testing.T) {
+	points := []Point{{0, 1}, {1, 3}, {2, 5}, {3, 7}, {4, 9}}
+
+	assert.Equal(t, []Point{{0, 2}, {2, 7}}, DownsampleMean(points, 2))
+	assert.Equal(t, []Point{{0, 5}}, DownsampleMean(points, 1))
+	assert.Equal(t, points, DownsampleMean(points, 5))
+	assert.Equal(t, points, DownsampleMean(points, 0))
+}
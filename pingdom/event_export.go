@@ -0,0 +1,193 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of exported events.
+const (
+	EventTypeOutage = "outage"
+	EventTypeAlert  = "alert"
+)
+
+// Event is a Pingdom event in a form suitable for SIEM ingestion.
+type Event struct {
+	Time      time.Time     `json:"time"`
+	Type      string        `json:"type"`
+	CheckID   int           `json:"check_id"`
+	CheckName string        `json:"check_name,omitempty"`
+	Status    string        `json:"status,omitempty"`
+	Message   string        `json:"message,omitempty"`
+	Duration  time.Duration `json:"duration,omitempty"`
+	// Contact and Via are the contact an alert was sent to and the channel
+	// it was sent through.
+	Contact string `json:"contact,omitempty"`
+	Via     string `json:"via,omitempty"`
+	// Severity ranges from 0 (lowest) to 10 (highest), as in CEF.
+	Severity int `json:"severity"`
+}
+
+// EventWriter writes events to a stream in a given format.
+type EventWriter interface {
+	WriteEvent(e Event) error
+}
+
+// JSONLinesEventWriter writes events as JSON objects, one per line.
+type JSONLinesEventWriter struct {
+	enc *json.Encoder
+}
+
+// NewJSONLinesEventWriter returns an EventWriter writing JSON Lines to w.
+func NewJSONLinesEventWriter(w io.Writer) *JSONLinesEventWriter {
+	return &JSONLinesEventWriter{enc: json.NewEncoder(w)}
+}
+
+// WriteEvent writes e as a single line.
+func (jw *JSONLinesEventWriter) WriteEvent(e Event) error {
+	return jw.enc.Encode(e)
+}
+
+// CEFEventWriter writes events in the ArcSight Common Event Format, one per
+// line.
+type CEFEventWriter struct {
+	w io.Writer
+}
+
+// NewCEFEventWriter returns an EventWriter writing CEF records to w.
+func NewCEFEventWriter(w io.Writer) *CEFEventWriter {
+	return &CEFEventWriter{w: w}
+}
+
+// WriteEvent writes e as a CEF record.
+func (cw *CEFEventWriter) WriteEvent(e Event) error {
+	name := e.Message
+	if name == "" {
+		name = e.Type
+	}
+
+	ext := []string{
+		"rt=" + strconv.FormatInt(e.Time.UnixNano()/int64(time.Millisecond), 10),
+		"cn1Label=checkId",
+		"cn1=" + strconv.Itoa(e.CheckID),
+	}
+	if e.CheckName != "" {
+		ext = append(ext, "cs1Label=checkName", "cs1="+cefExtensionEscape(e.CheckName))
+	}
+	if e.Contact != "" {
+		ext = append(ext, "duser="+cefExtensionEscape(e.Contact))
+	}
+	if e.Via != "" {
+		ext = append(ext, "cs2Label=via", "cs2="+cefExtensionEscape(e.Via))
+	}
+	if e.Status != "" {
+		ext = append(ext, "outcome="+cefExtensionEscape(e.Status))
+	}
+	if e.Duration > 0 {
+		end := e.Time.Add(e.Duration)
+		ext = append(ext, "end="+strconv.FormatInt(end.UnixNano()/int64(time.Millisecond), 10))
+	}
+
+	_, err := fmt.Fprintf(cw.w, "CEF:0|Pingdom|go-pingdom|%s|%s|%s|%d|%s\n",
+		cefHeaderEscape(Version), cefHeaderEscape(e.Type), cefHeaderEscape(name),
+		e.Severity, strings.Join(ext, " "))
+	return err
+}
+
+var (
+	cefHeaderReplacer    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefExtensionReplacer = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+func cefHeaderEscape(s string) string {
+	return cefHeaderReplacer.Replace(s)
+}
+
+func cefExtensionEscape(s string) string {
+	return cefExtensionReplacer.Replace(s)
+}
+
+// OutageEvents returns an event for each down period of a check.
+func OutageEvents(checkID int, checkName string, states []SummaryOutageState) []Event {
+	var events []Event
+	for _, s := range states {
+		if s.Status != "down" {
+			continue
+		}
+		events = append(events, Event{
+			Time:      s.Start(),
+			Type:      EventTypeOutage,
+			CheckID:   checkID,
+			CheckName: checkName,
+			Status:    s.Status,
+			Message:   "Check down",
+			Duration:  time.Duration(s.TimeTo-s.TimeFrom) * time.Second,
+			Severity:  8,
+		})
+	}
+	return events
+}
+
+// ExportOutages writes an event for every outage of the given checks in the
+// time window to w.
+func (cs *CheckService) ExportOutages(w EventWriter, checkIDs []int, from, to time.Time) error {
+	for _, id := range checkIDs {
+		check, err := cs.Read(id)
+		if err != nil {
+			return err
+		}
+		summary, err := cs.SummaryOutage(SummaryOutageRequest{Id: id, From: from.Unix(), To: to.Unix()})
+		if err != nil {
+			return err
+		}
+		for _, e := range OutageEvents(id, check.Name, summary.Summary.States) {
+			if err := w.WriteEvent(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AlertEvents returns an event for each alert sent by Pingdom.  High
+// severity alerts, see ActionsRequest.IncludeSeverity, rank as outages.
+func AlertEvents(alerts []AlertEntry) []Event {
+	var events []Event
+	for _, a := range alerts {
+		severity := 6
+		switch a.Severity {
+		case SeverityHigh:
+			severity = 8
+		case SeverityLow:
+			severity = 4
+		}
+		events = append(events, Event{
+			Time:     a.SentAt(),
+			Type:     EventTypeAlert,
+			CheckID:  a.CheckID,
+			Status:   a.Status,
+			Message:  a.MessageShort,
+			Contact:  a.ContactName,
+			Via:      a.Via,
+			Severity: severity,
+		})
+	}
+	return events
+}
+
+// ExportAlerts writes an event for every alert matching the request to w,
+// walking the pages like Pages.
+func (as *ActionsService) ExportAlerts(w EventWriter, request ActionsRequest) error {
+	return as.Pages(request, func(alerts []AlertEntry) error {
+		for _, e := range AlertEvents(alerts) {
+			if err := w.WriteEvent(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
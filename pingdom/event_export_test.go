@@ -0,0 +1,121 @@
+package pingdom
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testOutageEvent = Event{
+	Time:      time.Unix(1600000000, 0).UTC(),
+	Type:      EventTypeOutage,
+	CheckID:   12345,
+	CheckName: "web|a=b",
+	Status:    "down",
+	Message:   "Check down",
+	Duration:  5 * time.Minute,
+	Severity:  8,
+}
+
+func TestOutageEvents(t *testing.T) {
+	events := OutageEvents(12345, "web|a=b", []SummaryOutageState{
+		{Status: "up", TimeFrom: 1599999000, TimeTo: 1600000000},
+		{Status: "down", TimeFrom: 1600000000, TimeTo: 1600000300},
+		{Status: "up", TimeFrom: 1600000300, TimeTo: 1600001000},
+	})
+	assert.Equal(t, []Event{testOutageEvent}, events)
+}
+
+func TestJSONLinesEventWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewJSONLinesEventWriter(&buf)
+	assert.NoError(t, w.WriteEvent(testOutageEvent))
+	assert.NoError(t, w.WriteEvent(testOutageEvent))
+
+	line := `{"time":"2020-09-13T12:26:40Z","type":"outage","check_id":12345,"check_name":"web|a=b",` +
+		`"status":"down","message":"Check down","duration":300000000000,"severity":8}` + "\n"
+	assert.Equal(t, line+line, buf.String())
+}
+
+func TestCEFEventWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCEFEventWriter(&buf)
+	assert.NoError(t, w.WriteEvent(testOutageEvent))
+
+	want := "CEF:0|Pingdom|go-pingdom|" + Version + "|outage|Check down|8|" +
+		`rt=1600000000000 cn1Label=checkId cn1=12345 cs1Label=checkName cs1=web|a\=b outcome=down end=1600000300000` + "\n"
+	assert.Equal(t, want, buf.String())
+
+	assert.Equal(t, `a\|b\\c`, cefHeaderEscape(`a|b\c`))
+}
+
+func TestCheckServiceExportOutages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 12345, "name": "web|a=b"}}`)
+	})
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1599990000", r.URL.Query().Get("from"))
+		assert.Equal(t, "1600010000", r.URL.Query().Get("to"))
+		fmt.Fprint(w, `{"summary": {"states": [
+			{"status": "up", "timefrom": 1599990000, "timeto": 1600000000},
+			{"status": "down", "timefrom": 1600000000, "timeto": 1600000300}
+		]}}`)
+	})
+
+	var events []Event
+	w := eventWriterFunc(func(e Event) error {
+		events = append(events, e)
+		return nil
+	})
+	err := client.Checks.ExportOutages(w, []int{12345}, time.Unix(1599990000, 0), time.Unix(1600010000, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{testOutageEvent}, events)
+}
+
+type eventWriterFunc func(e Event) error
+
+func (f eventWriterFunc) WriteEvent(e Event) error {
+	return f(e)
+}
+
+func TestAlertEvents(t *testing.T) {
+	events := AlertEvents([]AlertEntry{
+		{ContactName: "Jane", CheckID: 12345, Time: 1600000000, Via: "sms", Status: "delivered", MessageShort: "down", Severity: SeverityHigh},
+		{ContactName: "John", CheckID: 12345, Time: 1600000060, Via: "email", Status: "sent", MessageShort: "down"},
+	})
+	assert.Equal(t, []Event{
+		{Time: time.Unix(1600000000, 0).UTC(), Type: EventTypeAlert, CheckID: 12345, Status: "delivered", Message: "down", Contact: "Jane", Via: "sms", Severity: 8},
+		{Time: time.Unix(1600000060, 0).UTC(), Type: EventTypeAlert, CheckID: 12345, Status: "sent", Message: "down", Contact: "John", Via: "email", Severity: 6},
+	}, events)
+
+	var buf bytes.Buffer
+	assert.NoError(t, NewCEFEventWriter(&buf).WriteEvent(events[0]))
+	want := "CEF:0|Pingdom|go-pingdom|" + Version + "|alert|down|8|" +
+		`rt=1600000000000 cn1Label=checkId cn1=12345 duser=Jane cs2Label=via cs2=sms outcome=delivered` + "\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestActionsServiceExportAlerts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/actions", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "12345", r.URL.Query().Get("checkids"))
+		fmt.Fprint(w, `{"actions": {"alerts": [
+			{"contactname": "Jane", "checkid": 12345, "time": 1600000000, "via": "email", "status": "sent", "messageshort": "down"}
+		]}}`)
+	})
+
+	var buf bytes.Buffer
+	err := client.Actions.ExportAlerts(NewJSONLinesEventWriter(&buf), ActionsRequest{CheckIds: []int{12345}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"time":"2020-09-13T12:26:40Z","type":"alert","check_id":12345,"status":"sent",`+
+		`"message":"down","contact":"Jane","via":"email","severity":6}`+"\n", buf.String())
+}
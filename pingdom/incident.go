@@ -0,0 +1,103 @@
+package pingdom
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Incident is a down period of a check.  With a debounce, outages separated
+// by less than it are merged into a single incident, so a flapping check
+// makes one incident instead of many.
+type Incident struct {
+	TimeFrom int64
+	TimeTo   int64
+	// Ongoing is set when the check was still down at the end of the
+	// states the incident was built from.
+	Ongoing bool
+	// Outages is the number of outages merged into the incident.
+	Outages int
+}
+
+// Duration returns how long the incident lasted, up to the end of the
+// states when it is ongoing.
+func (i Incident) Duration() time.Duration {
+	return time.Duration(i.TimeTo-i.TimeFrom) * time.Second
+}
+
+// IncidentDiff is the change between two timelines of incidents, see
+// DiffIncidents.
+type IncidentDiff struct {
+	// New are the incidents which were not in the previous timeline.
+	New []Incident
+	// Resolved are the incidents which were ongoing in the previous
+	// timeline and are over in the current one.
+	Resolved []Incident
+}
+
+// BuildIncidents converts the states of a summary outage into incidents,
+// oldest first.  Down states less than debounce apart are merged, zero only
+// merges adjacent ones.
+func BuildIncidents(states []SummaryOutageState, debounce time.Duration) []Incident {
+	sorted := append([]SummaryOutageState(nil), states...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].TimeFrom < sorted[j].TimeFrom })
+
+	var incidents []Incident
+	gap := int64(debounce / time.Second)
+	for i, s := range sorted {
+		if s.Status != "down" {
+			continue
+		}
+		last := len(incidents) - 1
+		if last >= 0 && s.TimeFrom-incidents[last].TimeTo <= gap {
+			incidents[last].TimeTo = max64(incidents[last].TimeTo, s.TimeTo)
+			incidents[last].Outages++
+		} else {
+			incidents = append(incidents, Incident{TimeFrom: s.TimeFrom, TimeTo: s.TimeTo, Outages: 1})
+			last++
+		}
+		incidents[last].Ongoing = i == len(sorted)-1
+	}
+	return incidents
+}
+
+// DiffIncidents compares two timelines of the same check, e.g. built by
+// successive polls, matching the incidents by start time.
+func DiffIncidents(previous, current []Incident) IncidentDiff {
+	before := map[int64]Incident{}
+	for _, i := range previous {
+		before[i.TimeFrom] = i
+	}
+
+	var diff IncidentDiff
+	for _, i := range current {
+		p, ok := before[i.TimeFrom]
+		switch {
+		case !ok:
+			diff.New = append(diff.New, i)
+		case p.Ongoing && !i.Ongoing:
+			diff.Resolved = append(diff.Resolved, i)
+		}
+	}
+	return diff
+}
+
+// Incidents returns the incidents of a check between from and to, see
+// BuildIncidents.
+func (cs *CheckService) Incidents(id int, from, to time.Time, debounce time.Duration) ([]Incident, error) {
+	return cs.IncidentsWithContext(context.Background(), id, from, to, debounce)
+}
+
+// IncidentsWithContext is like Incidents, the request is bound to ctx so it
+// can be canceled or given a deadline.
+func (cs *CheckService) IncidentsWithContext(ctx context.Context, id int, from, to time.Time, debounce time.Duration) ([]Incident, error) {
+	summary, err := cs.SummaryOutageWithContext(ctx, SummaryOutageRequest{
+		Id:   id,
+		From: from.Unix(),
+		To:   to.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return BuildIncidents(summary.Summary.States, debounce), nil
+}
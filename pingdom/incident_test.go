@@ -0,0 +1,85 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildIncidents(t *testing.T) {
+	states := []SummaryOutageState{
+		{Status: "down", TimeFrom: 200, TimeTo: 260},
+		{Status: "up", TimeFrom: 0, TimeTo: 100},
+		{Status: "down", TimeFrom: 100, TimeTo: 150},
+		{Status: "up", TimeFrom: 150, TimeTo: 200},
+		{Status: "up", TimeFrom: 260, TimeTo: 1000},
+		{Status: "down", TimeFrom: 1000, TimeTo: 1030},
+		{Status: "unknown", TimeFrom: 1030, TimeTo: 1040},
+		{Status: "down", TimeFrom: 1040, TimeTo: 1100},
+	}
+
+	assert.Equal(t, []Incident{
+		{TimeFrom: 100, TimeTo: 150, Outages: 1},
+		{TimeFrom: 200, TimeTo: 260, Outages: 1},
+		{TimeFrom: 1000, TimeTo: 1030, Outages: 1},
+		{TimeFrom: 1040, TimeTo: 1100, Outages: 1, Ongoing: true},
+	}, BuildIncidents(states, 0))
+
+	incidents := BuildIncidents(states, time.Minute)
+	assert.Equal(t, []Incident{
+		{TimeFrom: 100, TimeTo: 260, Outages: 2},
+		{TimeFrom: 1000, TimeTo: 1100, Outages: 2, Ongoing: true},
+	}, incidents)
+	assert.Equal(t, 160*time.Second, incidents[0].Duration())
+
+	assert.Nil(t, BuildIncidents([]SummaryOutageState{{Status: "up", TimeFrom: 0, TimeTo: 100}}, time.Hour))
+
+	// Adjacent down states always merge.
+	assert.Equal(t, []Incident{{TimeFrom: 0, TimeTo: 20, Outages: 2}}, BuildIncidents([]SummaryOutageState{
+		{Status: "down", TimeFrom: 0, TimeTo: 10},
+		{Status: "down", TimeFrom: 10, TimeTo: 20},
+		{Status: "up", TimeFrom: 20, TimeTo: 30},
+	}, 0))
+}
+
+func TestDiffIncidents(t *testing.T) {
+	previous := []Incident{
+		{TimeFrom: 100, TimeTo: 150, Outages: 1},
+		{TimeFrom: 1000, TimeTo: 1100, Outages: 1, Ongoing: true},
+	}
+	current := []Incident{
+		{TimeFrom: 100, TimeTo: 150, Outages: 1},
+		{TimeFrom: 1000, TimeTo: 1200, Outages: 1},
+		{TimeFrom: 1500, TimeTo: 1600, Outages: 1, Ongoing: true},
+	}
+	assert.Equal(t, IncidentDiff{
+		New:      []Incident{{TimeFrom: 1500, TimeTo: 1600, Outages: 1, Ongoing: true}},
+		Resolved: []Incident{{TimeFrom: 1000, TimeTo: 1200, Outages: 1}},
+	}, DiffIncidents(previous, current))
+	assert.Equal(t, IncidentDiff{}, DiffIncidents(current, current))
+}
+
+func TestCheckServiceIncidents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "1000", r.URL.Query().Get("from"))
+		assert.Equal(t, "2000", r.URL.Query().Get("to"))
+		fmt.Fprint(w, `{"summary": {"states": [
+			{"status": "up", "timefrom": 1000, "timeto": 1200},
+			{"status": "down", "timefrom": 1200, "timeto": 1300},
+			{"status": "up", "timefrom": 1300, "timeto": 1320},
+			{"status": "down", "timefrom": 1320, "timeto": 1400},
+			{"status": "up", "timefrom": 1400, "timeto": 2000}
+		]}}`)
+	})
+
+	incidents, err := client.Checks.Incidents(12345, time.Unix(1000, 0), time.Unix(2000, 0), 30*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, []Incident{{TimeFrom: 1200, TimeTo: 1400, Outages: 2}}, incidents)
+}
@@ -0,0 +1,124 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Keys under which the inventory stores its data.
+const (
+	InventoryChecks   = "inventory/checks"
+	InventoryContacts = "inventory/contacts"
+	InventoryProbes   = "inventory/probes"
+	InventoryTeams    = "inventory/teams"
+)
+
+// Inventory gives access to the checks and reference data of an account
+// through a Cache.  Backed by a FileCache it lets CLIs and exporters restart
+// without fetching everything again.
+type Inventory struct {
+	client *Client
+	cache  Cache
+	// MaxAge is how long cached data is used before it is fetched again.
+	// Zero means cached data never expires on its own.
+	MaxAge time.Duration
+}
+
+// InventoryInfo describes where inventory data came from.
+type InventoryInfo struct {
+	// FetchedAt is when the data was fetched from Pingdom.
+	FetchedAt time.Time
+	// FromCache is true when the data was read from the cache.
+	FromCache bool
+}
+
+// Age returns how old the data is.
+func (i InventoryInfo) Age() time.Duration {
+	return time.Since(i.FetchedAt)
+}
+
+// NewInventory returns an Inventory for the client using the given cache.
+func NewInventory(client *Client, cache Cache, maxAge time.Duration) *Inventory {
+	return &Inventory{client: client, cache: cache, MaxAge: maxAge}
+}
+
+// Checks returns the checks of the account.  With refresh set they are
+// fetched from Pingdom regardless of the cache.
+func (inv *Inventory) Checks(refresh bool) ([]CheckResponse, InventoryInfo, error) {
+	var checks []CheckResponse
+	info, err := inv.load(InventoryChecks, &checks, refresh, func() (interface{}, error) {
+		return inv.client.Checks.List()
+	})
+	return checks, info, err
+}
+
+// Contacts returns the alerting contacts of the account.
+func (inv *Inventory) Contacts(refresh bool) ([]ContactResponse, InventoryInfo, error) {
+	var contacts []ContactResponse
+	info, err := inv.load(InventoryContacts, &contacts, refresh, func() (interface{}, error) {
+		return inv.client.Contacts.List()
+	})
+	return contacts, info, err
+}
+
+// Probes returns the Pingdom probes.
+func (inv *Inventory) Probes(refresh bool) ([]ProbeResponse, InventoryInfo, error) {
+	var probes []ProbeResponse
+	info, err := inv.load(InventoryProbes, &probes, refresh, func() (interface{}, error) {
+		return inv.client.Probes.List()
+	})
+	return probes, info, err
+}
+
+// Teams returns the alerting teams of the account.
+func (inv *Inventory) Teams(refresh bool) ([]TeamResponse, InventoryInfo, error) {
+	var teams []TeamResponse
+	info, err := inv.load(InventoryTeams, &teams, refresh, func() (interface{}, error) {
+		return inv.client.Teams.List()
+	})
+	return teams, info, err
+}
+
+// Invalidate drops the cached data for the given inventory keys, e.g. after
+// creating a check.
+func (inv *Inventory) Invalidate(keys ...string) error {
+	for _, key := range keys {
+		if err := inv.cache.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load reads the key from the cache into v, or fetches and stores it when
+// missing, expired or a refresh is requested.
+func (inv *Inventory) load(key string, v interface{}, refresh bool, fetch func() (interface{}, error)) (InventoryInfo, error) {
+	if !refresh {
+		entry, err := inv.cache.Get(key)
+		if err != nil {
+			return InventoryInfo{}, err
+		}
+		if entry != nil && (inv.MaxAge == 0 || entry.Age() < inv.MaxAge) {
+			if err := json.Unmarshal(entry.Data, v); err == nil {
+				return InventoryInfo{FetchedAt: entry.StoredAt, FromCache: true}, nil
+			}
+		}
+	}
+
+	data, err := fetch()
+	if err != nil {
+		return InventoryInfo{}, err
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return InventoryInfo{}, err
+	}
+	entry := &CacheEntry{Data: b, StoredAt: time.Now()}
+	if err := inv.cache.Set(key, entry); err != nil {
+		return InventoryInfo{}, err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return InventoryInfo{}, err
+	}
+	return InventoryInfo{FetchedAt: entry.StoredAt}, nil
+}
@@ -0,0 +1,82 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventoryChecks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hits := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		fmt.Fprint(w, `{"checks": [{"id": 85975, "name": "My check 1", "type": "http"}]}`)
+	})
+
+	cache := NewMemoryCache()
+	inv := NewInventory(client, cache, time.Hour)
+	want := []CheckResponse{{ID: 85975, Name: "My check 1", Type: CheckResponseType{Name: "http"}}}
+
+	checks, info, err := inv.Checks(false)
+	assert.NoError(t, err)
+	assert.Equal(t, want, checks)
+	assert.False(t, info.FromCache)
+
+	checks, info, err = inv.Checks(false)
+	assert.NoError(t, err)
+	assert.Equal(t, want, checks)
+	assert.True(t, info.FromCache)
+	assert.Equal(t, 1, hits)
+
+	_, info, err = inv.Checks(true)
+	assert.NoError(t, err)
+	assert.False(t, info.FromCache)
+	assert.Equal(t, 2, hits)
+
+	assert.NoError(t, inv.Invalidate(InventoryChecks))
+	_, _, err = inv.Checks(false)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, hits)
+
+	// Expired entries are fetched again.
+	cache.entries[InventoryChecks] = CacheEntry{Data: []byte(`[]`), StoredAt: time.Now().Add(-2 * time.Hour)}
+	checks, info, err = inv.Checks(false)
+	assert.NoError(t, err)
+	assert.Equal(t, want, checks)
+	assert.False(t, info.FromCache)
+	assert.Equal(t, 4, hits)
+}
+
+func TestInventoryReferenceData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"probes": [{"id": 32, "name": "Los Angeles, CA"}]}`)
+	})
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "name": "John Doe"}]}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"teams": [{"id": 7, "name": "Ops"}]}`)
+	})
+
+	inv := NewInventory(client, NewMemoryCache(), 0)
+
+	probes, _, err := inv.Probes(false)
+	assert.NoError(t, err)
+	assert.Equal(t, 32, probes[0].ID)
+	contacts, _, err := inv.Contacts(false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, contacts[0].ID)
+	teams, info, err := inv.Teams(false)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, teams[0].ID)
+	assert.True(t, info.Age() < time.Minute)
+}
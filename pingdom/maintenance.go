@@ -1,8 +1,7 @@
 package pingdom
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
 	"strconv"
 )
 
@@ -26,6 +25,28 @@ type MaintenanceDelete interface {
 
 // List returns the response holding a list of Maintenance windows.
 func (cs *MaintenanceService) List(params ...map[string]string) ([]MaintenanceResponse, error) {
+	return cs.ListWithContext(context.Background(), params...)
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *MaintenanceService) ListWithContext(ctx context.Context, params ...map[string]string) ([]MaintenanceResponse, error) {
+	l, err := cs.ListWithMetaWithContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	return l.Maintenances, nil
+}
+
+// ListWithMeta returns a page of Maintenance windows along with the paging
+// metadata, see List.
+func (cs *MaintenanceService) ListWithMeta(params ...map[string]string) (*MaintenanceList, error) {
+	return cs.ListWithMetaWithContext(context.Background(), params...)
+}
+
+// ListWithMetaWithContext is like ListWithMeta, the request is bound to ctx so
+// it can be canceled or given a deadline.
+func (cs *MaintenanceService) ListWithMetaWithContext(ctx context.Context, params ...map[string]string) (*MaintenanceList, error) {
 	param := map[string]string{}
 	if len(params) != 0 {
 		for _, m := range params {
@@ -38,32 +59,33 @@ func (cs *MaintenanceService) List(params ...map[string]string) ([]MaintenanceRe
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	resp, err := cs.client.client.Do(req)
+	m := &listMaintenanceJSONResponse{}
+	resp, err := cs.client.Do(req, m)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if err := validateResponse(resp); err != nil {
-		return nil, err
-	}
 
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
-
-	m := &listMaintenanceJSONResponse{}
-	err = json.Unmarshal([]byte(bodyString), &m)
-
-	return m.Maintenances, err
+	return &MaintenanceList{
+		Maintenances: m.Maintenances,
+		Meta:         newListMeta(resp, param, len(m.Maintenances)),
+	}, nil
 }
 
 // Read returns a Maintenance for a given ID.
 func (cs *MaintenanceService) Read(id int) (*MaintenanceResponse, error) {
+	return cs.ReadWithContext(context.Background(), id)
+}
+
+// ReadWithContext is like Read, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *MaintenanceService) ReadWithContext(ctx context.Context, id int) (*MaintenanceResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/maintenance/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &maintenanceDetailsJSONResponse{}
 	_, err = cs.client.Do(req, m)
@@ -76,6 +98,12 @@ func (cs *MaintenanceService) Read(id int) (*MaintenanceResponse, error) {
 
 // Create creates a new Maintenance.
 func (cs *MaintenanceService) Create(maintenance Maintenance) (*MaintenanceResponse, error) {
+	return cs.CreateWithContext(context.Background(), maintenance)
+}
+
+// CreateWithContext is like Create, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *MaintenanceService) CreateWithContext(ctx context.Context, maintenance Maintenance) (*MaintenanceResponse, error) {
 	if err := maintenance.Valid(); err != nil {
 		return nil, err
 	}
@@ -84,6 +112,7 @@ func (cs *MaintenanceService) Create(maintenance Maintenance) (*MaintenanceRespo
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &maintenanceDetailsJSONResponse{}
 	_, err = cs.client.Do(req, m)
@@ -96,6 +125,12 @@ func (cs *MaintenanceService) Create(maintenance Maintenance) (*MaintenanceRespo
 // Update is used to update an existing Maintenance. Only the 'Description',
 // and 'To' fields can be updated.
 func (cs *MaintenanceService) Update(id int, maintenance Maintenance) (*PingdomResponse, error) {
+	return cs.UpdateWithContext(context.Background(), id, maintenance)
+}
+
+// UpdateWithContext is like Update, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *MaintenanceService) UpdateWithContext(ctx context.Context, id int, maintenance Maintenance) (*PingdomResponse, error) {
 	if err := maintenance.Valid(); err != nil {
 		return nil, err
 	}
@@ -104,6 +139,7 @@ func (cs *MaintenanceService) Update(id int, maintenance Maintenance) (*PingdomR
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &PingdomResponse{}
 	_, err = cs.client.Do(req, m)
@@ -115,6 +151,12 @@ func (cs *MaintenanceService) Update(id int, maintenance Maintenance) (*PingdomR
 
 // MultiDelete will delete the Maintenance for the given ID.
 func (cs *MaintenanceService) MultiDelete(maintenance MaintenanceDelete) (*PingdomResponse, error) {
+	return cs.MultiDeleteWithContext(context.Background(), maintenance)
+}
+
+// MultiDeleteWithContext is like MultiDelete, the request is bound to ctx so
+// it can be canceled or given a deadline.
+func (cs *MaintenanceService) MultiDeleteWithContext(ctx context.Context, maintenance MaintenanceDelete) (*PingdomResponse, error) {
 	if err := maintenance.ValidDelete(); err != nil {
 		return nil, err
 	}
@@ -123,6 +165,7 @@ func (cs *MaintenanceService) MultiDelete(maintenance MaintenanceDelete) (*Pingd
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &PingdomResponse{}
 	_, err = cs.client.Do(req, m)
@@ -134,10 +177,17 @@ func (cs *MaintenanceService) MultiDelete(maintenance MaintenanceDelete) (*Pingd
 
 // Delete will delete the Maintenance for the given ID.
 func (cs *MaintenanceService) Delete(id int) (*PingdomResponse, error) {
+	return cs.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *MaintenanceService) DeleteWithContext(ctx context.Context, id int) (*PingdomResponse, error) {
 	req, err := cs.client.NewRequest("DELETE", "/maintenance/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	m := &PingdomResponse{}
 	_, err = cs.client.Do(req, m)
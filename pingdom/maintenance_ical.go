@@ -0,0 +1,286 @@
+package pingdom
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CalendarEvent is an event read from an iCalendar (.ics) file.
+type CalendarEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// ParseICal reads the events of an iCalendar stream.  Recurrence rules are not
+// expanded, only the first occurrence of a recurring event is returned.
+// Events without an end take their duration, or last a day when they are
+// all-day events.
+func ParseICal(r io.Reader) ([]CalendarEvent, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []CalendarEvent
+	var ev *CalendarEvent
+	var duration time.Duration
+	allDay := false
+
+	for _, line := range lines {
+		name, params, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			ev = &CalendarEvent{}
+			duration, allDay = 0, false
+		case name == "END" && value == "VEVENT":
+			if ev == nil {
+				return nil, fmt.Errorf("ical: END:VEVENT without BEGIN")
+			}
+			if ev.Start.IsZero() {
+				return nil, fmt.Errorf("ical: event %q has no DTSTART", ev.UID)
+			}
+			if ev.End.IsZero() {
+				switch {
+				case duration > 0:
+					ev.End = ev.Start.Add(duration)
+				case allDay:
+					ev.End = ev.Start.AddDate(0, 0, 1)
+				default:
+					ev.End = ev.Start
+				}
+			}
+			events = append(events, *ev)
+			ev = nil
+		case ev == nil:
+			continue
+		case name == "UID":
+			ev.UID = value
+		case name == "SUMMARY":
+			ev.Summary = unescapeICalText(value)
+		case name == "DTSTART":
+			ev.Start, allDay, err = parseICalTime(params, value)
+		case name == "DTEND":
+			ev.End, _, err = parseICalTime(params, value)
+		case name == "DURATION":
+			duration, err = parseICalDuration(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// unfoldICalLines joins continuation lines, which start with a space or tab.
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func splitICalLine(line string) (string, map[string]string, string) {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return strings.ToUpper(line), nil, ""
+	}
+	parts := strings.Split(line[:i], ";")
+	params := map[string]string{}
+	for _, p := range parts[1:] {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[i+1:]
+}
+
+func parseICalTime(params map[string]string, value string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, time.UTC)
+		if err != nil {
+			return t, true, fmt.Errorf("ical: invalid date %q", value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return t, false, fmt.Errorf("ical: invalid time %q", value)
+		}
+		return t, false, nil
+	}
+
+	loc := time.UTC
+	if tzid, ok := params["TZID"]; ok {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ical: unknown time zone %q", tzid)
+		}
+		loc = l
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return t, false, fmt.Errorf("ical: invalid time %q", value)
+	}
+	return t, false, nil
+}
+
+// parseICalDuration parses durations such as PT1H30M or P1D.
+func parseICalDuration(value string) (time.Duration, error) {
+	v := strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P")
+	if v == value || v == "" {
+		return 0, fmt.Errorf("ical: invalid duration %q", value)
+	}
+
+	var d time.Duration
+	inTime := false
+	n := 0
+	for _, c := range v {
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int(c-'0')
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		case c == 'W' && !inTime:
+			d += time.Duration(n) * 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			d += time.Duration(n) * 24 * time.Hour
+		case c == 'H' && inTime:
+			d += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			d += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			d += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("ical: invalid duration %q", value)
+		}
+		n = 0
+	}
+	return d, nil
+}
+
+func unescapeICalText(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+// icalMarker tags the description of maintenance windows imported from a
+// calendar event so that later imports can find them again.
+func icalMarker(uid string) string {
+	return " [ical:" + uid + "]"
+}
+
+// ImportICal creates a maintenance window for the given uptime checks for
+// every upcoming event of the calendar, e.g. an organization's change-freeze
+// calendar.  Imported windows remember the event they were created from, so
+// running the import again keeps them in sync: upcoming windows whose event
+// moved, or was removed from the calendar, are deleted and recreated as
+// needed.  An import only touches the imported windows of exactly the given
+// checks, so calendars imported for different sets of checks don't remove
+// each other's windows.  Events which already ended are ignored, and the
+// windows of events in progress start now as Pingdom rejects windows starting
+// in the past.
+func (cs *MaintenanceService) ImportICal(r io.Reader, checkIDs []int) (*ScheduleResult, error) {
+	if len(checkIDs) == 0 {
+		return nil, fmt.Errorf("Invalid value for `checkIDs`.  Must contain at least one check")
+	}
+	events, err := ParseICal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := append([]int(nil), checkIDs...)
+	sort.Ints(ids)
+	now := time.Now().Unix()
+
+	wanted := map[string]MaintenanceWindow{}
+	for _, ev := range events {
+		if ev.UID == "" || ev.End.Unix() <= now || !ev.End.After(ev.Start) {
+			continue
+		}
+		from := ev.Start.Unix()
+		if from < now {
+			from = now
+		}
+		wanted[ev.UID] = MaintenanceWindow{
+			Description: ev.Summary + icalMarker(ev.UID),
+			From:        from,
+			To:          ev.End.Unix(),
+			UptimeIDs:   intListToCDString(ids),
+		}
+	}
+
+	existing, err := cs.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScheduleResult{}
+	for _, m := range existing {
+		i := strings.LastIndex(m.Description, " [ical:")
+		if i < 0 || !strings.HasSuffix(m.Description, "]") {
+			continue
+		}
+		if !sameIDs(m.Checks.Uptime, ids) || len(m.Checks.Tms) > 0 {
+			// Imported for another set of checks.
+			continue
+		}
+		uid := m.Description[i+len(" [ical:") : len(m.Description)-1]
+		w, ok := wanted[uid]
+		if ok && w.Description == m.Description && w.From == m.From && w.To == m.To {
+			delete(wanted, uid)
+			result.Kept = append(result.Kept, m)
+			continue
+		}
+		if m.From <= now {
+			// Windows which already started can't be deleted.
+			delete(wanted, uid)
+			result.Kept = append(result.Kept, m)
+			continue
+		}
+		if _, err := cs.Delete(m.ID); err != nil {
+			return result, err
+		}
+		result.Deleted = append(result.Deleted, m)
+	}
+
+	windows := make([]MaintenanceWindow, 0, len(wanted))
+	for _, w := range wanted {
+		windows = append(windows, w)
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].From < windows[j].From })
+
+	for _, w := range windows {
+		window := w
+		m, err := cs.Create(&window)
+		if err != nil {
+			return result, err
+		}
+		m.Description = window.Description
+		m.From = window.From
+		m.To = window.To
+		m.Checks.Uptime = ids
+		result.Created = append(result.Created, *m)
+	}
+
+	return result, nil
+}
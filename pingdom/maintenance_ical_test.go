@@ -0,0 +1,180 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:freeze-1@example.com\r\n" +
+	"SUMMARY:Change freeze\\, Q4\r\n" +
+	"DTSTART:20301201T000000Z\r\n" +
+	"DTEND:20301202T120000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:freeze-2@exam\r\n" +
+	" ple.com\r\n" +
+	"SUMMARY:Datacenter move\r\n" +
+	"DTSTART;TZID=Europe/Stockholm:20301205T220000\r\n" +
+	"DURATION:PT2H30M\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday@example.com\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"DTSTART;VALUE=DATE:20301225\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:past@example.com\r\n" +
+	"SUMMARY:Long gone\r\n" +
+	"DTSTART:20000101T000000Z\r\n" +
+	"DTEND:20000101T010000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICal(t *testing.T) {
+	events, err := ParseICal(strings.NewReader(testCalendar))
+	assert.NoError(t, err)
+
+	stockholm, _ := time.LoadLocation("Europe/Stockholm")
+	start := time.Date(2030, time.December, 5, 22, 0, 0, 0, stockholm)
+
+	assert.Equal(t, 4, len(events))
+	assert.Equal(t, CalendarEvent{
+		UID:     "freeze-1@example.com",
+		Summary: "Change freeze, Q4",
+		Start:   time.Date(2030, time.December, 1, 0, 0, 0, 0, time.UTC),
+		End:     time.Date(2030, time.December, 2, 12, 0, 0, 0, time.UTC),
+	}, events[0])
+	assert.Equal(t, "freeze-2@example.com", events[1].UID)
+	assert.True(t, start.Equal(events[1].Start))
+	assert.True(t, start.Add(150*time.Minute).Equal(events[1].End))
+	assert.Equal(t, 24*time.Hour, events[2].End.Sub(events[2].Start))
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nUID:x\nEND:VEVENT\n"))
+	assert.Error(t, err)
+}
+
+func TestMaintenanceServiceImportICal(t *testing.T) {
+	setup()
+	defer teardown()
+
+	from := time.Date(2030, time.December, 1, 0, 0, 0, 0, time.UTC).Unix()
+	to := time.Date(2030, time.December, 2, 12, 0, 0, 0, time.UTC).Unix()
+
+	var created []string
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, `{"maintenance": [
+				{"id": 10, "description": "Change freeze, Q4 [ical:freeze-1@example.com]", "from": %d, "to": %d, "checks": {"uptime": [2, 1]}},
+				{"id": 11, "description": "Cancelled [ical:cancelled@example.com]", "from": %d, "to": %d, "checks": {"uptime": [1, 2]}},
+				{"id": 12, "description": "Hand made", "from": %d, "to": %d, "checks": {"uptime": [1, 2]}}
+			]}`, from, to, from, to, from, to)
+		case "POST":
+			created = append(created, r.URL.Query().Get("description"))
+			assert.Equal(t, "1,2", r.URL.Query().Get("uptimeids"))
+			fmt.Fprint(w, `{"maintenance": {"id": 20}}`)
+		}
+	})
+
+	var deleted []string
+	mux.HandleFunc("/maintenance/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = append(deleted, r.URL.Path)
+		fmt.Fprint(w, `{"message": "Maintenance window successfully deleted!"}`)
+	})
+
+	result, err := client.Maintenances.ImportICal(strings.NewReader(testCalendar), []int{2, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "created 2, deleted 1, kept 1", result.String())
+	assert.Equal(t, []string{"/maintenance/11"}, deleted)
+	assert.Equal(t, []string{
+		"Datacenter move [ical:freeze-2@example.com]",
+		"Holiday [ical:holiday@example.com]",
+	}, created)
+}
+
+func TestMaintenanceServiceImportICalTwoCalendars(t *testing.T) {
+	setup()
+	defer teardown()
+
+	from := time.Date(2030, time.December, 1, 0, 0, 0, 0, time.UTC).Unix()
+	to := time.Date(2030, time.December, 2, 12, 0, 0, 0, time.UTC).Unix()
+
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, `{"maintenance": [
+				{"id": 10, "description": "Change freeze, Q4 [ical:freeze-1@example.com]", "from": %d, "to": %d, "checks": {"uptime": [1, 2]}},
+				{"id": 11, "description": "Office move [ical:move@example.com]", "from": %d, "to": %d, "checks": {"uptime": [3]}}
+			]}`, from, to, from, to)
+		case "POST":
+			assert.Equal(t, "3", r.URL.Query().Get("uptimeids"))
+			fmt.Fprint(w, `{"maintenance": {"id": 20}}`)
+		}
+	})
+
+	var deleted []string
+	mux.HandleFunc("/maintenance/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = append(deleted, r.URL.Path)
+		fmt.Fprint(w, `{"message": "Maintenance window successfully deleted!"}`)
+	})
+
+	// The second calendar no longer has the office move, and doesn't know
+	// about the change freeze imported for checks 1 and 2.
+	office := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:cleaning@example.com\r\n" +
+		"SUMMARY:Cleaning\r\n" +
+		"DTSTART:20301210T000000Z\r\n" +
+		"DTEND:20301210T020000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	result, err := client.Maintenances.ImportICal(strings.NewReader(office), []int{3})
+	assert.NoError(t, err)
+	assert.Equal(t, "created 1, deleted 1, kept 0", result.String())
+	assert.Equal(t, []string{"/maintenance/11"}, deleted)
+}
+
+func TestMaintenanceServiceImportICalEventInProgress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Now()
+	calendar := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:freeze@example.com\r\n" +
+		"SUMMARY:Change freeze\r\n" +
+		"DTSTART:" + now.Add(-time.Hour).UTC().Format("20060102T150405Z") + "\r\n" +
+		"DTEND:" + now.Add(time.Hour).UTC().Format("20060102T150405Z") + "\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	var from, to int64
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"maintenance": []}`)
+		case "POST":
+			from, _ = strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+			to, _ = strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+			fmt.Fprint(w, `{"maintenance": {"id": 20}}`)
+		}
+	})
+
+	result, err := client.Maintenances.ImportICal(strings.NewReader(calendar), []int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, "created 1, deleted 0, kept 0", result.String())
+	assert.True(t, from >= now.Unix(), "the window must not start in the past")
+	assert.Equal(t, now.Add(time.Hour).Unix(), to)
+}
@@ -0,0 +1,170 @@
+package pingdom
+
+import (
+	"fmt"
+	"time"
+)
+
+// Recurrence frequencies of a maintenance window, the values of
+// RecurrenceType.
+const (
+	RecurrenceNone    = "none"
+	RecurrenceDaily   = "day"
+	RecurrenceWeekly  = "week"
+	RecurrenceMonthly = "month"
+)
+
+// RecurrenceRule describes how a maintenance window repeats.
+type RecurrenceRule struct {
+	// Frequency is one of RecurrenceNone, RecurrenceDaily, RecurrenceWeekly
+	// or RecurrenceMonthly.
+	Frequency string
+	// Interval repeats the window every Interval days, weeks or months, it
+	// defaults to 1.
+	Interval int
+	// Until is the last time a window may start, required unless
+	// Frequency is RecurrenceNone.
+	Until time.Time
+}
+
+// Valid determines whether the rule is one Pingdom accepts.
+func (r RecurrenceRule) Valid() error {
+	switch r.Frequency {
+	case RecurrenceNone:
+		if r.Interval != 0 {
+			return fmt.Errorf("Invalid value for `Interval`.  Must not be set without recurrence")
+		}
+		return nil
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+	default:
+		return fmt.Errorf("Invalid value %q for `Frequency`.  Must be one of none, day, week or month", r.Frequency)
+	}
+	if r.Interval < 0 {
+		return fmt.Errorf("Invalid value for `Interval`.  Must be positive")
+	}
+	if r.Until.IsZero() {
+		return fmt.Errorf("Invalid value for `Until`.  Must contain time")
+	}
+	return nil
+}
+
+// period returns the shortest time between two windows of the rule, zero
+// when it does not recur.
+func (r RecurrenceRule) period() time.Duration {
+	every := time.Duration(r.Interval)
+	if every < 1 {
+		every = 1
+	}
+	switch r.Frequency {
+	case RecurrenceDaily:
+		return every * 24 * time.Hour
+	case RecurrenceWeekly:
+		return every * 7 * 24 * time.Hour
+	case RecurrenceMonthly:
+		return every * 28 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Recurrence returns the recurrence rule of the window.
+func (ck *MaintenanceWindow) Recurrence() RecurrenceRule {
+	r := RecurrenceRule{Frequency: ck.RecurrenceType, Interval: ck.RepeatEvery}
+	if r.Frequency == "" {
+		r.Frequency = RecurrenceNone
+	}
+	if ck.EffectiveTo != 0 {
+		r.Until = time.Unix(int64(ck.EffectiveTo), 0)
+	}
+	return r
+}
+
+// SetRecurrence validates the rule and sets the recurrence fields of the
+// window from it.
+func (ck *MaintenanceWindow) SetRecurrence(r RecurrenceRule) error {
+	if err := r.Valid(); err != nil {
+		return err
+	}
+	ck.RecurrenceType = r.Frequency
+	ck.RepeatEvery = r.Interval
+	ck.EffectiveTo = 0
+	if !r.Until.IsZero() {
+		ck.EffectiveTo = int(r.Until.Unix())
+	}
+	return ck.validRecurrence()
+}
+
+// validRecurrence checks the recurrence fields of the window against each
+// other and against its duration.  A recurring window without EffectiveTo is
+// accepted, Pingdom ends the recurrence with the first window then.
+func (ck *MaintenanceWindow) validRecurrence() error {
+	if ck.RecurrenceType == "" {
+		if ck.RepeatEvery != 0 {
+			return fmt.Errorf("Invalid value for `RepeatEvery`.  Must set `RecurrenceType`")
+		}
+		return nil
+	}
+
+	r := ck.Recurrence()
+	if r.Frequency == RecurrenceNone {
+		if r.Interval != 0 {
+			return fmt.Errorf("Invalid value for `RepeatEvery`.  Must not be set without recurrence")
+		}
+		return nil
+	}
+	if r.Until.IsZero() {
+		r.Until = time.Unix(ck.To, 0)
+	}
+	if err := r.Valid(); err != nil {
+		return fmt.Errorf("Invalid recurrence: %v", err)
+	}
+	if ck.EffectiveTo != 0 && int64(ck.EffectiveTo) < ck.To {
+		return fmt.Errorf("Invalid value for `EffectiveTo`.  Must be after `To`")
+	}
+	if time.Duration(ck.To-ck.From)*time.Second >= r.period() {
+		return fmt.Errorf("Invalid value for `To`.  The window must be shorter than its recurrence")
+	}
+	return nil
+}
+
+// ScheduleDaily returns a maintenance window of the given checks starting
+// at start and repeating every day until until.
+func ScheduleDaily(description string, checkIDs []int, start time.Time, duration time.Duration, until time.Time) (*MaintenanceWindow, error) {
+	return scheduleWindow(description, checkIDs, start, duration, RecurrenceRule{Frequency: RecurrenceDaily, Until: until})
+}
+
+// ScheduleWeekly returns a maintenance window of the given checks repeating
+// every week on weekday until until.  The first window starts at the clock
+// time of start, on the first weekday from start.
+func ScheduleWeekly(description string, checkIDs []int, weekday time.Weekday, start time.Time, duration time.Duration, until time.Time) (*MaintenanceWindow, error) {
+	days := (int(weekday) - int(start.Weekday()) + 7) % 7
+	return scheduleWindow(description, checkIDs, start.AddDate(0, 0, days), duration, RecurrenceRule{Frequency: RecurrenceWeekly, Until: until})
+}
+
+// ScheduleMonthly returns a maintenance window of the given checks starting
+// at start and repeating every month on the same day until until.
+func ScheduleMonthly(description string, checkIDs []int, start time.Time, duration time.Duration, until time.Time) (*MaintenanceWindow, error) {
+	return scheduleWindow(description, checkIDs, start, duration, RecurrenceRule{Frequency: RecurrenceMonthly, Until: until})
+}
+
+func scheduleWindow(description string, checkIDs []int, start time.Time, duration time.Duration, r RecurrenceRule) (*MaintenanceWindow, error) {
+	if duration < time.Minute {
+		return nil, fmt.Errorf("Invalid value for `Duration`.  Must be at least one minute")
+	}
+	if len(checkIDs) == 0 {
+		return nil, fmt.Errorf("Invalid value for `checkIDs`.  Must contain at least one check")
+	}
+
+	ck := &MaintenanceWindow{
+		Description: description,
+		From:        start.Unix(),
+		To:          start.Add(duration).Unix(),
+		UptimeIDs:   intListToCDString(checkIDs),
+	}
+	if err := ck.SetRecurrence(r); err != nil {
+		return nil, err
+	}
+	if err := ck.Valid(); err != nil {
+		return nil, err
+	}
+	return ck, nil
+}
@@ -0,0 +1,97 @@
+package pingdom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurrenceRuleValid(t *testing.T) {
+	until := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rule  RecurrenceRule
+		valid bool
+	}{
+		{RecurrenceRule{Frequency: RecurrenceNone}, true},
+		{RecurrenceRule{Frequency: RecurrenceNone, Interval: 2}, false},
+		{RecurrenceRule{Frequency: RecurrenceWeekly, Interval: 2, Until: until}, true},
+		{RecurrenceRule{Frequency: RecurrenceDaily, Until: until}, true},
+		{RecurrenceRule{Frequency: RecurrenceMonthly}, false},
+		{RecurrenceRule{Frequency: RecurrenceDaily, Interval: -1, Until: until}, false},
+		{RecurrenceRule{Frequency: "year", Until: until}, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, tt.rule.Valid() == nil, "%+v", tt.rule)
+	}
+}
+
+func TestMaintenanceWindowRecurrence(t *testing.T) {
+	from := time.Date(2020, 9, 1, 22, 0, 0, 0, time.UTC)
+	until := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	mw := MaintenanceWindow{Description: "deploys", From: from.Unix(), To: from.Add(2 * time.Hour).Unix()}
+
+	assert.NoError(t, mw.SetRecurrence(RecurrenceRule{Frequency: RecurrenceWeekly, Interval: 2, Until: until}))
+	assert.Equal(t, "week", mw.RecurrenceType)
+	assert.Equal(t, 2, mw.RepeatEvery)
+	assert.Equal(t, int(until.Unix()), mw.EffectiveTo)
+	assert.NoError(t, mw.Valid())
+	assert.Equal(t, RecurrenceRule{Frequency: RecurrenceWeekly, Interval: 2, Until: until.Local()}, mw.Recurrence())
+
+	assert.Error(t, mw.SetRecurrence(RecurrenceRule{Frequency: RecurrenceDaily, Until: from}), "ends before the first window")
+	long := MaintenanceWindow{Description: "long", From: from.Unix(), To: from.Add(25 * time.Hour).Unix()}
+	assert.Error(t, long.SetRecurrence(RecurrenceRule{Frequency: RecurrenceDaily, Until: until}), "longer than a day")
+	assert.NoError(t, long.SetRecurrence(RecurrenceRule{Frequency: RecurrenceDaily, Interval: 2, Until: until}))
+
+	assert.Error(t, (&MaintenanceWindow{Description: "d", From: 1, To: 2, RepeatEvery: 1}).Valid())
+	assert.Error(t, (&MaintenanceWindow{Description: "d", From: 1, To: 2, RecurrenceType: RecurrenceNone, RepeatEvery: 1}).Valid())
+	assert.NoError(t, (&MaintenanceWindow{Description: "d", From: 1, To: 2, RecurrenceType: RecurrenceDaily}).Valid(), "Pingdom defaults EffectiveTo to To")
+	assert.Error(t, (&MaintenanceWindow{Description: "d", From: 1, To: 2, RecurrenceType: RecurrenceDaily}).SetRecurrence(RecurrenceRule{Frequency: RecurrenceDaily}))
+	assert.NoError(t, (&MaintenanceWindow{Description: "d", From: 1, To: 2, RecurrenceType: RecurrenceNone, EffectiveTo: 2}).Valid())
+}
+
+func TestScheduleWeekly(t *testing.T) {
+	// Tuesday 22:00
+	start := time.Date(2020, 9, 1, 22, 0, 0, 0, time.UTC)
+	until := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	mw, err := ScheduleWeekly("deploys", []int{12, 34}, time.Saturday, start, 3*time.Hour, until)
+	assert.NoError(t, err)
+	saturday := time.Date(2020, 9, 5, 22, 0, 0, 0, time.UTC)
+	assert.Equal(t, &MaintenanceWindow{
+		Description:    "deploys",
+		From:           saturday.Unix(),
+		To:             saturday.Add(3 * time.Hour).Unix(),
+		RecurrenceType: "week",
+		EffectiveTo:    int(until.Unix()),
+		UptimeIDs:      "12,34",
+	}, mw)
+
+	mw, err = ScheduleWeekly("deploys", []int{12}, time.Tuesday, start, time.Hour, until)
+	assert.NoError(t, err)
+	assert.Equal(t, start.Unix(), mw.From)
+
+	_, err = ScheduleWeekly("deploys", []int{12}, time.Saturday, start, 8*24*time.Hour, until)
+	assert.Error(t, err)
+	_, err = ScheduleWeekly("deploys", nil, time.Saturday, start, time.Hour, until)
+	assert.Error(t, err)
+	_, err = ScheduleWeekly("", []int{12}, time.Saturday, start, time.Hour, until)
+	assert.Error(t, err)
+}
+
+func TestScheduleDailyAndMonthly(t *testing.T) {
+	start := time.Date(2020, 9, 1, 2, 0, 0, 0, time.UTC)
+	until := start.AddDate(0, 6, 0)
+
+	mw, err := ScheduleDaily("backups", []int{12}, start, 30*time.Minute, until)
+	assert.NoError(t, err)
+	assert.Equal(t, "day", mw.RecurrenceType)
+	assert.Equal(t, start.Unix(), mw.From)
+
+	mw, err = ScheduleMonthly("patching", []int{12}, start, 4*time.Hour, until)
+	assert.NoError(t, err)
+	assert.Equal(t, "month", mw.RecurrenceType)
+
+	_, err = ScheduleDaily("backups", []int{12}, start, 30*time.Second, until)
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+package pingdom
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaintenanceSchedule describes maintenance windows recurring on a cron
+// schedule for the uptime checks carrying any of the given tags.
+//
+// Windows are identified by their description, so every schedule needs a
+// description which is unique among the maintenance windows of the account.
+type MaintenanceSchedule struct {
+	Description string
+	Cron        string
+	Duration    time.Duration
+	Tags        []string
+	// Location the cron expression is evaluated in, defaults to UTC.
+	Location *time.Location
+}
+
+// ScheduleResult reports the changes made by MaterializeSchedule.
+type ScheduleResult struct {
+	Created []MaintenanceResponse
+	Deleted []MaintenanceResponse
+	// Kept are the existing windows which already match an occurrence.
+	Kept []MaintenanceResponse
+}
+
+// Valid determines whether the MaintenanceSchedule contains valid fields.
+func (ms *MaintenanceSchedule) Valid() error {
+	if ms.Description == "" {
+		return fmt.Errorf("Invalid value for `Description`.  Must contain non-empty string")
+	}
+	if ms.Duration < time.Minute {
+		return fmt.Errorf("Invalid value for `Duration`.  Must be at least one minute")
+	}
+	if len(ms.Tags) == 0 {
+		return fmt.Errorf("Invalid value for `Tags`.  Must select checks by at least one tag")
+	}
+	_, err := ParseCron(ms.Cron)
+	return err
+}
+
+// MaterializeSchedule makes sure a maintenance window exists for every
+// occurrence of the schedule within the given horizon from now.  Upcoming
+// windows created for the schedule which no longer match an occurrence, or
+// which cover a different set of checks, are deleted.  Past windows are left
+// alone as Pingdom only allows deleting future windows.
+//
+// Run it periodically, e.g. daily with a horizon of a week, to keep the
+// windows rolling.
+func (cs *MaintenanceService) MaterializeSchedule(schedule MaintenanceSchedule, horizon time.Duration) (*ScheduleResult, error) {
+	if err := schedule.Valid(); err != nil {
+		return nil, err
+	}
+	cron, _ := ParseCron(schedule.Cron)
+	loc := schedule.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	checks, err := cs.client.Checks.ListByTags(schedule.Tags...)
+	if err != nil {
+		return nil, err
+	}
+	checkIDs := make([]int, 0, len(checks))
+	for _, c := range checks {
+		checkIDs = append(checkIDs, c.ID)
+	}
+	sort.Ints(checkIDs)
+
+	existing, err := cs.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(loc)
+	wanted := map[int64]bool{}
+	for _, start := range cron.Between(now, now.Add(horizon)) {
+		wanted[start.Unix()] = true
+	}
+
+	result := &ScheduleResult{}
+	for _, m := range existing {
+		if m.Description != schedule.Description || m.From <= now.Unix() {
+			continue
+		}
+		if wanted[m.From] && m.To == m.From+int64(schedule.Duration/time.Second) && sameIDs(m.Checks.Uptime, checkIDs) {
+			delete(wanted, m.From)
+			result.Kept = append(result.Kept, m)
+			continue
+		}
+		if _, err := cs.Delete(m.ID); err != nil {
+			return result, err
+		}
+		result.Deleted = append(result.Deleted, m)
+	}
+
+	if len(checkIDs) == 0 {
+		return result, nil
+	}
+
+	starts := make([]int64, 0, len(wanted))
+	for from := range wanted {
+		starts = append(starts, from)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	for _, from := range starts {
+		window := MaintenanceWindow{
+			Description: schedule.Description,
+			From:        from,
+			To:          from + int64(schedule.Duration/time.Second),
+			UptimeIDs:   intListToCDString(checkIDs),
+		}
+		m, err := cs.Create(&window)
+		if err != nil {
+			return result, err
+		}
+		m.Description = window.Description
+		m.From = window.From
+		m.To = window.To
+		m.Checks.Uptime = checkIDs
+		result.Created = append(result.Created, *m)
+	}
+
+	return result, nil
+}
+
+func sameIDs(a []int, sorted []int) bool {
+	if len(a) != len(sorted) {
+		return false
+	}
+	c := append([]int(nil), a...)
+	sort.Ints(c)
+	for i := range c {
+		if c[i] != sorted[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a short description of the changes.
+func (r *ScheduleResult) String() string {
+	return fmt.Sprintf("created %d, deleted %d, kept %d", len(r.Created), len(r.Deleted), len(r.Kept))
+}
@@ -0,0 +1,118 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceScheduleValid(t *testing.T) {
+	valid := MaintenanceSchedule{Description: "Nightly", Cron: "0 2 * * *", Duration: time.Hour, Tags: []string{"db"}}
+	assert.NoError(t, valid.Valid())
+
+	invalid := []MaintenanceSchedule{
+		{Cron: "0 2 * * *", Duration: time.Hour, Tags: []string{"db"}},
+		{Description: "Nightly", Cron: "0 2 * *", Duration: time.Hour, Tags: []string{"db"}},
+		{Description: "Nightly", Cron: "0 2 * * *", Tags: []string{"db"}},
+		{Description: "Nightly", Cron: "0 2 * * *", Duration: time.Hour},
+	}
+	for _, s := range invalid {
+		assert.Error(t, s.Valid())
+	}
+}
+
+func TestMaintenanceServiceMaterializeSchedule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cron, _ := ParseCron("@hourly")
+	first := cron.Next(time.Now()).Unix()
+	hour := int64(3600)
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "db,cache", r.URL.Query().Get("tags"))
+		fmt.Fprint(w, `{"checks": [{"id": 2}, {"id": 1}]}`)
+	})
+
+	var created []string
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, `{"maintenance": [
+				{"id": 10, "description": "Hourly", "from": %d, "to": %d, "checks": {"uptime": [1, 2]}},
+				{"id": 11, "description": "Hourly", "from": %d, "to": %d, "checks": {"uptime": [1, 2]}},
+				{"id": 12, "description": "Hourly", "from": 1, "to": 2, "checks": {"uptime": [1, 2]}},
+				{"id": 13, "description": "Other", "from": %d, "to": %d, "checks": {"uptime": [1]}}
+			]}`, first, first+600, first+60, first+660, first+60, first+660)
+		case "POST":
+			created = append(created, r.URL.Query().Get("from"))
+			assert.Equal(t, "1,2", r.URL.Query().Get("uptimeids"))
+			fmt.Fprint(w, `{"maintenance": {"id": 20}}`)
+		default:
+			t.Errorf("unexpected method %v", r.Method)
+		}
+	})
+
+	var deleted []string
+	mux.HandleFunc("/maintenance/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = append(deleted, r.URL.Path)
+		fmt.Fprint(w, `{"message": "Maintenance window successfully deleted!"}`)
+	})
+
+	schedule := MaintenanceSchedule{
+		Description: "Hourly",
+		Cron:        "@hourly",
+		Duration:    10 * time.Minute,
+		Tags:        []string{"db", "cache"},
+	}
+	result, err := client.Maintenances.MaterializeSchedule(schedule, 3*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, "created 2, deleted 1, kept 1", result.String())
+	assert.Equal(t, 10, result.Kept[0].ID)
+	assert.Equal(t, []string{"/maintenance/11"}, deleted)
+	assert.Equal(t, []string{fmt.Sprint(first + hour), fmt.Sprint(first + 2*hour)}, created)
+}
+
+func TestMaintenanceServiceMaterializeScheduleListsAllPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cron, _ := ParseCron("@hourly")
+	first := cron.Next(time.Now()).Unix()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks": [{"id": 1}]}`)
+	})
+	var created []string
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			created = append(created, r.URL.Query().Get("from"))
+			fmt.Fprint(w, `{"maintenance": {"id": 20}}`)
+			return
+		}
+		// The window of the schedule is on the second page.
+		if r.URL.Query().Get("offset") == fmt.Sprint(maintenancesPageSize) {
+			fmt.Fprintf(w, `{"maintenance": [{"id": 10, "description": "Hourly", "from": %d, "to": %d, "checks": {"uptime": [1]}}]}`, first, first+600)
+			return
+		}
+		var windows []string
+		for i := 0; i < maintenancesPageSize; i++ {
+			windows = append(windows, fmt.Sprintf(`{"id": %d, "description": "Other", "from": 1, "to": 2}`, 100+i))
+		}
+		fmt.Fprintf(w, `{"maintenance": [%s]}`, strings.Join(windows, ","))
+	})
+
+	schedule := MaintenanceSchedule{Description: "Hourly", Cron: "@hourly", Duration: 10 * time.Minute, Tags: []string{"db"}}
+	result, err := client.Maintenances.MaterializeSchedule(schedule, time.Hour)
+	assert.NoError(t, err)
+	assert.Empty(t, created)
+	if assert.Len(t, result.Kept, 1) {
+		assert.Equal(t, 10, result.Kept[0].ID)
+	}
+}
@@ -164,3 +164,18 @@ func TestMaintenanceServiceDelete(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, msg, "Maintenances.Delete() should return correct result")
 }
+
+func TestMaintenanceServiceListWithMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"maintenance": [{"id": 1, "description": "One"}, {"id": 2, "description": "Two"}]}`)
+	})
+
+	list, err := client.Maintenances.ListWithMeta(map[string]string{"limit": "10"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(list.Maintenances))
+	assert.Equal(t, ListMeta{Total: 2, Limit: 10}, list.Meta)
+}
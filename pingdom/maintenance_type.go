@@ -84,7 +84,7 @@ func (ck *MaintenanceWindow) Valid() error {
 		return fmt.Errorf("Invalid value for `To`.  Must contain time")
 	}
 
-	return nil
+	return ck.validRecurrence()
 }
 
 // DeleteParams returns a map of parameters for an MaintenanceWindow that can be sent along.
@@ -0,0 +1,98 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type forceKey struct{}
+
+// Force returns a context letting the updates and deletes made with it
+// through checks lacking the ClientConfig.ManagedTag.
+func Force(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceKey{}, true)
+}
+
+func forced(ctx context.Context) bool {
+	f, _ := ctx.Value(forceKey{}).(bool)
+	return f
+}
+
+// ManagedTag returns the tag marking the checks owned by the client, empty
+// when checks are not guarded.
+func (pc *Client) ManagedTag() string {
+	return pc.managedTag
+}
+
+// managedParams returns a copy of the params of a check with the managed tag
+// added to its tags.
+func (pc *Client) managedParams(params map[string]string) map[string]string {
+	if pc.managedTag == "" {
+		return params
+	}
+	tags := SplitTags(params["tags"])
+	for _, t := range tags {
+		if t == pc.managedTag {
+			return params
+		}
+	}
+
+	copied := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		copied[k] = v
+	}
+	copied["tags"] = strings.Join(append(tags, pc.managedTag), ",")
+	return copied
+}
+
+// guardManaged returns ErrUnmanagedCheck, wrapped, when the check lacks the
+// managed tag and ctx was not made with Force.
+func (cs *CheckService) guardManaged(ctx context.Context, id int) error {
+	if cs.client.managedTag == "" || forced(ctx) {
+		return nil
+	}
+	check, err := cs.ReadWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !hasTags(*check, []string{cs.client.managedTag}) {
+		return cs.client.unmanagedError([]int{id})
+	}
+	return nil
+}
+
+// guardManagedIDs is like guardManaged for several checks at once, the
+// managed checks are listed in a single request.
+func (cs *CheckService) guardManagedIDs(ctx context.Context, ids []int) error {
+	if cs.client.managedTag == "" || forced(ctx) {
+		return nil
+	}
+	checks, err := cs.ListByTagsWithContext(ctx, cs.client.managedTag)
+	if err != nil {
+		return err
+	}
+	managed := map[int]bool{}
+	for _, check := range checks {
+		managed[check.ID] = true
+	}
+	var unmanaged []int
+	for _, id := range ids {
+		if !managed[id] {
+			unmanaged = append(unmanaged, id)
+		}
+	}
+	return cs.client.unmanagedError(unmanaged)
+}
+
+// unmanagedError returns ErrUnmanagedCheck, wrapped, naming the checks
+// lacking the managed tag, or nil when there are none.
+func (pc *Client) unmanagedError(ids []int) error {
+	switch len(ids) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("check %d lacks the %q tag: %w", ids[0], pc.managedTag, ErrUnmanagedCheck)
+	}
+	return fmt.Errorf("checks %s lack the %q tag: %w", intListToCDString(ids), pc.managedTag, ErrUnmanagedCheck)
+}
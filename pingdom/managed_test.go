@@ -0,0 +1,161 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagedTag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "my_api_key", ManagedTag: "managed-by-ci"})
+	assert.NoError(t, err)
+	c.BaseURL, _ = url.Parse(server.URL)
+	assert.Equal(t, "managed-by-ci", c.ManagedTag())
+
+	var sent []string
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		sent = append(sent, r.FormValue("tags"))
+		fmt.Fprint(w, `{"check":{"id":1,"name":"web"}}`)
+	})
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"check":{"id":1,"name":"web","tags":[{"name":"prod"},{"name":"managed-by-ci"}]}}`)
+		case "PUT":
+			sent = append(sent, r.FormValue("tags"))
+			fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"message":"Deletion of check was successful!"}`)
+		}
+	})
+	deleted := false
+	mux.HandleFunc("/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"check":{"id":2,"name":"manual","tags":[{"name":"prod"}]}}`)
+		case "DELETE":
+			deleted = true
+			fmt.Fprint(w, `{"message":"Deletion of check was successful!"}`)
+		default:
+			t.Errorf("unexpected %s of an unmanaged check", r.Method)
+		}
+	})
+
+	check := &HttpCheck{Name: "web", Hostname: "example.com", Resolution: 5, Tags: "prod"}
+	_, err = c.Checks.Create(check)
+	assert.NoError(t, err)
+	_, err = c.Checks.Update(1, check)
+	assert.NoError(t, err)
+	_, err = c.Checks.Update(1, &HttpCheck{Name: "web", Hostname: "example.com", Resolution: 5, Tags: "managed-by-ci"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prod,managed-by-ci", "prod,managed-by-ci", "managed-by-ci"}, sent)
+	assert.Equal(t, "prod", check.Tags, "the check is not modified")
+	_, err = c.Checks.Delete(1)
+	assert.NoError(t, err)
+
+	_, err = c.Checks.Update(2, check)
+	assert.True(t, errors.Is(err, ErrUnmanagedCheck))
+	_, err = c.Checks.Delete(2)
+	assert.True(t, errors.Is(err, ErrUnmanagedCheck))
+	assert.EqualError(t, err, `check 2 lacks the "managed-by-ci" tag: check is not managed by this client`)
+	assert.False(t, deleted)
+
+	_, err = c.Checks.DeleteWithContext(Force(context.Background()), 2)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+func TestManagedTagSetPaused(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "my_api_key", ManagedTag: "managed-by-ci"})
+	assert.NoError(t, err)
+	c.BaseURL, _ = url.Parse(server.URL)
+
+	var paused []string
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			assert.Equal(t, "managed-by-ci", r.URL.Query().Get("tags"))
+			fmt.Fprint(w, `{"checks":[{"id":1,"tags":[{"name":"managed-by-ci"}]},{"id":2,"tags":[{"name":"managed-by-ci"}]}]}`)
+		case "PUT":
+			paused = append(paused, r.FormValue("checkids"))
+			fmt.Fprint(w, `{"message":"Modification of checks was successful!"}`)
+		}
+	})
+
+	_, err = c.Checks.SetPaused(true, 1, 2)
+	assert.NoError(t, err)
+	_, err = c.Checks.SetPaused(true, 1, 3, 4)
+	assert.True(t, errors.Is(err, ErrUnmanagedCheck))
+	assert.EqualError(t, err, `checks 3,4 lack the "managed-by-ci" tag: check is not managed by this client`)
+	assert.Equal(t, []string{"1,2"}, paused)
+
+	_, err = c.Checks.SetPausedWithContext(Force(context.Background()), true, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1,2", "1,3"}, paused)
+}
+
+func TestManagedTagContactMerge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "my_api_key", ManagedTag: "managed-by-ci"})
+	assert.NoError(t, err)
+	c.BaseURL, _ = url.Parse(server.URL)
+
+	var updated []string
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks":[{"id":1},{"id":2}]}`)
+	})
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			updated = append(updated, r.URL.Path)
+			fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+			return
+		}
+		fmt.Fprint(w, `{"check":{"id":1,"userids":[3],"tags":[{"name":"managed-by-ci"}]}}`)
+	})
+	mux.HandleFunc("/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			updated = append(updated, r.URL.Path)
+			fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+			return
+		}
+		fmt.Fprint(w, `{"check":{"id":2,"userids":[3],"tags":[{"name":"prod"}]}}`)
+	})
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"teams":[]}`)
+	})
+	mux.HandleFunc("/alerting/contacts/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"Deleted"}`)
+	})
+
+	report, err := c.Contacts.Merge(1, []int{3}, true)
+	assert.NoError(t, err, "dry runs report unmanaged checks")
+	assert.Equal(t, map[int][]int{1: {1}, 2: {1}}, report.Checks)
+
+	_, err = c.Contacts.Merge(1, []int{3}, false)
+	assert.True(t, errors.Is(err, ErrUnmanagedCheck))
+	assert.EqualError(t, err, `check 2 lacks the "managed-by-ci" tag: check is not managed by this client`)
+	assert.Empty(t, updated)
+
+	_, err = c.Contacts.MergeWithContext(Force(context.Background()), 1, []int{3}, false)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"/checks/1", "/checks/2"}, updated)
+}
+
+func TestManagedTagInvalid(t *testing.T) {
+	_, err := NewClientWithConfig(ClientConfig{APIToken: "my_api_key", ManagedTag: "a,b"})
+	assert.Error(t, err)
+}
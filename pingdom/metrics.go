@@ -0,0 +1,188 @@
+package pingdom
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// FailureMetrics summarizes the reliability of a check over a time window.
+type FailureMetrics struct {
+	// Outages is the number of down periods in the window.
+	Outages int
+	// Uptime and Downtime are the total time the check was up and down,
+	// excluding unmonitored periods and maintenance when requested.
+	Uptime   time.Duration
+	Downtime time.Duration
+	// MTTR is the mean time to recovery, the average outage duration.
+	MTTR time.Duration
+	// MTBF is the mean time between failures, the average time the check
+	// was up per outage.  Both are zero when there were no outages.
+	MTBF time.Duration
+}
+
+// TimeWindow is a half-open period of time [From, To) in Unix seconds.
+type TimeWindow struct {
+	From, To int64
+}
+
+// ComputeFailureMetrics computes the failure metrics from the states of a
+// summary outage.  States are clipped to the window [from, to) and the
+// excluded periods, such as maintenance windows, are not counted.
+func ComputeFailureMetrics(states []SummaryOutageState, from, to int64, excluded []TimeWindow) FailureMetrics {
+	excluded = mergeIntervals(excluded)
+
+	var m FailureMetrics
+	for _, s := range states {
+		span := TimeWindow{max64(s.TimeFrom, from), min64(s.TimeTo, to)}
+		if span.To <= span.From {
+			continue
+		}
+		d := time.Duration(remainingSeconds(span, excluded)) * time.Second
+		if d == 0 {
+			continue
+		}
+		switch s.Status {
+		case "up":
+			m.Uptime += d
+		case "down":
+			m.Downtime += d
+			m.Outages++
+		}
+	}
+
+	if m.Outages > 0 {
+		m.MTTR = m.Downtime / time.Duration(m.Outages)
+		m.MTBF = m.Uptime / time.Duration(m.Outages)
+	}
+	return m
+}
+
+// FailureMetrics computes the MTTR and MTBF of an uptime check between from
+// and to.  With excludeMaintenance set, the maintenance windows covering the
+// check are left out of the computation.
+func (cs *CheckService) FailureMetrics(id int, from, to time.Time, excludeMaintenance bool) (*FailureMetrics, error) {
+	summary, err := cs.SummaryOutage(SummaryOutageRequest{
+		Id:   id,
+		From: from.Unix(),
+		To:   to.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var excluded []TimeWindow
+	if excludeMaintenance {
+		excluded, err = cs.client.Maintenances.checkWindows(context.Background(), id, from.Unix(), to.Unix())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m := ComputeFailureMetrics(summary.Summary.States, from.Unix(), to.Unix(), excluded)
+	return &m, nil
+}
+
+// checkWindows returns the maintenance periods covering the uptime check
+// between from and to, with recurring windows expanded.
+func (cs *MaintenanceService) checkWindows(ctx context.Context, checkID int, from, to int64) ([]TimeWindow, error) {
+	maintenances, err := cs.ListAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []TimeWindow
+	for _, m := range maintenances {
+		for _, id := range m.Checks.Uptime {
+			if id == checkID {
+				windows = append(windows, maintenanceOccurrences(m, from, to)...)
+				break
+			}
+		}
+	}
+	return windows, nil
+}
+
+// maintenanceOccurrences expands a maintenance window into its occurrences
+// overlapping [from, to).
+func maintenanceOccurrences(m MaintenanceResponse, from, to int64) []TimeWindow {
+	var occurrences []TimeWindow
+	start := time.Unix(m.From, 0).UTC()
+	length := m.To - m.From
+	every := m.RepeatEvery
+	if every < 1 {
+		every = 1
+	}
+
+	for i := 0; ; i++ {
+		var s time.Time
+		switch m.RecurrenceType {
+		case RecurrenceDaily:
+			s = start.AddDate(0, 0, i*every)
+		case RecurrenceWeekly:
+			s = start.AddDate(0, 0, 7*i*every)
+		case RecurrenceMonthly:
+			s = start.AddDate(0, i*every, 0)
+		default:
+			if i > 0 {
+				return occurrences
+			}
+			s = start
+		}
+
+		o := TimeWindow{s.Unix(), s.Unix() + length}
+		if o.From >= to || (i > 0 && m.EffectiveTo != 0 && o.From > m.EffectiveTo) {
+			return occurrences
+		}
+		if o.To > from {
+			occurrences = append(occurrences, o)
+		}
+	}
+}
+
+// mergeIntervals sorts the intervals and merges the overlapping ones.
+func mergeIntervals(in []TimeWindow) []TimeWindow {
+	if len(in) == 0 {
+		return nil
+	}
+	sorted := append([]TimeWindow(nil), in...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].From < sorted[j].From })
+
+	out := []TimeWindow{sorted[0]}
+	for _, iv := range sorted[1:] {
+		last := &out[len(out)-1]
+		if iv.From <= last.To {
+			last.To = max64(last.To, iv.To)
+			continue
+		}
+		out = append(out, iv)
+	}
+	return out
+}
+
+// remainingSeconds returns the length of span not covered by the merged
+// excluded intervals.
+func remainingSeconds(span TimeWindow, excluded []TimeWindow) int64 {
+	total := span.To - span.From
+	for _, e := range excluded {
+		overlap := min64(span.To, e.To) - max64(span.From, e.From)
+		if overlap > 0 {
+			total -= overlap
+		}
+	}
+	return total
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,81 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeFailureMetrics(t *testing.T) {
+	states := []SummaryOutageState{
+		{Status: "up", TimeFrom: 0, TimeTo: 3600},
+		{Status: "down", TimeFrom: 3600, TimeTo: 3900},
+		{Status: "up", TimeFrom: 3900, TimeTo: 7200},
+		{Status: "unknown", TimeFrom: 7200, TimeTo: 7300},
+		{Status: "down", TimeFrom: 7300, TimeTo: 7400},
+		{Status: "up", TimeFrom: 7400, TimeTo: 10000},
+	}
+
+	m := ComputeFailureMetrics(states, 0, 9000, nil)
+	assert.Equal(t, 2, m.Outages)
+	assert.Equal(t, 400*time.Second, m.Downtime)
+	assert.Equal(t, (3600+3300+1600)*time.Second, m.Uptime)
+	assert.Equal(t, 200*time.Second, m.MTTR)
+	assert.Equal(t, 4250*time.Second, m.MTBF)
+
+	// The second outage happened during maintenance.
+	m = ComputeFailureMetrics(states, 0, 9000, []TimeWindow{{7200, 7500}, {7250, 7400}})
+	assert.Equal(t, 1, m.Outages)
+	assert.Equal(t, 300*time.Second, m.MTTR)
+	assert.Equal(t, (3600+3300+1500)*time.Second, m.MTBF)
+
+	m = ComputeFailureMetrics(states[:1], 0, 9000, nil)
+	assert.Equal(t, FailureMetrics{Uptime: time.Hour}, m)
+}
+
+func TestMaintenanceOccurrences(t *testing.T) {
+	day := int64(24 * 3600)
+	once := MaintenanceResponse{From: 100, To: 200}
+	assert.Equal(t, []TimeWindow{{100, 200}}, maintenanceOccurrences(once, 0, 1000))
+	assert.Empty(t, maintenanceOccurrences(once, 200, 1000))
+
+	daily := MaintenanceResponse{From: 100, To: 200, RecurrenceType: "day", RepeatEvery: 2, EffectiveTo: 100 + 4*day}
+	assert.Equal(t, []TimeWindow{
+		{100 + 2*day, 200 + 2*day},
+		{100 + 4*day, 200 + 4*day},
+	}, maintenanceOccurrences(daily, 1000, 10*day))
+}
+
+func TestCheckServiceFailureMetrics(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"summary": {"states": [
+			{"status": "up", "timefrom": 0, "timeto": 3600},
+			{"status": "down", "timefrom": 3600, "timeto": 3900},
+			{"status": "up", "timefrom": 3900, "timeto": 7200},
+			{"status": "down", "timefrom": 7200, "timeto": 7500},
+			{"status": "up", "timefrom": 7500, "timeto": 9000}
+		]}}`)
+	})
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"maintenance": [
+			{"id": 1, "from": 7200, "to": 7500, "recurrencetype": "none", "checks": {"uptime": [12345]}},
+			{"id": 2, "from": 0, "to": 3600, "recurrencetype": "none", "checks": {"uptime": [999]}}
+		]}`)
+	})
+
+	m, err := client.Checks.FailureMetrics(12345, time.Unix(0, 0), time.Unix(9000, 0), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, m.Outages)
+
+	m, err = client.Checks.FailureMetrics(12345, time.Unix(0, 0), time.Unix(9000, 0), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, m.Outages)
+	assert.Equal(t, 5*time.Minute, m.MTTR)
+	assert.Equal(t, (3600+3300+1500)*time.Second, m.MTBF)
+}
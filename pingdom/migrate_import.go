@@ -0,0 +1,254 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ImportResult holds the checks translated from another uptime provider,
+// ready to be created with CheckService.Create.
+type ImportResult struct {
+	Checks []CheckConfig
+	// Skipped lists the monitors which have no Pingdom equivalent.
+	Skipped []SkippedMonitor
+}
+
+// SkippedMonitor is a monitor of another provider which was not imported.
+type SkippedMonitor struct {
+	Name   string
+	Reason string
+}
+
+func (r *ImportResult) skip(name, format string, args ...interface{}) {
+	r.Skipped = append(r.Skipped, SkippedMonitor{Name: name, Reason: fmt.Sprintf(format, args...)})
+}
+
+// flexInt decodes integers which providers send either as numbers or
+// strings, an empty string decodes to 0.
+type flexInt int
+
+func (i *flexInt) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*i = 0
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*i = flexInt(n)
+	return nil
+}
+
+// uptimeRobotMonitor is a monitor as returned by the UptimeRobot
+// getMonitors API.
+type uptimeRobotMonitor struct {
+	FriendlyName string  `json:"friendly_name"`
+	URL          string  `json:"url"`
+	Type         int     `json:"type"`
+	SubType      flexInt `json:"sub_type"`
+	KeywordType  flexInt `json:"keyword_type"`
+	KeywordValue string  `json:"keyword_value"`
+	HTTPUsername string  `json:"http_username"`
+	HTTPPassword string  `json:"http_password"`
+	Port         flexInt `json:"port"`
+	Interval     int     `json:"interval"`
+	Status       int     `json:"status"`
+}
+
+// Types and keyword types of UptimeRobot monitors.
+const (
+	uptimeRobotHTTP    = 1
+	uptimeRobotKeyword = 2
+	uptimeRobotPing    = 3
+	uptimeRobotPort    = 4
+
+	uptimeRobotAlertIfExists = 1
+)
+
+// Well known ports of UptimeRobot port monitors, by sub type.
+var uptimeRobotPorts = map[flexInt]int{1: 80, 2: 443, 3: 21, 4: 25, 5: 110, 6: 143}
+
+// ImportUptimeRobot translates the JSON returned by the UptimeRobot
+// getMonitors API into Pingdom checks.  Keyword monitors become HTTP checks
+// with ShouldContain or ShouldNotContain set.
+func ImportUptimeRobot(r io.Reader) (*ImportResult, error) {
+	var export struct {
+		Monitors []uptimeRobotMonitor `json:"monitors"`
+	}
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	for _, m := range export.Monitors {
+		resolution := importResolution(m.Interval)
+		paused := m.Status == 0
+
+		switch m.Type {
+		case uptimeRobotHTTP, uptimeRobotKeyword:
+			check, err := importHTTPCheck(m.FriendlyName, m.URL, resolution)
+			if err != nil {
+				result.skip(m.FriendlyName, "invalid url: %v", err)
+				continue
+			}
+			check.Paused = paused
+			if m.HTTPUsername != "" {
+				check.Username, check.Password = m.HTTPUsername, m.HTTPPassword
+			}
+			if m.Type == uptimeRobotKeyword {
+				if m.KeywordType == uptimeRobotAlertIfExists {
+					check.ShouldNotContain = m.KeywordValue
+				} else {
+					check.ShouldContain = m.KeywordValue
+				}
+			}
+			result.Checks = append(result.Checks, check)
+		case uptimeRobotPing:
+			result.Checks = append(result.Checks, &PingCheck{
+				Name:       m.FriendlyName,
+				Hostname:   m.URL,
+				Resolution: resolution,
+				Paused:     paused,
+			})
+		case uptimeRobotPort:
+			port := int(m.Port)
+			if p, ok := uptimeRobotPorts[m.SubType]; ok {
+				port = p
+			}
+			result.Checks = append(result.Checks, &TCPCheck{
+				Name:       m.FriendlyName,
+				Hostname:   m.URL,
+				Resolution: resolution,
+				Paused:     paused,
+				Port:       port,
+			})
+		default:
+			result.skip(m.FriendlyName, "unsupported monitor type %d", m.Type)
+		}
+	}
+	return result, nil
+}
+
+// statusCakeTest is an uptime test as returned by the StatusCake API.
+type statusCakeTest struct {
+	Name       string   `json:"name"`
+	TestType   string   `json:"test_type"`
+	WebsiteURL string   `json:"website_url"`
+	CheckRate  int      `json:"check_rate"`
+	Paused     bool     `json:"paused"`
+	Tags       []string `json:"tags"`
+	FindString string   `json:"find_string"`
+	DoNotFind  bool     `json:"do_not_find"`
+	Port       flexInt  `json:"port"`
+}
+
+// ImportStatusCake translates the JSON returned by the StatusCake uptime
+// tests API into Pingdom checks.  Tests with a find string become HTTP
+// checks with ShouldContain or ShouldNotContain set.
+func ImportStatusCake(r io.Reader) (*ImportResult, error) {
+	var export struct {
+		Data []statusCakeTest `json:"data"`
+	}
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	for _, t := range export.Data {
+		resolution := importResolution(t.CheckRate)
+		tags := strings.Join(t.Tags, ",")
+
+		switch strings.ToUpper(t.TestType) {
+		case "HTTP", "HEAD":
+			check, err := importHTTPCheck(t.Name, t.WebsiteURL, resolution)
+			if err != nil {
+				result.skip(t.Name, "invalid url: %v", err)
+				continue
+			}
+			check.Paused = t.Paused
+			check.Tags = tags
+			if t.DoNotFind {
+				check.ShouldNotContain = t.FindString
+			} else {
+				check.ShouldContain = t.FindString
+			}
+			result.Checks = append(result.Checks, check)
+		case "PING":
+			result.Checks = append(result.Checks, &PingCheck{
+				Name:       t.Name,
+				Hostname:   importHost(t.WebsiteURL),
+				Resolution: resolution,
+				Paused:     t.Paused,
+				Tags:       tags,
+			})
+		case "TCP":
+			result.Checks = append(result.Checks, &TCPCheck{
+				Name:       t.Name,
+				Hostname:   importHost(t.WebsiteURL),
+				Resolution: resolution,
+				Paused:     t.Paused,
+				Tags:       tags,
+				Port:       int(t.Port),
+			})
+		default:
+			result.skip(t.Name, "unsupported test type %q", t.TestType)
+		}
+	}
+	return result, nil
+}
+
+// importHTTPCheck builds an HTTP check for the given URL.
+func importHTTPCheck(name, rawURL string, resolution int) (*HttpCheck, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("missing host in %q", rawURL)
+	}
+
+	check := &HttpCheck{
+		Name:       name,
+		Hostname:   u.Hostname(),
+		Resolution: resolution,
+		Encryption: u.Scheme == "https",
+		Url:        u.RequestURI(),
+	}
+	if p := u.Port(); p != "" {
+		check.Port, _ = strconv.Atoi(p)
+	}
+	if u.User != nil {
+		check.Username = u.User.Username()
+		check.Password, _ = u.User.Password()
+	}
+	return check, nil
+}
+
+// importHost returns the host of a URL, or the value itself when it is a
+// bare host name.
+func importHost(v string) string {
+	if u, err := url.Parse(v); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return v
+}
+
+// importResolution returns the smallest Pingdom resolution, in minutes, at
+// least as long as the given interval in seconds.
+func importResolution(seconds int) int {
+	if seconds <= 0 {
+		return 5
+	}
+	for _, r := range []int{1, 5, 15, 30} {
+		if seconds <= r*60 {
+			return r
+		}
+	}
+	return 60
+}
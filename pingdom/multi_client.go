@@ -0,0 +1,162 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MultiClient holds a client per Pingdom account, keyed by a name of your
+// choice, to route calls by account and to run operations across all the
+// accounts.  It is safe for concurrent use.
+type MultiClient struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// AccountErrors maps the names of the accounts an operation failed for to
+// their error.
+type AccountErrors map[string]error
+
+func (e AccountErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%s: %v", name, e[name])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AccountCheck is a check along with the name of its account.
+type AccountCheck struct {
+	Account string `json:"account"`
+	CheckResponse
+}
+
+// NewMultiClient returns a MultiClient with a client per config, keyed by
+// account name.  Each config carries the credentials of its account.
+func NewMultiClient(configs map[string]ClientConfig) (*MultiClient, error) {
+	mc := &MultiClient{clients: map[string]*Client{}}
+	for name, config := range configs {
+		c, err := NewClientWithConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %v", name, err)
+		}
+		mc.clients[name] = c
+	}
+	return mc, nil
+}
+
+// Add adds the client of an account, replacing any client of that name.
+func (mc *MultiClient) Add(account string, c *Client) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.clients == nil {
+		mc.clients = map[string]*Client{}
+	}
+	mc.clients[account] = c
+}
+
+// Remove removes the client of an account.
+func (mc *MultiClient) Remove(account string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	delete(mc.clients, account)
+}
+
+// Account returns the client of an account.
+func (mc *MultiClient) Account(account string) (*Client, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	c, ok := mc.clients[account]
+	if !ok {
+		return nil, fmt.Errorf("unknown Pingdom account %q", account)
+	}
+	return c, nil
+}
+
+// Accounts returns the names of the accounts, sorted.
+func (mc *MultiClient) Accounts() []string {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	names := make([]string, 0, len(mc.clients))
+	for name := range mc.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForEach calls fn concurrently with the client of every account.  It
+// returns once all the calls are done, with an AccountErrors holding the
+// errors returned, if any.
+func (mc *MultiClient) ForEach(fn func(account string, c *Client) error) error {
+	mc.mu.RLock()
+	clients := make(map[string]*Client, len(mc.clients))
+	for name, c := range mc.clients {
+		clients[name] = c
+	}
+	mc.mu.RUnlock()
+
+	var mu sync.Mutex
+	errs := AccountErrors{}
+	var wg sync.WaitGroup
+	for name, c := range clients {
+		wg.Add(1)
+		go func(name string, c *Client) {
+			defer wg.Done()
+			if err := fn(name, c); err != nil {
+				mu.Lock()
+				errs[name] = err
+				mu.Unlock()
+			}
+		}(name, c)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ListChecks lists the checks of every account, see CheckService.ListAll,
+// ordered by account then id.  When some accounts fail, the checks of the
+// others are returned along with an AccountErrors.
+func (mc *MultiClient) ListChecks(params ...map[string]string) ([]AccountCheck, error) {
+	return mc.ListChecksWithContext(context.Background(), params...)
+}
+
+// ListChecksWithContext is like ListChecks, the requests are bound to ctx so
+// they can be canceled or given a deadline.
+func (mc *MultiClient) ListChecksWithContext(ctx context.Context, params ...map[string]string) ([]AccountCheck, error) {
+	var mu sync.Mutex
+	var checks []AccountCheck
+	err := mc.ForEach(func(account string, c *Client) error {
+		list, err := c.Checks.ListAllWithContext(ctx, params...)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, check := range list {
+			checks = append(checks, AccountCheck{Account: account, CheckResponse: check})
+		}
+		return nil
+	})
+
+	sort.Slice(checks, func(i, j int) bool {
+		if checks[i].Account != checks[j].Account {
+			return checks[i].Account < checks[j].Account
+		}
+		return checks[i].ID < checks[j].ID
+	})
+	return checks, err
+}
@@ -0,0 +1,98 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// accountServer serves the checks of one account, requiring its token.
+func accountServer(t *testing.T, token, checks string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"statuscode":403,"statusdesc":"Forbidden","errormessage":"Invalid token"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"checks":[%s]}`, checks)
+	}))
+}
+
+func TestMultiClient(t *testing.T) {
+	prod := accountServer(t, "prod_token", `{"id":2,"name":"api"},{"id":1,"name":"web"}`)
+	defer prod.Close()
+	staging := accountServer(t, "staging_token", `{"id":1,"name":"web"}`)
+	defer staging.Close()
+
+	mc, err := NewMultiClient(map[string]ClientConfig{
+		"prod":    {APIToken: "prod_token", BaseURL: prod.URL},
+		"staging": {APIToken: "staging_token", BaseURL: staging.URL},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prod", "staging"}, mc.Accounts())
+
+	c, err := mc.Account("staging")
+	assert.NoError(t, err)
+	assert.Equal(t, "staging_token", c.APIToken)
+
+	_, err = mc.Account("dev")
+	assert.EqualError(t, err, `unknown Pingdom account "dev"`)
+
+	checks, err := mc.ListChecks()
+	assert.NoError(t, err)
+	assert.Equal(t, []AccountCheck{
+		{Account: "prod", CheckResponse: CheckResponse{ID: 1, Name: "web"}},
+		{Account: "prod", CheckResponse: CheckResponse{ID: 2, Name: "api"}},
+		{Account: "staging", CheckResponse: CheckResponse{ID: 1, Name: "web"}},
+	}, checks)
+}
+
+func TestMultiClientListChecksPartialFailure(t *testing.T) {
+	prod := accountServer(t, "prod_token", `{"id":1,"name":"web"}`)
+	defer prod.Close()
+
+	mc, err := NewMultiClient(map[string]ClientConfig{
+		"prod": {APIToken: "prod_token", BaseURL: prod.URL},
+		"old":  {APIToken: "revoked_token", BaseURL: prod.URL, DisableRetry: true},
+	})
+	assert.NoError(t, err)
+
+	checks, err := mc.ListChecks()
+	assert.Equal(t, []AccountCheck{
+		{Account: "prod", CheckResponse: CheckResponse{ID: 1, Name: "web"}},
+	}, checks)
+	if assert.IsType(t, AccountErrors{}, err) {
+		errs := err.(AccountErrors)
+		assert.Len(t, errs, 1)
+		assert.Contains(t, errs, "old")
+		assert.Contains(t, err.Error(), "old: ")
+	}
+
+	mc.Remove("old")
+	assert.Equal(t, []string{"prod"}, mc.Accounts())
+	_, err = mc.ListChecks()
+	assert.NoError(t, err)
+}
+
+func TestNewMultiClientError(t *testing.T) {
+	_, err := NewMultiClient(map[string]ClientConfig{
+		"prod": {BaseURL: "://bad"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "account prod: ")
+}
+
+func TestMultiClientAdd(t *testing.T) {
+	var mc MultiClient
+	c, _ := NewClientWithConfig(ClientConfig{APIToken: "token"})
+	mc.Add("prod", c)
+
+	got, err := mc.Account("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, c, got)
+	assert.NoError(t, mc.ForEach(func(account string, c *Client) error { return nil }))
+}
@@ -0,0 +1,195 @@
+package pingdom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// MutationRecord describes a create, update or delete performed through the
+// client.
+type MutationRecord struct {
+	Time   time.Time `json:"time"`
+	Actor  string    `json:"actor,omitempty"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	// Request holds the parameters of the change, either the JSON body or
+	// the query parameters encoded as a JSON object.  A repeated parameter
+	// is encoded as a list of its values.
+	Request json.RawMessage `json:"request,omitempty"`
+	// Before holds the resource as it was before an update or delete, when
+	// MutationCaptureBefore is enabled.
+	Before json.RawMessage `json:"before,omitempty"`
+	// Response holds the body returned by Pingdom when it is JSON.
+	Response   json.RawMessage `json:"response,omitempty"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// MutationSink receives a record of every change made through the client.
+// The credentials and request headers of checks and the addresses and
+// numbers of contacts are masked in the records.
+// Errors returned by the sink are ignored, auditing never fails a change
+// which was already applied.
+type MutationSink interface {
+	Record(rec MutationRecord) error
+}
+
+// MutationSinkFunc adapts a function to the MutationSink interface.
+type MutationSinkFunc func(rec MutationRecord) error
+
+// Record calls f(rec).
+func (f MutationSinkFunc) Record(rec MutationRecord) error {
+	return f(rec)
+}
+
+// FileMutationSink appends records to a file, one JSON object per line.
+type FileMutationSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFileMutationSink opens, creating it if needed, the file at path for
+// appending records.
+func NewFileMutationSink(path string) (*FileMutationSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileMutationSink{f: f}, nil
+}
+
+// Record appends rec to the file.
+func (s *FileMutationSink) Record(rec MutationRecord) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.f.Write(append(b, '\n'))
+	return err
+}
+
+// Close closes the underlying file.
+func (s *FileMutationSink) Close() error {
+	return s.f.Close()
+}
+
+// WebhookMutationSink posts each record as JSON to a URL.
+type WebhookMutationSink struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// Record posts rec to the webhook URL.
+func (s *WebhookMutationSink) Record(rec MutationRecord) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	hc := s.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Post(s.URL, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("mutation webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// isMutation reports whether requests with the given method change data.
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// newMutationRecord captures the parameters of a mutating request before it
+// is sent.
+func (pc *Client) newMutationRecord(req *http.Request) MutationRecord {
+	rec := MutationRecord{
+		Time:   time.Now(),
+		Actor:  pc.mutationActor,
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := ioutil.ReadAll(body)
+			body.Close()
+			rec.Request = redactJSON(b)
+		}
+	}
+	if rec.Request == nil && req.URL.RawQuery != "" {
+		params := map[string]interface{}{}
+		for k, v := range redactValues(req.URL.Query()) {
+			if len(v) == 1 {
+				params[k] = v[0]
+			} else {
+				params[k] = v
+			}
+		}
+		rec.Request, _ = json.Marshal(params)
+	}
+
+	if pc.mutationCaptureBefore && req.Method != http.MethodPost {
+		rec.Before = pc.fetchBefore(req)
+	}
+	return rec
+}
+
+// fetchBefore reads the current state of the resource a request is about to
+// change, returning nil when it can't be read.
+func (pc *Client) fetchBefore(req *http.Request) json.RawMessage {
+	get, err := pc.NewRequest(http.MethodGet, "", nil)
+	if err != nil {
+		return nil
+	}
+	u := *req.URL
+	u.RawQuery = ""
+	get.URL = &u
+	get = get.WithContext(req.Context())
+
+	resp, err := pc.send(get)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	b, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil
+	}
+	return redactJSON(b)
+}
+
+// recordMutation completes rec with the outcome of the request and hands it
+// to the sink.  The response body is buffered so callers can still read it.
+func (pc *Client) recordMutation(rec MutationRecord, resp *http.Response, err error) {
+	if err != nil {
+		rec.Error = err.Error()
+	} else {
+		rec.StatusCode = resp.StatusCode
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+		rec.Response = redactJSON(b)
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			rec.Error = resp.Status
+		}
+	}
+	pc.mutationSink.Record(rec)
+}
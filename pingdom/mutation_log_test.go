@@ -0,0 +1,166 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutationSink(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var records []MutationRecord
+	client.mutationSink = MutationSinkFunc(func(rec MutationRecord) error {
+		records = append(records, rec)
+		return nil
+	})
+	client.mutationActor = "ci"
+	client.mutationCaptureBefore = true
+
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"check": {"id": 12345, "name": "old"}}`)
+		case "PUT":
+			fmt.Fprint(w, `{"message": "Modification of check was successful!"}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error": {"statuscode": 403, "statusdesc": "Forbidden", "errormessage": "Nope"}}`)
+		}
+	})
+
+	_, err := client.Checks.Read(12345)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	updateCheck := HttpCheck{Name: "new", Hostname: "example.com", Resolution: 5}
+	msg, err := client.Checks.Update(12345, &updateCheck)
+	assert.NoError(t, err)
+	assert.Equal(t, "Modification of check was successful!", msg.Message)
+
+	_, err = client.Checks.Delete(12345)
+	assert.Error(t, err)
+
+	assert.Len(t, records, 2)
+	rec := records[0]
+	assert.Equal(t, "ci", rec.Actor)
+	assert.Equal(t, "PUT", rec.Method)
+	assert.Equal(t, "/checks/12345", rec.Path)
+	assert.Equal(t, 200, rec.StatusCode)
+	assert.Empty(t, rec.Error)
+	var params map[string]string
+	assert.NoError(t, json.Unmarshal(rec.Request, &params))
+	assert.Equal(t, "new", params["name"])
+	assert.JSONEq(t, `{"check": {"id": 12345, "name": "old"}}`, string(rec.Before))
+	assert.JSONEq(t, `{"message": "Modification of check was successful!"}`, string(rec.Response))
+
+	assert.Equal(t, "DELETE", records[1].Method)
+	assert.Equal(t, 403, records[1].StatusCode)
+	assert.Equal(t, "403 Forbidden", records[1].Error)
+}
+
+func TestMutationSinkJSONBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var records []MutationRecord
+	client.mutationSink = MutationSinkFunc(func(rec MutationRecord) error {
+		records = append(records, rec)
+		return nil
+	})
+
+	mux.HandleFunc("/alerting/teams/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"team": {"id": 1, "name": "Ops"}}`)
+	})
+
+	_, err := client.Teams.Update(1, &Team{Name: "Ops", MemberIDs: []int{2}})
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.JSONEq(t, `{"name": "Ops", "member_ids": [2]}`, string(records[0].Request))
+	assert.Nil(t, records[0].Before)
+}
+
+func TestMutationSinkRedactsSecrets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var records []MutationRecord
+	client.mutationSink = MutationSinkFunc(func(rec MutationRecord) error {
+		records = append(records, rec)
+		return nil
+	})
+	client.mutationCaptureBefore = true
+
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 1, "type": {"http": {"password": "hunter2"}}}}`)
+	})
+
+	check := HttpCheck{
+		Name:           "web",
+		Hostname:       "example.com",
+		Resolution:     5,
+		Username:       "admin",
+		Password:       "hunter2",
+		RequestHeaders: map[string]string{"X-Api-Key": "key123"},
+	}
+	_, err := client.Checks.Update(1, &check)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	for _, raw := range []json.RawMessage{records[0].Request, records[0].Before, records[0].Response} {
+		assert.NotContains(t, string(raw), "hunter2")
+		assert.NotContains(t, string(raw), "key123")
+	}
+	var params map[string]string
+	assert.NoError(t, json.Unmarshal(records[0].Request, &params))
+	assert.Equal(t, "X-Api-Key:REDACTED", params["requestheader0"])
+
+	req, _ := http.NewRequest("DELETE", server.URL+"/checks?delcheckids=1&delcheckids=2", nil)
+	var repeated map[string][]string
+	assert.NoError(t, json.Unmarshal(client.newMutationRecord(req).Request, &repeated))
+	assert.Equal(t, []string{"1", "2"}, repeated["delcheckids"])
+}
+
+func TestFileMutationSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pingdom-mutations")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	sink, err := NewFileMutationSink(path)
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Record(MutationRecord{Method: "POST", Path: "/checks"}))
+	assert.NoError(t, sink.Record(MutationRecord{Method: "DELETE", Path: "/checks/1"}))
+	assert.NoError(t, sink.Close())
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"time\":\"0001-01-01T00:00:00Z\",\"method\":\"POST\",\"path\":\"/checks\"}\n"+
+		"{\"time\":\"0001-01-01T00:00:00Z\",\"method\":\"DELETE\",\"path\":\"/checks/1\"}\n", string(b))
+}
+
+func TestWebhookMutationSink(t *testing.T) {
+	var got MutationRecord
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	sink := &WebhookMutationSink{URL: srv.URL}
+	assert.NoError(t, sink.Record(MutationRecord{Method: "POST", Path: "/checks"}))
+	assert.Equal(t, "/checks", got.Path)
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	assert.Error(t, sink.Record(MutationRecord{}))
+}
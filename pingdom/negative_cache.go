@@ -0,0 +1,67 @@
+package pingdom
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// notFoundCache remembers resources which recently returned a 404 so that
+// repeated lookups of deleted resources don't reach the API.
+type notFoundCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]notFoundEntry
+}
+
+type notFoundEntry struct {
+	err     PingdomError
+	expires time.Time
+}
+
+func newNotFoundCache(ttl time.Duration) *notFoundCache {
+	return &notFoundCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]notFoundEntry{},
+	}
+}
+
+// get returns the cached error for the given resource path, if any.
+func (c *notFoundCache) get(path string) (*PingdomError, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[path]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, path)
+		return nil, false
+	}
+	err := e.err
+	return &err, true
+}
+
+func (c *notFoundCache) add(path string, err *PingdomError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[path] = notFoundEntry{err: *err, expires: c.now().Add(c.ttl)}
+}
+
+// invalidate drops the entry for the given path along with every entry
+// beneath it, e.g. invalidating "/checks" forgets "/checks/123".
+func (c *notFoundCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	for k := range c.entries {
+		if k == path || strings.HasPrefix(k, prefix) {
+			delete(c.entries, k)
+		}
+	}
+}
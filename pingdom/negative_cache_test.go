@@ -0,0 +1,69 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFoundCache(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newNotFoundCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	pe := &PingdomError{StatusCode: 404, StatusDesc: "Not Found", Message: "Check not found"}
+	c.add("/api/3.1/checks/1", pe)
+	c.add("/api/3.1/maintenance/1", pe)
+
+	err, ok := c.get("/api/3.1/checks/1")
+	assert.True(t, ok)
+	assert.Equal(t, pe, err)
+
+	c.invalidate("/api/3.1/checks")
+	_, ok = c.get("/api/3.1/checks/1")
+	assert.False(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = c.get("/api/3.1/maintenance/1")
+	assert.False(t, ok)
+}
+
+func TestDoNotFoundCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hits := 0
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"statuscode":404,"statusdesc":"Not Found","errormessage":"Check not found"}}`)
+	})
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"check":{"id":12345,"name":"My new HTTP check"}}`)
+	})
+
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken:    "my_api_key",
+		NotFoundTTL: time.Minute,
+	})
+	assert.NoError(t, err)
+	c.BaseURL, _ = url.Parse(server.URL)
+
+	for i := 0; i < 3; i++ {
+		_, err = c.Checks.Read(12345)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 1, hits)
+
+	_, err = c.Checks.Create(&HttpCheck{Name: "My new HTTP check", Hostname: "example.com", Resolution: 5})
+	assert.NoError(t, err)
+
+	_, err = c.Checks.Read(12345)
+	assert.Error(t, err)
+	assert.Equal(t, 2, hits)
+}
@@ -0,0 +1,214 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OccurrenceService provides an interface to the occurrences of Pingdom
+// maintenance windows, i.e. each time a recurring window takes place.
+type OccurrenceService struct {
+	client *Client
+}
+
+// OccurrenceListRequest is the API request to Pingdom for the occurrences of
+// maintenance windows.
+type OccurrenceListRequest struct {
+	// MaintenanceId only returns the occurrences of the given window.
+	MaintenanceId int
+	// From and To bound the occurrences in Unix time.
+	From int64
+	To   int64
+}
+
+// Valid determines whether an OccurrenceListRequest contains valid fields for the Pingdom API.
+func (olr OccurrenceListRequest) Valid() error {
+	if olr.From != 0 && olr.To != 0 && olr.From > olr.To {
+		return ErrBadTimeRange
+	}
+	return nil
+}
+
+// GetParams returns the query params for a Pingdom OccurrenceListRequest.
+func (olr OccurrenceListRequest) GetParams() (params url.Values) {
+	params = url.Values{}
+
+	if olr.MaintenanceId != 0 {
+		params.Set("maintenanceid", strconv.Itoa(olr.MaintenanceId))
+	}
+
+	if olr.From != 0 {
+		params.Set("from", strconv.FormatInt(olr.From, 10))
+	}
+
+	if olr.To != 0 {
+		params.Set("to", strconv.FormatInt(olr.To, 10))
+	}
+
+	return
+}
+
+// OccurrenceUpdate moves or resizes a single occurrence.  To can be left out
+// when Duration is given, the occurrence then ends Duration after From.
+type OccurrenceUpdate struct {
+	From     int64
+	To       int64
+	Duration time.Duration
+}
+
+// Valid determines whether an OccurrenceUpdate contains valid fields for the Pingdom API.
+func (ou OccurrenceUpdate) Valid() error {
+	if ou.From == 0 {
+		return fmt.Errorf("Invalid value for `From`.  Must contain a Unix timestamp")
+	}
+
+	if ou.To == 0 && ou.Duration <= 0 {
+		return fmt.Errorf("Invalid value for `To`.  Must contain a Unix timestamp unless `Duration` is set")
+	}
+
+	if ou.To != 0 && ou.From > ou.To {
+		return ErrBadTimeRange
+	}
+	return nil
+}
+
+// PutParams returns a map of parameters for an OccurrenceUpdate that can be
+// sent along with an HTTP PUT request.
+func (ou OccurrenceUpdate) PutParams() map[string]string {
+	to := ou.To
+	if to == 0 {
+		to = ou.From + int64(ou.Duration/time.Second)
+	}
+	return map[string]string{
+		"from": strconv.FormatInt(ou.From, 10),
+		"to":   strconv.FormatInt(to, 10),
+	}
+}
+
+// List returns the occurrences of maintenance windows.
+func (ocs *OccurrenceService) List(request OccurrenceListRequest) ([]OccurrenceResponse, error) {
+	return ocs.ListWithContext(context.Background(), request)
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ocs *OccurrenceService) ListWithContext(ctx context.Context, request OccurrenceListRequest) ([]OccurrenceResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := ocs.client.NewRequestWithValues("GET", "/maintenance.occurrences", request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &listOccurrencesJSONResponse{}
+	_, err = ocs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Occurrences, nil
+}
+
+// Read returns the occurrence for a given ID.
+func (ocs *OccurrenceService) Read(id int) (*OccurrenceResponse, error) {
+	return ocs.ReadWithContext(context.Background(), id)
+}
+
+// ReadWithContext is like Read, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ocs *OccurrenceService) ReadWithContext(ctx context.Context, id int) (*OccurrenceResponse, error) {
+	req, err := ocs.client.NewRequest("GET", "/maintenance.occurrences/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &occurrenceDetailsJSONResponse{}
+	_, err = ocs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return &m.Occurrence, nil
+}
+
+// Update moves or resizes the occurrence for the given ID, leaving the other
+// occurrences of its window untouched.
+func (ocs *OccurrenceService) Update(id int, update OccurrenceUpdate) (*PingdomResponse, error) {
+	return ocs.UpdateWithContext(context.Background(), id, update)
+}
+
+// UpdateWithContext is like Update, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ocs *OccurrenceService) UpdateWithContext(ctx context.Context, id int, update OccurrenceUpdate) (*PingdomResponse, error) {
+	if err := update.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := ocs.client.NewRequest("PUT", "/maintenance.occurrences/"+strconv.Itoa(id), update.PutParams())
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &PingdomResponse{}
+	_, err = ocs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Delete will delete the occurrence for the given ID.
+func (ocs *OccurrenceService) Delete(id int) (*PingdomResponse, error) {
+	return ocs.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ocs *OccurrenceService) DeleteWithContext(ctx context.Context, id int) (*PingdomResponse, error) {
+	req, err := ocs.client.NewRequest("DELETE", "/maintenance.occurrences/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &PingdomResponse{}
+	_, err = ocs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MultiDelete will delete the occurrences for the given IDs.
+func (ocs *OccurrenceService) MultiDelete(ids []int) (*PingdomResponse, error) {
+	return ocs.MultiDeleteWithContext(context.Background(), ids)
+}
+
+// MultiDeleteWithContext is like MultiDelete, the request is bound to ctx so
+// it can be canceled or given a deadline.
+func (ocs *OccurrenceService) MultiDeleteWithContext(ctx context.Context, ids []int) (*PingdomResponse, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("Invalid value for `ids`.  Must contain at least one occurrence id")
+	}
+
+	req, err := ocs.client.NewRequest("DELETE", "/maintenance.occurrences", map[string]string{
+		"occurrenceids": intListToCDString(ids),
+	})
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &PingdomResponse{}
+	_, err = ocs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
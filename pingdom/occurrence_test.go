@@ -0,0 +1,120 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOccurrenceListRequest(t *testing.T) {
+	assert.NoError(t, OccurrenceListRequest{}.Valid())
+	assert.Equal(t, ErrBadTimeRange, OccurrenceListRequest{From: 20, To: 10}.Valid())
+
+	want := url.Values{
+		"maintenanceid": {"42"},
+		"from":          {"10"},
+		"to":            {"20"},
+	}
+	assert.Equal(t, want, OccurrenceListRequest{MaintenanceId: 42, From: 10, To: 20}.GetParams())
+}
+
+func TestOccurrenceUpdate(t *testing.T) {
+	assert.NoError(t, OccurrenceUpdate{From: 10, To: 20}.Valid())
+	assert.NoError(t, OccurrenceUpdate{From: 10, Duration: time.Hour}.Valid())
+	assert.Error(t, OccurrenceUpdate{To: 20}.Valid())
+	assert.Error(t, OccurrenceUpdate{From: 10}.Valid())
+	assert.Equal(t, ErrBadTimeRange, OccurrenceUpdate{From: 20, To: 10}.Valid())
+
+	assert.Equal(t, map[string]string{"from": "10", "to": "20"}, OccurrenceUpdate{From: 10, To: 20, Duration: time.Hour}.PutParams())
+	assert.Equal(t, map[string]string{"from": "10", "to": "3610"}, OccurrenceUpdate{From: 10, Duration: time.Hour}.PutParams())
+}
+
+func TestOccurrenceServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance.occurrences", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "42", r.URL.Query().Get("maintenanceid"))
+		fmt.Fprint(w, `{
+			"occurrences": [
+				{"id": 1, "maintenanceid": 42, "from": 1600000000, "to": 1600003600},
+				{"id": 2, "maintenanceid": 42, "from": 1600086400, "to": 1600090000}
+			]
+		}`)
+	})
+
+	want := []OccurrenceResponse{
+		{ID: 1, MaintenanceID: 42, From: 1600000000, To: 1600003600},
+		{ID: 2, MaintenanceID: 42, From: 1600086400, To: 1600090000},
+	}
+
+	occurrences, err := client.Occurrences.List(OccurrenceListRequest{MaintenanceId: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, want, occurrences)
+}
+
+func TestOccurrenceServiceRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance.occurrences/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"occurrence": {"id": 1, "maintenanceid": 42, "from": 1600000000, "to": 1600003600}}`)
+	})
+
+	occurrence, err := client.Occurrences.Read(1)
+	assert.NoError(t, err)
+	assert.Equal(t, &OccurrenceResponse{ID: 1, MaintenanceID: 42, From: 1600000000, To: 1600003600}, occurrence)
+}
+
+func TestOccurrenceServiceUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance.occurrences/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		assert.Equal(t, "1600001800", r.FormValue("to"))
+		fmt.Fprint(w, `{"message": "Occurrence successfully modified"}`)
+	})
+
+	msg, err := client.Occurrences.Update(1, OccurrenceUpdate{From: 1600000000, Duration: 30 * time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Occurrence successfully modified"}, msg)
+}
+
+func TestOccurrenceServiceDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance.occurrences/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"message": "Occurrence successfully deleted"}`)
+	})
+
+	msg, err := client.Occurrences.Delete(1)
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Occurrence successfully deleted"}, msg)
+}
+
+func TestOccurrenceServiceMultiDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance.occurrences", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		assert.Equal(t, "1,2", r.URL.Query().Get("occurrenceids"))
+		fmt.Fprint(w, `{"message": "Occurrences successfully deleted"}`)
+	})
+
+	msg, err := client.Occurrences.MultiDelete([]int{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Occurrences successfully deleted"}, msg)
+
+	_, err = client.Occurrences.MultiDelete(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package pingdom
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of root causes an outage can be attributed to.
+const (
+	RootCauseDNS        = "dns"
+	RootCauseTimeout    = "timeout"
+	RootCauseHTTPStatus = "http_status"
+	RootCauseConnection = "connection"
+	RootCauseTLS        = "tls"
+	RootCauseContent    = "content"
+	RootCauseUnknown    = "unknown"
+)
+
+// RootCause is the typed reason of a failed test.
+type RootCause struct {
+	Kind string
+	// HTTPStatus is set for RootCauseHTTPStatus.
+	HTTPStatus int
+	// Description is the status description reported by the probe.
+	Description string
+	ProbeID     int
+	Time        int64
+}
+
+// EnrichedOutage is a down period of a check along with the root cause data
+// Pingdom gathered while it lasted.
+type EnrichedOutage struct {
+	TimeFrom int64
+	TimeTo   int64
+	// AnalysisIDs are the root cause analyses started during the outage.
+	AnalysisIDs []int
+	// Causes are the causes of the failed tests during the outage.
+	Causes []RootCause
+	// PrimaryCause is the most frequent kind of cause.
+	PrimaryCause string
+}
+
+// Duration returns how long the outage lasted.
+func (o EnrichedOutage) Duration() time.Duration {
+	return time.Duration(o.TimeTo-o.TimeFrom) * time.Second
+}
+
+var httpStatusPattern = regexp.MustCompile(`\b([1-5][0-9]{2})\b`)
+
+// ClassifyRootCause derives a typed root cause from the status description
+// of a check result, e.g. "Timeout (> 30s)" or "HTTP Error 503".
+func ClassifyRootCause(statusDesc string) RootCause {
+	rc := RootCause{Kind: RootCauseUnknown, Description: statusDesc}
+	desc := strings.ToLower(statusDesc)
+
+	switch {
+	case strings.Contains(desc, "dns") || strings.Contains(desc, "name resolution") ||
+		strings.Contains(desc, "resolve"):
+		rc.Kind = RootCauseDNS
+	case strings.Contains(desc, "timeout") || strings.Contains(desc, "timed out"):
+		rc.Kind = RootCauseTimeout
+	case strings.Contains(desc, "ssl") || strings.Contains(desc, "tls") ||
+		strings.Contains(desc, "certificate"):
+		rc.Kind = RootCauseTLS
+	case strings.Contains(desc, "http") && httpStatusPattern.MatchString(desc):
+		rc.Kind = RootCauseHTTPStatus
+		rc.HTTPStatus, _ = strconv.Atoi(httpStatusPattern.FindStringSubmatch(desc)[1])
+	case strings.Contains(desc, "connection") || strings.Contains(desc, "unreachable") ||
+		strings.Contains(desc, "refused") || strings.Contains(desc, "reset"):
+		rc.Kind = RootCauseConnection
+	case strings.Contains(desc, "keyword") || strings.Contains(desc, "contain"):
+		rc.Kind = RootCauseContent
+	}
+	return rc
+}
+
+// EnrichedOutages returns the outages of a check in the given time window,
+// each with the root cause analyses and the causes of the failed tests
+// recorded while it lasted.  Use it to build postmortems.
+func (cs *CheckService) EnrichedOutages(id int, from, to time.Time) ([]EnrichedOutage, error) {
+	summary, err := cs.SummaryOutage(SummaryOutageRequest{
+		Id:   id,
+		From: from.Unix(),
+		To:   to.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var outages []EnrichedOutage
+	for _, state := range summary.Summary.States {
+		if state.Status == "down" {
+			outages = append(outages, EnrichedOutage{TimeFrom: state.TimeFrom, TimeTo: state.TimeTo})
+		}
+	}
+	if len(outages) == 0 {
+		return outages, nil
+	}
+
+	analyses, err := cs.client.Analysis.List(id, map[string]string{
+		"from": strconv.FormatInt(from.Unix(), 10),
+		"to":   strconv.FormatInt(to.Unix(), 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range outages {
+		o := &outages[i]
+		for _, a := range analyses {
+			// The analysis starts with the first failed test, which may
+			// precede the confirmed outage slightly.
+			if a.TimeConfirmTest >= o.TimeFrom && a.TimeFirstTest <= o.TimeTo {
+				o.AnalysisIDs = append(o.AnalysisIDs, a.ID)
+			}
+		}
+
+		results, err := cs.client.Results.List(ResultsRequest{
+			Id:     id,
+			From:   o.TimeFrom,
+			To:     o.TimeTo,
+			Status: []string{"down"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		counts := map[string]int{}
+		for _, r := range results.Results {
+			desc := r.StatusDesc
+			if r.StatusDescLong != "" && ClassifyRootCause(desc).Kind == RootCauseUnknown {
+				desc = r.StatusDescLong
+			}
+			rc := ClassifyRootCause(desc)
+			rc.ProbeID = r.ProbeID
+			rc.Time = int64(r.Time)
+			o.Causes = append(o.Causes, rc)
+			counts[rc.Kind]++
+		}
+		o.PrimaryCause = primaryCause(counts)
+	}
+
+	return outages, nil
+}
+
+func primaryCause(counts map[string]int) string {
+	primary, max := RootCauseUnknown, 0
+	for _, kind := range []string{RootCauseDNS, RootCauseTimeout, RootCauseHTTPStatus,
+		RootCauseConnection, RootCauseTLS, RootCauseContent, RootCauseUnknown} {
+		if counts[kind] > max {
+			primary, max = kind, counts[kind]
+		}
+	}
+	return primary
+}
@@ -0,0 +1,113 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyRootCause(t *testing.T) {
+	tests := []struct {
+		desc       string
+		kind       string
+		httpStatus int
+	}{
+		{"Non-recoverable failure in name resolution", RootCauseDNS, 0},
+		{"Cannot resolve default host name", RootCauseDNS, 0},
+		{"Timeout (> 30s)", RootCauseTimeout, 0},
+		{"HTTP Error 503", RootCauseHTTPStatus, 503},
+		{"HTTP/1.1 404 Not Found", RootCauseHTTPStatus, 404},
+		{"Connection refused", RootCauseConnection, 0},
+		{"Invalid SSL certificate", RootCauseTLS, 0},
+		{"Keyword not found", RootCauseContent, 0},
+		{"Something odd", RootCauseUnknown, 0},
+	}
+
+	for _, tc := range tests {
+		rc := ClassifyRootCause(tc.desc)
+		assert.Equal(t, tc.kind, rc.Kind, tc.desc)
+		assert.Equal(t, tc.httpStatus, rc.HTTPStatus, tc.desc)
+		assert.Equal(t, tc.desc, rc.Description)
+	}
+}
+
+func TestCheckServiceSummaryOutage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "1000", r.URL.Query().Get("from"))
+		assert.Equal(t, "desc", r.URL.Query().Get("order"))
+		fmt.Fprint(w, `{
+			"summary": {
+				"states": [
+					{"status": "up", "timefrom": 1000, "timeto": 2000},
+					{"status": "down", "timefrom": 2000, "timeto": 2300}
+				]
+			}
+		}`)
+	})
+
+	want := &SummaryOutageResponse{
+		Summary: SummaryOutageStates{
+			States: []SummaryOutageState{
+				{Status: "up", TimeFrom: 1000, TimeTo: 2000},
+				{Status: "down", TimeFrom: 2000, TimeTo: 2300},
+			},
+		},
+	}
+
+	resp, err := client.Checks.SummaryOutage(SummaryOutageRequest{Id: 12345, From: 1000, Order: "desc"})
+	assert.NoError(t, err)
+	assert.Equal(t, want, resp)
+
+	_, err = client.Checks.SummaryOutage(SummaryOutageRequest{Id: 12345, Order: "up"})
+	assert.Equal(t, ErrBadOrder, err)
+	_, err = client.Checks.SummaryOutage(SummaryOutageRequest{})
+	assert.Equal(t, ErrMissingId, err)
+}
+
+func TestCheckServiceEnrichedOutages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"summary": {"states": [
+			{"status": "up", "timefrom": 1000, "timeto": 2000},
+			{"status": "down", "timefrom": 2000, "timeto": 2300},
+			{"status": "up", "timefrom": 2300, "timeto": 5000}
+		]}}`)
+	})
+	mux.HandleFunc("/analysis/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"analysis": [
+			{"id": 4, "timefirsttest": 1990, "timeconfirmtest": 2000},
+			{"id": 5, "timefirsttest": 4000, "timeconfirmtest": 4010}
+		]}`)
+	})
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "down", r.URL.Query().Get("status"))
+		assert.Equal(t, "2000", r.URL.Query().Get("from"))
+		assert.Equal(t, "2300", r.URL.Query().Get("to"))
+		fmt.Fprint(w, `{"results": [
+			{"probeid": 1, "time": 2000, "status": "down", "statusdesc": "HTTP Error 503"},
+			{"probeid": 2, "time": 2060, "status": "down", "statusdesc": "HTTP Error 503"},
+			{"probeid": 3, "time": 2120, "status": "down", "statusdesc": "Timeout (> 30s)"}
+		]}`)
+	})
+
+	outages, err := client.Checks.EnrichedOutages(12345, time.Unix(1000, 0), time.Unix(5000, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(outages))
+
+	o := outages[0]
+	assert.Equal(t, 5*time.Minute, o.Duration())
+	assert.Equal(t, []int{4}, o.AnalysisIDs)
+	assert.Equal(t, RootCauseHTTPStatus, o.PrimaryCause)
+	assert.Equal(t, 3, len(o.Causes))
+	assert.Equal(t, RootCause{Kind: RootCauseHTTPStatus, HTTPStatus: 503, Description: "HTTP Error 503", ProbeID: 1, Time: 2000}, o.Causes[0])
+	assert.Equal(t, RootCauseTimeout, o.Causes[2].Kind)
+}
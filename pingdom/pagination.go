@@ -0,0 +1,224 @@
+package pingdom
+
+import (
+	"context"
+	"strconv"
+)
+
+// Page sizes used by the Pages and ListAll methods when the request does not
+// set a limit.
+const (
+	checksPageSize       = 1000
+	maintenancesPageSize = 100
+	resultsPageSize      = 1000
+	actionsPageSize      = 300
+)
+
+// paginate calls fetch with increasing offsets until it returns fewer items
+// than limit.
+func paginate(limit, offset int, fetch func(limit, offset int) (int, error)) error {
+	for {
+		n, err := fetch(limit, offset)
+		if err != nil {
+			return err
+		}
+		if n < limit || n == 0 {
+			return nil
+		}
+		offset += n
+	}
+}
+
+// pageParams merges the given params into a new map and returns it along with
+// the requested limit, or pageSize when none is set, and the starting offset.
+func pageParams(pageSize int, params []map[string]string) (map[string]string, int, int) {
+	param := map[string]string{}
+	for _, m := range params {
+		for k, v := range m {
+			param[k] = v
+		}
+	}
+	limit, _ := strconv.Atoi(param["limit"])
+	if limit <= 0 {
+		limit = pageSize
+	}
+	offset, _ := strconv.Atoi(param["offset"])
+	return param, limit, offset
+}
+
+// Pages walks all the checks matching params, calling fn with each page.  The
+// "limit" param sets the page size, 1000 by default.  Walking stops at the
+// first error, either from Pingdom or from fn, which is returned.
+func (cs *CheckService) Pages(fn func(*CheckList) error, params ...map[string]string) error {
+	return cs.PagesWithContext(context.Background(), fn, params...)
+}
+
+// PagesWithContext is like Pages, the requests are bound to ctx so they can be
+// canceled or given a deadline.
+func (cs *CheckService) PagesWithContext(ctx context.Context, fn func(*CheckList) error, params ...map[string]string) error {
+	param, limit, offset := pageParams(checksPageSize, params)
+	return paginate(limit, offset, func(limit, offset int) (int, error) {
+		param["limit"] = strconv.Itoa(limit)
+		param["offset"] = strconv.Itoa(offset)
+		l, err := cs.ListWithMetaWithContext(ctx, param)
+		if err != nil {
+			return 0, err
+		}
+		return len(l.Checks), fn(l)
+	})
+}
+
+// ListAll returns all the checks matching params, walking the pages like
+// Pages.
+func (cs *CheckService) ListAll(params ...map[string]string) ([]CheckResponse, error) {
+	return cs.ListAllWithContext(context.Background(), params...)
+}
+
+// ListAllWithContext is like ListAll, the requests are bound to ctx so they
+// can be canceled or given a deadline.
+func (cs *CheckService) ListAllWithContext(ctx context.Context, params ...map[string]string) ([]CheckResponse, error) {
+	var checks []CheckResponse
+	err := cs.PagesWithContext(ctx, func(l *CheckList) error {
+		checks = append(checks, l.Checks...)
+		return nil
+	}, params...)
+	if err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// Pages walks all the maintenance windows matching params, calling fn with
+// each page.  The "limit" param sets the page size, 100 by default.  Walking
+// stops at the first error, either from Pingdom or from fn, which is returned.
+func (cs *MaintenanceService) Pages(fn func(*MaintenanceList) error, params ...map[string]string) error {
+	return cs.PagesWithContext(context.Background(), fn, params...)
+}
+
+// PagesWithContext is like Pages, the requests are bound to ctx so they can be
+// canceled or given a deadline.
+func (cs *MaintenanceService) PagesWithContext(ctx context.Context, fn func(*MaintenanceList) error, params ...map[string]string) error {
+	param, limit, offset := pageParams(maintenancesPageSize, params)
+	return paginate(limit, offset, func(limit, offset int) (int, error) {
+		param["limit"] = strconv.Itoa(limit)
+		param["offset"] = strconv.Itoa(offset)
+		l, err := cs.ListWithMetaWithContext(ctx, param)
+		if err != nil {
+			return 0, err
+		}
+		return len(l.Maintenances), fn(l)
+	})
+}
+
+// ListAll returns all the maintenance windows matching params, walking the
+// pages like Pages.
+func (cs *MaintenanceService) ListAll(params ...map[string]string) ([]MaintenanceResponse, error) {
+	return cs.ListAllWithContext(context.Background(), params...)
+}
+
+// ListAllWithContext is like ListAll, the requests are bound to ctx so they
+// can be canceled or given a deadline.
+func (cs *MaintenanceService) ListAllWithContext(ctx context.Context, params ...map[string]string) ([]MaintenanceResponse, error) {
+	var maintenances []MaintenanceResponse
+	err := cs.PagesWithContext(ctx, func(l *MaintenanceList) error {
+		maintenances = append(maintenances, l.Maintenances...)
+		return nil
+	}, params...)
+	if err != nil {
+		return nil, err
+	}
+	return maintenances, nil
+}
+
+// Pages walks all the results matching the request, calling fn with each
+// page.  The Limit of the request sets the page size, 1000 by default.
+// Walking stops at the first error, either from Pingdom or from fn, which is
+// returned.
+func (rs *ResultsService) Pages(request ResultsRequest, fn func(*ResultsResponse) error) error {
+	return rs.PagesWithContext(context.Background(), request, fn)
+}
+
+// PagesWithContext is like Pages, the requests are bound to ctx so they can be
+// canceled or given a deadline.
+func (rs *ResultsService) PagesWithContext(ctx context.Context, request ResultsRequest, fn func(*ResultsResponse) error) error {
+	if request.Limit == 0 {
+		request.Limit = resultsPageSize
+	}
+	return paginate(request.Limit, request.Offset, func(limit, offset int) (int, error) {
+		request.Limit, request.Offset = limit, offset
+		r, err := rs.ListWithContext(ctx, request)
+		if err != nil {
+			return 0, err
+		}
+		return len(r.Results), fn(r)
+	})
+}
+
+// ListAll returns all the results matching the request, walking the pages
+// like Pages.
+func (rs *ResultsService) ListAll(request ResultsRequest) ([]Result, error) {
+	return rs.ListAllWithContext(context.Background(), request)
+}
+
+// ListAllWithContext is like ListAll, the requests are bound to ctx so they
+// can be canceled or given a deadline.
+func (rs *ResultsService) ListAllWithContext(ctx context.Context, request ResultsRequest) ([]Result, error) {
+	var results []Result
+	err := rs.PagesWithContext(ctx, request, func(r *ResultsResponse) error {
+		results = append(results, r.Results...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Pages walks all the alerts matching the request, calling fn with each page.
+// The Limit of the request sets the page size, 300 by default.  Walking stops
+// at the first error, either from Pingdom or from fn, which is returned.
+func (as *ActionsService) Pages(request ActionsRequest, fn func([]AlertEntry) error) error {
+	return as.PagesWithContext(context.Background(), request, fn)
+}
+
+// PagesWithContext is like Pages, the requests are bound to ctx so they can be
+// canceled or given a deadline.
+func (as *ActionsService) PagesWithContext(ctx context.Context, request ActionsRequest, fn func([]AlertEntry) error) error {
+	if request.Limit == 0 {
+		request.Limit = actionsPageSize
+	}
+	return paginate(request.Limit, request.Offset, func(limit, offset int) (int, error) {
+		request.Limit, request.Offset = limit, offset
+		// The size of the page before the severity filter tells whether
+		// there are more pages.
+		alerts, _, err := as.fetchPage(ctx, request)
+		if err != nil {
+			return 0, err
+		}
+		n := len(alerts)
+		if alerts, err = as.withSeverity(ctx, request, alerts); err != nil {
+			return 0, err
+		}
+		return n, fn(alerts)
+	})
+}
+
+// ListAll returns all the alerts matching the request, walking the pages like
+// Pages.
+func (as *ActionsService) ListAll(request ActionsRequest) ([]AlertEntry, error) {
+	return as.ListAllWithContext(context.Background(), request)
+}
+
+// ListAllWithContext is like ListAll, the requests are bound to ctx so they
+// can be canceled or given a deadline.
+func (as *ActionsService) ListAllWithContext(ctx context.Context, request ActionsRequest) ([]AlertEntry, error) {
+	var alerts []AlertEntry
+	err := as.PagesWithContext(ctx, request, func(page []AlertEntry) error {
+		alerts = append(alerts, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
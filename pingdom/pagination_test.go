@@ -0,0 +1,121 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// servePages answers with the items of ids between the requested offset and
+// limit, formatted with format and wrapped in envelope.
+func servePages(t *testing.T, ids []int, envelope, format string, calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		assert.NoError(t, err)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		var items []string
+		for i := offset; i < offset+limit && i < len(ids); i++ {
+			items = append(items, fmt.Sprintf(format, ids[i]))
+		}
+		fmt.Fprintf(w, envelope, strings.Join(items, ","))
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	var offsets []int
+	err := paginate(2, 1, func(limit, offset int) (int, error) {
+		offsets = append(offsets, offset)
+		if offset >= 5 {
+			return 1, nil
+		}
+		return limit, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 5}, offsets)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = paginate(2, 0, func(limit, offset int) (int, error) {
+		calls++
+		return limit, stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCheckServiceListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/checks", servePages(t, []int{1, 2, 3, 4, 5}, `{"checks": [%s]}`, `{"id": %d}`, &calls))
+
+	params := map[string]string{"limit": "2"}
+	checks, err := client.Checks.ListAll(params)
+	assert.NoError(t, err)
+	assert.Equal(t, []CheckResponse{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}, checks)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, map[string]string{"limit": "2"}, params)
+}
+
+func TestCheckServicePagesStops(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/checks", servePages(t, []int{1, 2, 3, 4, 5}, `{"checks": [%s]}`, `{"id": %d}`, &calls))
+
+	stop := errors.New("stop")
+	err := client.Checks.Pages(func(l *CheckList) error {
+		assert.Equal(t, 2, len(l.Checks))
+		return stop
+	}, map[string]string{"limit": "2"})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestMaintenanceServiceListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/maintenance", servePages(t, []int{1, 2, 3}, `{"maintenance": [%s]}`, `{"id": %d}`, &calls))
+
+	maintenances, err := client.Maintenances.ListAll(map[string]string{"limit": "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []MaintenanceResponse{{ID: 1}, {ID: 2}, {ID: 3}}, maintenances)
+	assert.Equal(t, 2, calls)
+}
+
+func TestResultsServiceListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/results/12345", servePages(t, []int{1, 2, 3}, `{"results": [%s]}`, `{"probeid": %d}`, &calls))
+
+	results, err := client.Results.ListAll(ResultsRequest{Id: 12345, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{{ProbeID: 1}, {ProbeID: 2}, {ProbeID: 3}}, results)
+	assert.Equal(t, 2, calls)
+}
+
+func TestActionsServiceListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/actions", servePages(t, []int{1, 2, 3}, `{"actions": {"alerts": [%s]}}`, `{"checkid": %d}`, &calls))
+
+	alerts, err := client.Actions.ListAll(ActionsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []AlertEntry{{CheckID: 1}, {CheckID: 2}, {CheckID: 3}}, alerts)
+	assert.Equal(t, 1, calls)
+}
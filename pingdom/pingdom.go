@@ -1,25 +1,99 @@
 package pingdom
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	defaultBaseURL = "https://api.pingdom.com/api/3.1"
+
+	// Version is the version of this library, it is sent as part of the
+	// User-Agent of every request.
+	Version = "1.2.0"
+)
+
+// Headers which carry the identifiers of a request on the Pingdom side, in
+// order of preference.
+var (
+	requestIDHeaders = []string{"X-Request-Id", "Request-Id", "X-Amzn-Requestid"}
+	traceIDHeaders   = []string{"X-Trace-Id", "X-B3-Traceid", "Traceparent"}
 )
 
+// ResponseDiagnostics holds the identifiers Pingdom attaches to a response.
+// Include them when opening a support ticket about a specific request.
+type ResponseDiagnostics struct {
+	RequestID string
+	TraceID   string
+}
+
+// Diagnostics returns the request and trace identifiers found in the headers
+// of the given response.
+func Diagnostics(r *http.Response) ResponseDiagnostics {
+	if r == nil {
+		return ResponseDiagnostics{}
+	}
+	return ResponseDiagnostics{
+		RequestID: firstHeader(r.Header, requestIDHeaders),
+		TraceID:   firstHeader(r.Header, traceIDHeaders),
+	}
+}
+
+func firstHeader(h http.Header, names []string) string {
+	for _, name := range names {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // Client represents a client to the Pingdom API.
 type Client struct {
 	APIToken     string
 	BaseURL      *url.URL
 	client       *http.Client
+	notFound     *notFoundCache
+	userAgent    string
+	throttle     *Throttle
+	rateLimit    rateLimitState
+	sleep        func(ctx context.Context, d time.Duration) error
+	Actions      *ActionsService
+	Analysis     *AnalysisService
 	Checks       *CheckService
+	Contacts     *ContactService
+	Credits      *CreditsService
 	Maintenances *MaintenanceService
+	Occurrences  *OccurrenceService
 	Probes       *ProbeService
+	Results      *ResultsService
+	Single       *SingleService
+	Summary      *SummaryService
+	Teams        *TeamService
+	Traceroute   *TracerouteService
+
+	mutationSink          MutationSink
+	mutationActor         string
+	mutationCaptureBefore bool
+	history               *requestHistory
+	historyBodies         bool
+	retry                 *RetryPolicy
+	logger                RequestLogger
+	responseCache         *responseCache
+	credentials           CredentialsProvider
+	strictDecoding        bool
+	compression           bool
+	managedTag            string
 }
 
 // ClientConfig represents a configuration for a pingdom client.
@@ -27,6 +101,110 @@ type ClientConfig struct {
 	APIToken   string
 	BaseURL    string
 	HTTPClient *http.Client
+
+	// Credentials supplies the API token of each request instead of
+	// APIToken, e.g. to rotate it at runtime.  When neither is set the
+	// token is read from $PINGDOM_API_TOKEN.
+	Credentials CredentialsProvider
+
+	// NotFoundTTL enables caching of 404 responses for the given duration.
+	// While cached, reading a missing resource returns the previous error
+	// without calling the API.  Creating a resource invalidates the cached
+	// entries beneath it.  Zero disables the cache.
+	NotFoundTTL time.Duration
+
+	// AppName and AppVersion identify the application embedding the
+	// client.  They are appended to the User-Agent, which Pingdom support
+	// asks for when debugging rate limit issues.
+	AppName    string
+	AppVersion string
+
+	// UserAgentSuffix is appended to the User-Agent as is, e.g. to tell
+	// the deployments of an application apart.
+	UserAgentSuffix string
+
+	// Transport, Proxy, TLSConfig and Timeout configure the HTTP client
+	// used when HTTPClient is not set, they can't be combined with it.
+	// Transport replaces the default transport.  Proxy and TLSConfig are set
+	// on a copy of http.DefaultTransport, e.g. to go through an egress
+	// proxy with http.ProxyURL or to trust a private CA, and can't be
+	// combined with Transport.  Timeout bounds each attempt of a request,
+	// reading the response included.
+	Transport http.RoundTripper
+	Proxy     func(*http.Request) (*url.URL, error)
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+
+	// MaxIdleConnsPerHost, IdleConnTimeout and DisableKeepAlives tune the
+	// reuse of connections, like Proxy they are set on a copy of
+	// http.DefaultTransport and can't be combined with Transport.  Raise
+	// MaxIdleConnsPerHost, 2 by default, when running requests concurrently.
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	DisableKeepAlives   bool
+
+	// DisableCompression asks Pingdom for uncompressed responses instead
+	// of gzip compressed ones.  Compressed responses are decoded
+	// transparently whatever the transport, large lists shrink several
+	// times over.
+	DisableCompression bool
+
+	// ManagedTag marks the checks owned by the client, e.g. "managed-by-ci".
+	// It is added to the tags of the checks the client creates or updates,
+	// and checks without it are neither updated nor deleted unless the
+	// context is made with Force, so automation can't clobber the checks
+	// made by hand.  It costs a read of the check before each update and
+	// delete.
+	ManagedTag string
+
+	// Throttle enables adaptive throttling based on the remaining rate
+	// limit, so long bulk jobs slow down instead of hitting the limit.
+	Throttle *Throttle
+
+	// MutationSink receives a record of every create, update and delete
+	// made through the client, giving an audit log of programmatic
+	// changes.  MutationActor identifies who made them.  When
+	// MutationCaptureBefore is set, the resource is read before updates and
+	// deletes so the record holds its previous state, at the cost of an
+	// extra request.
+	MutationSink          MutationSink
+	MutationActor         string
+	MutationCaptureBefore bool
+
+	// RequestHistory is the number of recent requests kept for
+	// RecentRequests and WriteDiagnosticsBundle.  Zero keeps none.
+	// RequestHistoryBodies also keeps the start of the JSON response bodies,
+	// with the secrets they may hold masked: the credentials, headers and
+	// post data of checks and the email addresses and phone numbers of
+	// contacts.
+	RequestHistory       int
+	RequestHistoryBodies bool
+
+	// Retry configures how requests throttled by Pingdom are retried,
+	// DefaultRetryPolicy is used when nil.  Set DisableRetry to return
+	// throttled responses immediately.
+	Retry        *RetryPolicy
+	DisableRetry bool
+
+	// Logger is called after every attempt of a request with its method,
+	// URL, status code, latency and the rate limit reported, e.g. to debug
+	// intermittent errors.  The API token is never passed to it, nor are
+	// the credentials and custom header values of checks.
+	Logger RequestLogger
+
+	// ResponseCache keeps the responses to GET requests, so repeated reads
+	// within ResponseCacheTTL don't call Pingdom.  Older responses are
+	// revalidated with If-None-Match or If-Modified-Since when Pingdom sent
+	// an ETag or Last-Modified header.  Any change made through the client
+	// drops the responses it cached.  Use a separate cache per API token.
+	ResponseCache    Cache
+	ResponseCacheTTL time.Duration
+
+	// StrictDecoding fails responses holding fields the response types
+	// don't represent with an *UnknownFieldError, to catch API changes
+	// while debugging.  Mismatches are also reported to the Logger.  Leave
+	// it off in production, Pingdom adds fields without notice.
+	StrictDecoding bool
 }
 
 // NewClientWithConfig returns a Pingdom client.
@@ -42,76 +220,350 @@ func NewClientWithConfig(config ClientConfig) (*Client, error) {
 		return nil, err
 	}
 
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ManagedTag != "" {
+		if _, err := JoinTags(config.ManagedTag); err != nil {
+			return nil, err
+		}
+	}
+
 	c := &Client{
-		APIToken: config.APIToken,
-		BaseURL:  baseURL,
+		APIToken:  config.APIToken,
+		BaseURL:   baseURL,
+		client:    httpClient,
+		userAgent: userAgent(config.AppName, config.AppVersion, config.UserAgentSuffix),
+		throttle:  config.Throttle,
+		sleep:     sleepContext,
+
+		mutationSink:          config.MutationSink,
+		mutationActor:         config.MutationActor,
+		mutationCaptureBefore: config.MutationCaptureBefore,
+		logger:                config.Logger,
 	}
 
-	if config.HTTPClient != nil {
-		c.client = config.HTTPClient
-	} else {
-		c.client = http.DefaultClient
+	if config.NotFoundTTL > 0 {
+		c.notFound = newNotFoundCache(config.NotFoundTTL)
 	}
 
+	if !config.DisableRetry {
+		retry := DefaultRetryPolicy
+		if config.Retry != nil {
+			retry = *config.Retry
+		}
+		c.retry = &retry
+	}
+
+	c.strictDecoding = config.StrictDecoding
+	c.compression = !config.DisableCompression
+	c.managedTag = config.ManagedTag
+
+	if config.Credentials != nil {
+		c.credentials = config.Credentials
+	}
+
+	if config.ResponseCache != nil {
+		c.responseCache = newResponseCache(config.ResponseCache, config.ResponseCacheTTL)
+	}
+
+	if config.RequestHistory > 0 {
+		c.history = newRequestHistory(config.RequestHistory)
+		c.historyBodies = config.RequestHistoryBodies
+	}
+
+	c.Actions = &ActionsService{client: c}
+	c.Analysis = &AnalysisService{client: c}
 	c.Checks = &CheckService{client: c}
+	c.Contacts = &ContactService{client: c}
+	c.Credits = &CreditsService{client: c}
 	c.Maintenances = &MaintenanceService{client: c}
+	c.Occurrences = &OccurrenceService{client: c}
 	c.Probes = &ProbeService{client: c}
+	c.Results = &ResultsService{client: c}
+	c.Single = &SingleService{client: c}
+	c.Summary = &SummaryService{client: c}
+	c.Teams = &TeamService{client: c}
+	c.Traceroute = &TracerouteService{client: c}
 	return c, nil
 }
 
+// newHTTPClient returns the HTTP client of the config, http.DefaultClient
+// when nothing is configured.
+func newHTTPClient(config ClientConfig) (*http.Client, error) {
+	tuned := config.Proxy != nil || config.TLSConfig != nil ||
+		config.MaxIdleConnsPerHost != 0 || config.IdleConnTimeout != 0 || config.DisableKeepAlives
+	custom := config.Transport != nil || tuned || config.Timeout != 0
+	if config.HTTPClient != nil {
+		if custom {
+			return nil, errors.New("HTTPClient can't be combined with Transport, Proxy, TLSConfig, Timeout or connection settings")
+		}
+		return config.HTTPClient, nil
+	}
+	if !custom {
+		return http.DefaultClient, nil
+	}
+	if config.Timeout < 0 {
+		return nil, fmt.Errorf("Invalid value for `Timeout`.  Must be positive")
+	}
+	if config.MaxIdleConnsPerHost < 0 {
+		return nil, fmt.Errorf("Invalid value for `MaxIdleConnsPerHost`.  Must be positive")
+	}
+	if config.IdleConnTimeout < 0 {
+		return nil, fmt.Errorf("Invalid value for `IdleConnTimeout`.  Must be positive")
+	}
+
+	transport := config.Transport
+	if tuned {
+		if transport != nil {
+			return nil, errors.New("Transport can't be combined with Proxy, TLSConfig or connection settings")
+		}
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		if config.Proxy != nil {
+			t.Proxy = config.Proxy
+		}
+		if config.TLSConfig != nil {
+			t.TLSClientConfig = config.TLSConfig
+		}
+		if config.MaxIdleConnsPerHost != 0 {
+			t.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+			if t.MaxIdleConns != 0 && t.MaxIdleConns < t.MaxIdleConnsPerHost {
+				t.MaxIdleConns = t.MaxIdleConnsPerHost
+			}
+		}
+		if config.IdleConnTimeout != 0 {
+			t.IdleConnTimeout = config.IdleConnTimeout
+		}
+		t.DisableKeepAlives = config.DisableKeepAlives
+		transport = t
+	}
+	return &http.Client{Transport: transport, Timeout: config.Timeout}, nil
+}
+
+// userAgent builds the User-Agent header from the library version and the
+// optional application information and suffix.
+func userAgent(appName, appVersion, suffix string) string {
+	ua := "go-pingdom/" + Version
+	if appName != "" {
+		app := appName
+		if appVersion != "" {
+			app += "/" + appVersion
+		}
+		ua = app + " " + ua
+	}
+	if suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
 // NewRequest makes a new HTTP Request.  The method param should be an HTTP method in
 // all caps such as GET, POST, PUT, DELETE.  The rsc param should correspond with
 // a restful resource.  Params can be passed in as a map of strings
 // Usually users of the client can use one of the convenience methods such as
 // ListChecks, etc but this method is provided to allow for making other
-// API calls that might not be built in.
+// API calls that might not be built in.  Use NewRequestWithValues to repeat a
+// query parameter.
 func (pc *Client) NewRequest(method string, rsc string, params map[string]string) (*http.Request, error) {
+	var values url.Values
+	if params != nil {
+		values = url.Values{}
+		for k, v := range params {
+			values.Set(k, v)
+		}
+	}
+	return pc.NewRequestWithValues(method, rsc, values)
+}
+
+// NewRequestWithValues makes a new HTTP Request like NewRequest, taking the
+// query parameters as url.Values so that a key can be given more than once.
+// The parameters are added to any query already present in rsc.
+func (pc *Client) NewRequestWithValues(method string, rsc string, params url.Values) (*http.Request, error) {
 	baseURL, err := url.Parse(pc.BaseURL.String() + rsc)
 	if err != nil {
 		return nil, err
 	}
 
 	if params != nil {
-		ps := url.Values{}
+		ps := baseURL.Query()
 		for k, v := range params {
-			ps.Set(k, v)
+			ps[k] = append(ps[k], v...)
 		}
 		baseURL.RawQuery = ps.Encode()
 	}
 
 	req, err := http.NewRequest(method, baseURL.String(), nil)
-	req.Header.Add("Authorization", "Bearer "+pc.APIToken)
-	return req, err
+	if err != nil {
+		return nil, err
+	}
+	if err := pc.authorize(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", pc.userAgent)
+	return req, nil
+}
+
+// NewJSONRequest makes a new HTTP Request with the given body encoded as JSON.
+// The alerting endpoints expect their parameters this way rather than in the
+// query string.
+func (pc *Client) NewJSONRequest(method string, rsc string, body interface{}) (*http.Request, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, pc.BaseURL.String()+rsc, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	if err := pc.authorize(req); err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", pc.userAgent)
+	return req, nil
 }
 
 // Do makes an HTTP request and will unmarshal the JSON response in to the
 // passed in interface.  If the HTTP response is outside of the 2xx range the
-// response will be returned along with the error.
+// response will be returned along with the error.  When a cached 404 is
+// returned the response is nil.
 func (pc *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := pc.client.Do(req)
+	if pc.notFound != nil && req.Method == http.MethodGet {
+		if err, ok := pc.notFound.get(req.URL.Path); ok {
+			return nil, err
+		}
+	}
+
+	var cached *CacheEntry
+	if pc.responseCache != nil && req.Method == http.MethodGet {
+		e, fresh := pc.responseCache.lookup(req)
+		if fresh {
+			return cachedResponse(req, e), decodeCached(e, v)
+		}
+		cached = e
+	}
+
+	resp, err := pc.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		pc.responseCache.refresh(req, cached)
+		return resp, decodeCached(cached, v)
+	}
+
 	if err := validateResponse(resp); err != nil {
+		if pe, ok := err.(*PingdomError); ok && pc.notFound != nil &&
+			req.Method == http.MethodGet && resp.StatusCode == http.StatusNotFound {
+			pc.notFound.add(req.URL.Path, pe)
+		}
 		return resp, err
 	}
 
+	if pc.notFound != nil && req.Method == http.MethodPost {
+		pc.notFound.invalidate(req.URL.Path)
+	}
+
+	if pc.responseCache != nil {
+		if req.Method == http.MethodGet {
+			pc.responseCache.store(req, resp)
+		} else {
+			pc.responseCache.invalidate()
+		}
+	}
+
+	if pc.strictDecoding {
+		err = decodeStrict(req, resp, v)
+		if err != nil && pc.logger != nil {
+			pc.logDecodeError(req, resp, err)
+		}
+		return resp, err
+	}
 	err = decodeResponse(resp, v)
 	return resp, err
 
 }
 
+// do sends the request, every call to the API goes through here.  It delays
+// the request when throttling is enabled and records the rate limit reported
+// in the response.  Throttled requests are retried according to the retry
+// policy.  Mutations are reported to the mutation sink.
+func (pc *Client) do(req *http.Request) (*http.Response, error) {
+	if pc.mutationSink != nil && isMutation(req.Method) {
+		rec := pc.newMutationRecord(req)
+		resp, err := pc.send(req)
+		pc.recordMutation(rec, resp, err)
+		return resp, err
+	}
+	return pc.send(req)
+}
+
+func (pc *Client) send(req *http.Request) (*http.Response, error) {
+	for retry := 0; ; retry++ {
+		if pc.throttle != nil {
+			rl, recorded := pc.rateLimit.get()
+			if err := pc.sleep(req.Context(), pc.throttle.delay(rl, recorded, time.Now())); err != nil {
+				return nil, err
+			}
+		}
+
+		if req.Header.Get("Accept-Encoding") == "" {
+			req.Header.Set("Accept-Encoding", pc.acceptEncoding())
+		}
+		start := time.Now()
+		resp, err := pc.client.Do(req)
+		if err == nil {
+			decompress(resp)
+		}
+		if pc.history != nil {
+			pc.recordRequest(req, start, resp, err)
+		}
+		if pc.logger != nil {
+			pc.logRequest(req, retry+1, start, resp, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if rl, ok := parseRateLimit(resp.Header); ok {
+			pc.rateLimit.record(rl, time.Now())
+		}
+
+		if pc.retry == nil || retry >= pc.retry.MaxRetries || !retryable(resp) {
+			return resp, nil
+		}
+		wait, ok := pc.retry.wait(retry, resp, time.Now())
+		if !ok || !rewindBody(req) {
+			return resp, nil
+		}
+		discardBody(resp)
+		if err := pc.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// newListMeta builds the metadata of a list call from its response and the
+// parameters it was made with.
+func newListMeta(resp *http.Response, params map[string]string, count int) ListMeta {
+	meta := ListMeta{Total: count}
+	meta.Limit, _ = strconv.Atoi(params["limit"])
+	meta.Offset, _ = strconv.Atoi(params["offset"])
+	meta.RateLimit = ResponseRateLimit(resp)
+	return meta
+}
+
 func decodeResponse(r *http.Response, v interface{}) error {
 	if v == nil {
 		return fmt.Errorf("nil interface provided to decodeResponse")
 	}
 
-	bodyBytes, _ := ioutil.ReadAll(r.Body)
-	bodyString := string(bodyBytes)
-	err := json.Unmarshal([]byte(bodyString), &v)
-	return err
+	return json.NewDecoder(r.Body).Decode(&v)
 }
 
 // Takes an HTTP response and determines whether it was successful.
@@ -123,12 +575,24 @@ func validateResponse(r *http.Response) error {
 	}
 
 	bodyBytes, _ := ioutil.ReadAll(r.Body)
-	bodyString := string(bodyBytes)
 	m := &errorJSONResponse{}
-	err := json.Unmarshal([]byte(bodyString), &m)
-	if err != nil {
-		return err
+	if err := json.Unmarshal(bodyBytes, &m); err != nil || m.Error == nil {
+		// Not a Pingdom error envelope (e.g. an error page from a proxy),
+		// build the error from the HTTP status instead.
+		m.Error = &PingdomError{Message: strings.TrimSpace(string(bodyBytes))}
 	}
 
-	return m.Error
+	pe := m.Error
+	if pe.StatusCode == 0 {
+		pe.StatusCode = r.StatusCode
+	}
+	if pe.StatusDesc == "" {
+		pe.StatusDesc = http.StatusText(r.StatusCode)
+	}
+
+	d := Diagnostics(r)
+	pe.RequestID = d.RequestID
+	pe.TraceID = d.TraceID
+
+	return pe
 }
@@ -1,6 +1,8 @@
 package pingdom
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -8,6 +10,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -59,6 +62,96 @@ func TestNewRequest(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "GET", req.Method)
 	assert.Equal(t, client.BaseURL.String()+"/checks", req.URL.String())
+	assert.Equal(t, "go-pingdom/"+Version, req.Header.Get("User-Agent"))
+}
+
+func TestNewRequestWithValues(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := url.Values{"tags": {"web", "db"}, "limit": {"10"}}
+	req, err := client.NewRequestWithValues("GET", "/checks?include_teams=true", params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web", "db"}, req.URL.Query()["tags"])
+	assert.Equal(t, "10", req.URL.Query().Get("limit"))
+	assert.Equal(t, "true", req.URL.Query().Get("include_teams"))
+
+	req, err = client.NewRequest("GET", "/checks", map[string]string{"name": "a b&c"})
+	assert.NoError(t, err)
+	assert.Equal(t, "name=a+b%26c", req.URL.RawQuery)
+}
+
+func TestNewRequestAppInfo(t *testing.T) {
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken:   "key",
+		AppName:    "my-operator",
+		AppVersion: "0.4.2",
+	})
+	assert.NoError(t, err)
+
+	req, err := c.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-operator/0.4.2 go-pingdom/"+Version, req.Header.Get("User-Agent"))
+
+	req, err = c.NewJSONRequest("PUT", "/alerting/teams/1", map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-operator/0.4.2 go-pingdom/"+Version, req.Header.Get("User-Agent"))
+}
+
+func TestNewClientWithConfigTransport(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.internal:3128")
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken:        "key",
+		Proxy:           http.ProxyURL(proxy),
+		TLSConfig:       &tls.Config{ServerName: "api.pingdom.com"},
+		Timeout:         10 * time.Second,
+		UserAgentSuffix: "region=eu",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, c.client.Timeout)
+	transport := c.client.Transport.(*http.Transport)
+	assert.Equal(t, "api.pingdom.com", transport.TLSClientConfig.ServerName)
+	assert.NotEqual(t, http.DefaultTransport, transport)
+
+	req, err := c.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	u, err := transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, proxy, u)
+	assert.Equal(t, "go-pingdom/"+Version+" region=eu", req.Header.Get("User-Agent"))
+
+	rt := &http.Transport{}
+	c, err = NewClientWithConfig(ClientConfig{APIToken: "key", Transport: rt})
+	assert.NoError(t, err)
+	assert.Equal(t, rt, c.client.Transport)
+	assert.Equal(t, time.Duration(0), c.client.Timeout)
+
+	_, err = NewClientWithConfig(ClientConfig{APIToken: "key", HTTPClient: &http.Client{}, Timeout: time.Second})
+	assert.Error(t, err)
+	_, err = NewClientWithConfig(ClientConfig{APIToken: "key", Transport: rt, Proxy: http.ProxyFromEnvironment})
+	assert.Error(t, err)
+	_, err = NewClientWithConfig(ClientConfig{APIToken: "key", Timeout: -time.Second})
+	assert.Error(t, err)
+}
+
+func TestClientTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	done := make(chan struct{})
+	defer close(done)
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+		}
+	})
+
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "key", BaseURL: server.URL, Timeout: 20 * time.Millisecond, DisableRetry: true})
+	assert.NoError(t, err)
+	_, err = c.Checks.List()
+	assert.Error(t, err)
 }
 
 func TestDo(t *testing.T) {
@@ -106,6 +199,137 @@ func TestValidateResponse(t *testing.T) {
 		}`)),
 	}
 
-	want := &PingdomError{400, "Bad Request", "This is an error"}
+	want := &PingdomError{StatusCode: 400, StatusDesc: "Bad Request", Message: "This is an error"}
+	assert.Equal(t, want, validateResponse(invalid))
+}
+
+func TestValidateResponseDiagnostics(t *testing.T) {
+	invalid := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusInternalServerError,
+		Header: http.Header{
+			"X-Request-Id": []string{"abc-123"},
+			"X-Trace-Id":   []string{"trace-456"},
+		},
+		Body: ioutil.NopCloser(strings.NewReader(`{
+			"error" : {
+				"statuscode": 500,
+				"statusdesc": "Internal Server Error",
+				"errormessage": "Something went wrong"
+			}
+		}`)),
+	}
+
+	want := &PingdomError{
+		StatusCode: 500,
+		StatusDesc: "Internal Server Error",
+		Message:    "Something went wrong",
+		RequestID:  "abc-123",
+		TraceID:    "trace-456",
+	}
+	assert.Equal(t, want, validateResponse(invalid))
+}
+
+func TestDiagnostics(t *testing.T) {
+	resp := &http.Response{
+		Header: http.Header{
+			"Request-Id":  []string{"abc-123"},
+			"Traceparent": []string{"00-4bf92f3577b34da6-00f067aa0ba902b7-01"},
+		},
+	}
+
+	want := ResponseDiagnostics{
+		RequestID: "abc-123",
+		TraceID:   "00-4bf92f3577b34da6-00f067aa0ba902b7-01",
+	}
+	assert.Equal(t, want, Diagnostics(resp))
+	assert.Equal(t, ResponseDiagnostics{}, Diagnostics(nil))
+}
+
+func TestValidateResponseDetails(t *testing.T) {
+	invalid := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusBadRequest,
+		Body: ioutil.NopCloser(strings.NewReader(`{
+			"error" : {
+				"statuscode": 400,
+				"statusdesc": "Bad Request",
+				"errormessage": "Invalid parameters",
+				"errors": [
+					{"field": "resolution", "code": "invalid", "message": "must be one of 1, 5, 15, 30, 60"},
+					{"field": "host", "message": "is required"}
+				]
+			}
+		}`)),
+	}
+
+	want := &PingdomError{
+		StatusCode: 400,
+		StatusDesc: "Bad Request",
+		Message:    "Invalid parameters",
+		Details: []PingdomErrorDetail{
+			{Field: "resolution", Code: "invalid", Message: "must be one of 1, 5, 15, 30, 60"},
+			{Field: "host", Message: "is required"},
+		},
+	}
+	err := validateResponse(invalid)
+	assert.Equal(t, want, err)
+	assert.Equal(t, "400 Bad Request: Invalid parameters; resolution: must be one of 1, 5, 15, 30, 60; host: is required", err.Error())
+}
+
+func TestValidateResponseWithoutEnvelope(t *testing.T) {
+	invalid := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusBadGateway,
+		Body:       ioutil.NopCloser(strings.NewReader("<html>Bad Gateway</html>\n")),
+	}
+
+	want := &PingdomError{
+		StatusCode: 502,
+		StatusDesc: "Bad Gateway",
+		Message:    "<html>Bad Gateway</html>",
+	}
 	assert.Equal(t, want, validateResponse(invalid))
 }
+
+func TestServicesWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hits := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := map[string]func() error{
+		"Checks.List": func() error { _, err := client.Checks.ListWithContext(ctx); return err },
+		"Checks.Create": func() error {
+			_, err := client.Checks.CreateWithContext(ctx, &HttpCheck{Name: "a", Hostname: "example.com", Resolution: 5})
+			return err
+		},
+		"Checks.Read":    func() error { _, err := client.Checks.ReadWithContext(ctx, 1); return err },
+		"Checks.Delete":  func() error { _, err := client.Checks.DeleteWithContext(ctx, 1); return err },
+		"Checks.Results": func() error { _, err := client.Checks.ResultsWithContext(ctx, 1); return err },
+		"Checks.SummaryOutage": func() error {
+			_, err := client.Checks.SummaryOutageWithContext(ctx, SummaryOutageRequest{Id: 1})
+			return err
+		},
+		"Maintenances.List":   func() error { _, err := client.Maintenances.ListWithContext(ctx); return err },
+		"Maintenances.Read":   func() error { _, err := client.Maintenances.ReadWithContext(ctx, 1); return err },
+		"Maintenances.Delete": func() error { _, err := client.Maintenances.DeleteWithContext(ctx, 1); return err },
+		"Probes.List":         func() error { _, err := client.Probes.ListWithContext(ctx); return err },
+		"Contacts.List":       func() error { _, err := client.Contacts.ListWithContext(ctx); return err },
+		"Contacts.Delete":     func() error { _, err := client.Contacts.DeleteWithContext(ctx, 1); return err },
+		"Teams.List":          func() error { _, err := client.Teams.ListWithContext(ctx); return err },
+		"Analysis.List":       func() error { _, err := client.Analysis.ListWithContext(ctx, 1); return err },
+	}
+	for name, call := range calls {
+		err := call()
+		assert.Error(t, err, name)
+		assert.True(t, strings.Contains(err.Error(), context.Canceled.Error()), name)
+	}
+	assert.Equal(t, 0, hits)
+}
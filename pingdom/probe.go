@@ -1,8 +1,8 @@
 package pingdom
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
+	"strconv"
 )
 
 // ProbeService provides an interface to Pingdom probes.
@@ -12,6 +12,27 @@ type ProbeService struct {
 
 // List return a list of probes from Pingdom.
 func (cs *ProbeService) List(params ...map[string]string) ([]ProbeResponse, error) {
+	return cs.ListWithContext(context.Background(), params...)
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (cs *ProbeService) ListWithContext(ctx context.Context, params ...map[string]string) ([]ProbeResponse, error) {
+	l, err := cs.ListWithMetaWithContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	return l.Probes, nil
+}
+
+// ListWithMeta returns the probes along with the list metadata, see List.
+func (cs *ProbeService) ListWithMeta(params ...map[string]string) (*ProbeList, error) {
+	return cs.ListWithMetaWithContext(context.Background(), params...)
+}
+
+// ListWithMetaWithContext is like ListWithMeta, the request is bound to ctx so
+// it can be canceled or given a deadline.
+func (cs *ProbeService) ListWithMetaWithContext(ctx context.Context, params ...map[string]string) (*ProbeList, error) {
 	param := map[string]string{}
 	if len(params) == 1 {
 		param = params[0]
@@ -20,22 +41,67 @@ func (cs *ProbeService) List(params ...map[string]string) ([]ProbeResponse, erro
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	resp, err := cs.client.client.Do(req)
+	p := &listProbesJSONResponse{}
+	resp, err := cs.client.Do(req, p)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
+	return &ProbeList{
+		Probes: p.Probes,
+		Meta:   newListMeta(resp, param, len(p.Probes)),
+	}, nil
+}
+
+// ListWithOptions returns the probes like List, taking typed filters instead
+// of raw parameters.
+func (cs *ProbeService) ListWithOptions(opts ...ProbeListOption) ([]ProbeResponse, error) {
+	return cs.ListWithOptionsWithContext(context.Background(), opts...)
+}
+
+// ListWithOptionsWithContext is like ListWithOptions, the request is bound to
+// ctx so it can be canceled or given a deadline.
+func (cs *ProbeService) ListWithOptionsWithContext(ctx context.Context, opts ...ProbeListOption) ([]ProbeResponse, error) {
+	o := &probeListOptions{params: map[string]string{}}
+	for _, opt := range opts {
+		opt(o)
 	}
 
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
+	probes, err := cs.ListWithContext(ctx, o.params)
+	if err != nil {
+		return nil, err
+	}
+	filtered := probes[:0]
+	for _, p := range probes {
+		if o.match(p) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
 
-	p := &listProbesJSONResponse{}
-	err = json.Unmarshal([]byte(bodyString), &p)
+// Location returns where the probe is, e.g. "Los Angeles, United States",
+// falling back to its name.
+func (p ProbeResponse) Location() string {
+	switch {
+	case p.City != "" && p.Country != "":
+		return p.City + ", " + p.Country
+	case p.Country != "":
+		return p.Country
+	case p.Name != "":
+		return p.Name
+	}
+	return "probe " + strconv.Itoa(p.ID)
+}
 
-	return p.Probes, err
+// ProbesByID indexes probes by id, to translate the probe ids of results and
+// alerts into locations.
+func ProbesByID(probes []ProbeResponse) map[int]ProbeResponse {
+	index := make(map[int]ProbeResponse, len(probes))
+	for _, p := range probes {
+		index[p.ID] = p
+	}
+	return index
 }
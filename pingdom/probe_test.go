@@ -76,3 +76,59 @@ func TestProbesServiceList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, probes, "Probes.List() should return correct result")
 }
+
+func TestProbesServiceListWithMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"probes": [{"id": 32, "name": "Los Angeles, CA"}]}`)
+	})
+
+	list, err := client.Probes.ListWithMeta()
+	assert.NoError(t, err)
+	assert.Equal(t, []ProbeResponse{{ID: 32, Name: "Los Angeles, CA"}}, list.Probes)
+	assert.Equal(t, ListMeta{Total: 1}, list.Meta)
+}
+
+func TestProbesServiceListWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "true", r.URL.Query().Get("onlyactive"))
+		assert.Equal(t, "true", r.URL.Query().Get("includedeleted"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Empty(t, r.URL.Query().Get("region"))
+		fmt.Fprint(w, `{"probes": [
+			{"id": 32, "countryiso": "US", "region": "NA"},
+			{"id": 33, "countryiso": "CA", "region": "NA"},
+			{"id": 184, "countryiso": "BR", "region": "LATAM"}
+		]}`)
+	})
+
+	probes, err := client.Probes.ListWithOptions(
+		ProbeFilter.OnlyActive(),
+		ProbeFilter.IncludeDeleted(),
+		ProbeFilter.WithLimit(10),
+		ProbeFilter.WithRegions(RegionNorthAmerica, "eu"),
+		ProbeFilter.WithCountries("US"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []ProbeResponse{{ID: 32, CountryISO: "US", Region: "NA"}}, probes)
+}
+
+func TestProbeResponseLocation(t *testing.T) {
+	assert.Equal(t, "Los Angeles, United States", ProbeResponse{City: "Los Angeles", Country: "United States"}.Location())
+	assert.Equal(t, "Brazil", ProbeResponse{Country: "Brazil"}.Location())
+	assert.Equal(t, "Sao Paulo 2, Brazil", ProbeResponse{Name: "Sao Paulo 2, Brazil"}.Location())
+	assert.Equal(t, "probe 7", ProbeResponse{ID: 7}.Location())
+}
+
+func TestProbesByID(t *testing.T) {
+	index := ProbesByID([]ProbeResponse{{ID: 32, City: "Los Angeles"}, {ID: 184, City: "São Paulo"}})
+	assert.Len(t, index, 2)
+	assert.Equal(t, "São Paulo", index[184].City)
+}
@@ -0,0 +1,89 @@
+package pingdom
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers in which Pingdom reports the remaining request quota.
+const (
+	rateLimitShortHeader = "Req-Limit-Short"
+	rateLimitLongHeader  = "Req-Limit-Long"
+)
+
+// RateLimit is the remaining request quota reported by Pingdom.  Pingdom
+// enforces a short (hourly) and a long (monthly) limit, each with its own
+// reset period.
+type RateLimit struct {
+	ShortRemaining int
+	ShortReset     time.Duration
+	LongRemaining  int
+	LongReset      time.Duration
+}
+
+// RateLimit returns the remaining request quota reported in the last
+// response, or nil when no response carried the rate limit headers yet.  The
+// reset durations are as reported, they don't account for the time elapsed
+// since.
+func (pc *Client) RateLimit() *RateLimit {
+	rl, _ := pc.rateLimit.get()
+	return rl
+}
+
+// ResponseRateLimit returns the remaining request quota reported in the
+// headers of the given response, or nil when they are missing.
+func ResponseRateLimit(r *http.Response) *RateLimit {
+	if r == nil {
+		return nil
+	}
+	rl, ok := parseRateLimit(r.Header)
+	if !ok {
+		return nil
+	}
+	return &rl
+}
+
+// parseRateLimit reads the rate limit headers from a response.  The second
+// return value is false when none of the headers are present.
+func parseRateLimit(h http.Header) (RateLimit, bool) {
+	var rl RateLimit
+	short, okShort := parseRateLimitHeader(h.Get(rateLimitShortHeader))
+	long, okLong := parseRateLimitHeader(h.Get(rateLimitLongHeader))
+	if okShort {
+		rl.ShortRemaining, rl.ShortReset = short.remaining, short.reset
+	}
+	if okLong {
+		rl.LongRemaining, rl.LongReset = long.remaining, long.reset
+	}
+	return rl, okShort || okLong
+}
+
+type rateLimitValue struct {
+	remaining int
+	reset     time.Duration
+}
+
+// parseRateLimitHeader parses values of the form
+// "Remaining: 394 Time until reset: 3589".
+func parseRateLimitHeader(v string) (rateLimitValue, bool) {
+	var rv rateLimitValue
+	fields := strings.Fields(v)
+	found := false
+	for i := 0; i < len(fields)-1; i++ {
+		n, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			continue
+		}
+		switch strings.ToLower(fields[i]) {
+		case "remaining:":
+			rv.remaining = n
+			found = true
+		case "reset:":
+			rv.reset = time.Duration(n) * time.Second
+			found = true
+		}
+	}
+	return rv, found
+}
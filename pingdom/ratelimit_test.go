@@ -0,0 +1,64 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	h := http.Header{}
+	h.Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+	h.Set("Req-Limit-Long", "Remaining: 71994 Time until reset: 2591989")
+
+	rl, ok := parseRateLimit(h)
+	assert.True(t, ok)
+	assert.Equal(t, RateLimit{
+		ShortRemaining: 394,
+		ShortReset:     3589 * time.Second,
+		LongRemaining:  71994,
+		LongReset:      2591989 * time.Second,
+	}, rl)
+
+	_, ok = parseRateLimit(http.Header{})
+	assert.False(t, ok)
+
+	h = http.Header{}
+	h.Set("Req-Limit-Short", "garbage")
+	_, ok = parseRateLimit(h)
+	assert.False(t, ok)
+}
+
+func TestClientRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+		w.Header().Set("Req-Limit-Long", "Remaining: 71994 Time until reset: 2591989")
+		fmt.Fprint(w, `{"probes": []}`)
+	})
+
+	assert.Nil(t, client.RateLimit())
+
+	_, err := client.Probes.List()
+	assert.NoError(t, err)
+	assert.Equal(t, &RateLimit{
+		ShortRemaining: 394,
+		ShortReset:     3589 * time.Second,
+		LongRemaining:  71994,
+		LongReset:      2591989 * time.Second,
+	}, client.RateLimit())
+}
+
+func TestResponseRateLimit(t *testing.T) {
+	assert.Nil(t, ResponseRateLimit(nil))
+	assert.Nil(t, ResponseRateLimit(&http.Response{Header: http.Header{}}))
+
+	h := http.Header{}
+	h.Set("Req-Limit-Short", "Remaining: 12 Time until reset: 60")
+	assert.Equal(t, &RateLimit{ShortRemaining: 12, ShortReset: time.Minute}, ResponseRateLimit(&http.Response{Header: h}))
+}
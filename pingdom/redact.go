@@ -0,0 +1,115 @@
+package pingdom
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces the secrets in the URLs and parameters the client
+// reports to loggers, diagnostics bundles and mutation sinks.
+const redacted = "REDACTED"
+
+// redactParam masks the value of a query parameter holding a secret: the
+// credentials of HTTP and SMTP checks and the values of the custom headers
+// of HTTP checks, whose names are kept.
+func redactParam(name, value string) string {
+	switch {
+	case name == "auth":
+		return redacted
+	case strings.HasPrefix(name, "requestheader"):
+		if i := strings.Index(value, ":"); i >= 0 {
+			return value[:i+1] + redacted
+		}
+		return redacted
+	}
+	return value
+}
+
+// redactValues returns a copy of values with the secrets masked.
+func redactValues(values url.Values) url.Values {
+	copied := make(url.Values, len(values))
+	for name, vs := range values {
+		for _, v := range vs {
+			copied[name] = append(copied[name], redactParam(name, v))
+		}
+	}
+	return copied
+}
+
+// redactURL renders u with the secrets of its query and the API token
+// masked.
+func (pc *Client) redactURL(u *url.URL) string {
+	copied := *u
+	if copied.RawQuery != "" {
+		copied.RawQuery = redactValues(copied.Query()).Encode()
+	}
+	s := copied.String()
+	if pc.APIToken != "" {
+		s = strings.Replace(s, pc.APIToken, redacted, -1)
+	}
+	return s
+}
+
+// secretFields are the JSON fields of responses whose values are masked:
+// the credentials, headers and post data of checks and the email addresses
+// and phone numbers of contacts.
+var secretFields = map[string]bool{
+	"auth":           true,
+	"password":       true,
+	"postdata":       true,
+	"requestheaders": true,
+	"email":          true,
+	"address":        true,
+	"number":         true,
+	"phone":          true,
+}
+
+// redactJSON returns the JSON document b with the values of the
+// secretFields masked, or nothing when b is not JSON.
+func redactJSON(b []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil
+	}
+	redactJSONValue(v)
+	redactedJSON, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return redactedJSON
+}
+
+// redactJSONValue masks, in place, the strings held by the secretFields of
+// v, and the string values of the objects they hold such as the request
+// headers of HTTP checks.
+func redactJSONValue(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if !secretFields[k] {
+				redactJSONValue(field)
+				continue
+			}
+			switch field := field.(type) {
+			case string:
+				v[k] = redacted
+			case map[string]interface{}:
+				for name, value := range field {
+					if _, ok := value.(string); ok {
+						field[name] = redacted
+					}
+				}
+			default:
+				redactJSONValue(field)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactJSONValue(item)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package pingdom
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactURL(t *testing.T) {
+	c := &Client{APIToken: "secret_token"}
+	u, _ := url.Parse("https://api.pingdom.com/api/3.1/checks/1?auth=user%3Apass&name=web&requestheader0=X-Api-Key%3Akey123&requestheader1=broken&requestheader0=Accept%3Atext%2Fhtml&tags=secret_token")
+	assert.Equal(t, "https://api.pingdom.com/api/3.1/checks/1?auth=REDACTED&name=web"+
+		"&requestheader0=X-Api-Key%3AREDACTED&requestheader0=Accept%3AREDACTED&requestheader1=REDACTED&tags=REDACTED", c.redactURL(u))
+	assert.Equal(t, "user:pass", u.Query().Get("auth"), "the URL is not modified")
+
+	u, _ = url.Parse("https://api.pingdom.com/api/3.1/checks")
+	assert.Equal(t, "https://api.pingdom.com/api/3.1/checks", c.redactURL(u))
+}
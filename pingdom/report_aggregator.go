@@ -0,0 +1,144 @@
+package pingdom
+
+import (
+	"context"
+	"time"
+)
+
+// CheckReport merges the summary reports of a check over a time range.
+type CheckReport struct {
+	CheckID int
+	From    time.Time
+	To      time.Time
+	// Up, Down and Unknown are the time the check spent in each state.
+	Up      time.Duration
+	Down    time.Duration
+	Unknown time.Duration
+	// Uptime is the ratio of the time the check was up to the time it was
+	// up or down, from 0 to 1.  It is 1 when the check was never tested.
+	Uptime float64
+	// AvgResponse is the average response time over the range.
+	AvgResponse time.Duration
+	// Outages is the number of periods the check was down.
+	Outages int
+	// Performance holds the uptime, downtime and response time of each
+	// hour, day or week of the range, oldest first.
+	Performance []SummaryPerformanceSummary
+}
+
+// ReportAggregator fetches the summary reports of many checks concurrently
+// and merges them into a CheckReport per check, e.g. to build an account
+// wide dashboard.  Requests still go through the client, so they are
+// throttled and retried as usual.
+type ReportAggregator struct {
+	client *Client
+	// Workers is the number of checks fetched concurrently,
+	// DefaultBulkWorkers when zero.
+	Workers int
+	// Resolution is the period of the Performance of the reports, "hour",
+	// "day" or "week".  It defaults to "day".
+	Resolution string
+}
+
+// NewReportAggregator returns a ReportAggregator using client.
+func NewReportAggregator(client *Client) *ReportAggregator {
+	return &ReportAggregator{client: client}
+}
+
+// Fetch returns the reports of the checks with the given ids between from
+// and to, keyed by check id.  When some checks fail the reports of the
+// others are returned along with a *BulkError whose indexes are those of
+// ids.
+func (a *ReportAggregator) Fetch(ids []int, from, to time.Time) (map[int]*CheckReport, error) {
+	return a.FetchWithContext(context.Background(), ids, from, to)
+}
+
+// FetchWithContext is like Fetch, the requests are bound to ctx so they can
+// be canceled or given a deadline.
+func (a *ReportAggregator) FetchWithContext(ctx context.Context, ids []int, from, to time.Time) (map[int]*CheckReport, error) {
+	if from.After(to) {
+		return nil, ErrBadTimeRange
+	}
+	resolution := a.Resolution
+	if resolution == "" {
+		resolution = "day"
+	}
+	if err := (SummaryPerformanceRequest{Id: 1, Resolution: resolution}).Valid(); err != nil {
+		return nil, err
+	}
+
+	reports := make([]*CheckReport, len(ids))
+	err := bulk(ctx, len(ids), a.Workers, func(i int) int { return ids[i] }, func(ctx context.Context, i int) error {
+		r, err := a.fetch(ctx, ids[i], from, to, resolution)
+		reports[i] = r
+		return err
+	})
+
+	byID := make(map[int]*CheckReport, len(ids))
+	for _, r := range reports {
+		if r != nil {
+			byID[r.CheckID] = r
+		}
+	}
+	return byID, err
+}
+
+// fetch builds the report of a check from its average, outage and
+// performance summaries.
+func (a *ReportAggregator) fetch(ctx context.Context, id int, from, to time.Time, resolution string) (*CheckReport, error) {
+	summary := a.client.Summary
+	average, err := summary.AverageWithContext(ctx, SummaryAverageRequest{
+		Id:            id,
+		From:          from.Unix(),
+		To:            to.Unix(),
+		IncludeUptime: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	outage, err := summary.OutageWithContext(ctx, SummaryOutageRequest{
+		Id:   id,
+		From: from.Unix(),
+		To:   to.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	performance, err := summary.PerformanceWithContext(ctx, SummaryPerformanceRequest{
+		Id:            id,
+		From:          int(from.Unix()),
+		To:            int(to.Unix()),
+		Resolution:    resolution,
+		IncludeUptime: true,
+		Order:         "asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &CheckReport{
+		CheckID:     id,
+		From:        from,
+		To:          to,
+		Uptime:      1,
+		AvgResponse: average.Summary.ResponseTime.AvgResponseDuration(),
+		Outages:     len(BuildIncidents(outage.Summary.States, 0)),
+	}
+	if s := average.Summary.Status; s != nil {
+		r.Up = s.TotalUpDuration()
+		r.Down = s.TotalDownDuration()
+		r.Unknown = s.TotalUnknownDuration()
+		if s.TotalUp+s.TotalDown > 0 {
+			r.Uptime = float64(s.TotalUp) / float64(s.TotalUp+s.TotalDown)
+		}
+	}
+	switch resolution {
+	case "hour":
+		r.Performance = performance.Summary.Hours
+	case "day":
+		r.Performance = performance.Summary.Days
+	case "week":
+		r.Performance = performance.Summary.Weeks
+	}
+	return r, nil
+}
@@ -0,0 +1,79 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportAggregatorFetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, id := range []string{"1", "3"} {
+		mux.HandleFunc("/summary.average/"+id, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			assert.Equal(t, "true", r.URL.Query().Get("includeuptime"))
+			assert.Equal(t, "1000", r.URL.Query().Get("from"))
+			fmt.Fprint(w, `{"summary":{"responsetime":{"from":1000,"to":5000,"avgresponse":250},"status":{"totalup":3000,"totaldown":900,"totalunknown":100}}}`)
+		})
+		mux.HandleFunc("/summary.outage/"+id, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"summary":{"states":[
+				{"status":"up","timefrom":1000,"timeto":2000},
+				{"status":"down","timefrom":2000,"timeto":2500},
+				{"status":"up","timefrom":2500,"timeto":4000},
+				{"status":"down","timefrom":4000,"timeto":4400},
+				{"status":"up","timefrom":4400,"timeto":5000}
+			]}}`)
+		})
+		mux.HandleFunc("/summary.performance/"+id, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "week", r.URL.Query().Get("resolution"))
+			assert.Equal(t, "asc", r.URL.Query().Get("order"))
+			fmt.Fprint(w, `{"summary":{"weeks":[{"starttime":1000,"avgresponse":250,"uptime":3000,"downtime":900}]}}`)
+		})
+	}
+	mux.HandleFunc("/summary.average/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"statuscode":404,"statusdesc":"Not Found","errormessage":"Check not found"}}`)
+	})
+
+	a := NewReportAggregator(client)
+	a.Resolution = "week"
+	a.Workers = 2
+	reports, err := a.Fetch([]int{1, 2, 3}, time.Unix(1000, 0), time.Unix(5000, 0))
+
+	var be *BulkError
+	if assert.True(t, errors.As(err, &be)) {
+		assert.Len(t, be.Errors, 1)
+		assert.Equal(t, 1, be.Errors[0].Index)
+		assert.Equal(t, 2, be.Errors[0].ID)
+	}
+	assert.Len(t, reports, 2)
+	assert.Equal(t, &CheckReport{
+		CheckID:     1,
+		From:        time.Unix(1000, 0),
+		To:          time.Unix(5000, 0),
+		Up:          3000 * time.Second,
+		Down:        900 * time.Second,
+		Unknown:     100 * time.Second,
+		Uptime:      3000.0 / 3900.0,
+		AvgResponse: 250 * time.Millisecond,
+		Outages:     2,
+		Performance: []SummaryPerformanceSummary{{StartTime: 1000, AvgResponse: 250, Uptime: 3000, Downtime: 900}},
+	}, reports[1])
+	assert.Equal(t, 3, reports[3].CheckID)
+}
+
+func TestReportAggregatorFetchInvalid(t *testing.T) {
+	a := NewReportAggregator(client)
+	_, err := a.Fetch([]int{1}, time.Unix(5000, 0), time.Unix(1000, 0))
+	assert.Equal(t, ErrBadTimeRange, err)
+
+	a.Resolution = "month"
+	_, err = a.Fetch([]int{1}, time.Unix(1000, 0), time.Unix(5000, 0))
+	assert.Equal(t, ErrBadResolution, err)
+}
@@ -0,0 +1,171 @@
+package pingdom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HumanizeDuration renders a duration with its two most significant units,
+// e.g. "21m 40s", "3h 5m" or "2d 4h".  It is rounded to the second.
+func HumanizeDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < 0 {
+		return "-" + HumanizeDuration(-d)
+	}
+
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+	var parts []string
+	for _, u := range units {
+		if n := d / u.size; n > 0 || (len(parts) > 0 && len(parts) < 2) {
+			parts = append(parts, strconv.FormatInt(int64(n), 10)+u.suffix)
+			d -= n * u.size
+		}
+		if len(parts) == 2 {
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return "0s"
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormatPercent renders a percentage with up to two decimals, e.g. "99.95%"
+// or "100%".
+func FormatPercent(p float64) string {
+	s := strconv.FormatFloat(p, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	return s + "%"
+}
+
+// plural renders a count with a noun, adding an s unless count is 1.
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(count) + " " + noun + "s"
+}
+
+// Availability returns the percentage of monitored time the check was up, or
+// 100 when it was not monitored at all.
+func (m FailureMetrics) Availability() float64 {
+	total := m.Uptime + m.Downtime
+	if total == 0 {
+		return 100
+	}
+	return float64(m.Uptime) / float64(total) * 100
+}
+
+// String renders the metrics as e.g. "99.95% uptime, 2 outages totalling
+// 21m 40s".
+func (m FailureMetrics) String() string {
+	return FormatFailureMetrics(m, "")
+}
+
+// FormatFailureMetrics renders the metrics like FailureMetrics.String and,
+// when given, names the probe which saw the most failures.
+func FormatFailureMetrics(m FailureMetrics, worstProbe string) string {
+	s := FormatPercent(m.Availability()) + " uptime, "
+	switch m.Outages {
+	case 0:
+		s += "no outages"
+	case 1:
+		s += "1 outage lasting " + HumanizeDuration(m.Downtime)
+	default:
+		s += plural(m.Outages, "outage") + " totalling " + HumanizeDuration(m.Downtime)
+	}
+	if worstProbe != "" {
+		s += ", worst probe: " + worstProbe
+	}
+	return s
+}
+
+// WorstProbe returns the name of the probe which reported the most failed
+// results, or an empty string when none failed.  Probes are looked up in
+// probes, the id is used for unknown probes.
+func WorstProbe(results []Result, probes []ProbeResponse) string {
+	failures := map[int]int{}
+	worst := 0
+	for _, r := range results {
+		if r.Status == "up" {
+			continue
+		}
+		failures[r.ProbeID]++
+		if failures[r.ProbeID] > failures[worst] ||
+			(failures[r.ProbeID] == failures[worst] && r.ProbeID < worst) {
+			worst = r.ProbeID
+		}
+	}
+	if len(failures) == 0 {
+		return ""
+	}
+
+	for _, p := range probes {
+		if p.ID == worst {
+			if p.City != "" {
+				return p.City
+			}
+			return p.Name
+		}
+	}
+	return "probe " + strconv.Itoa(worst)
+}
+
+// String renders the outage as e.g. "down for 5m 0s from 2020-09-13 12:26
+// UTC (timeout)".
+func (o EnrichedOutage) String() string {
+	s := fmt.Sprintf("down for %s from %s", HumanizeDuration(o.Duration()),
+		time.Unix(o.TimeFrom, 0).UTC().Format("2006-01-02 15:04 MST"))
+	if o.PrimaryCause != "" {
+		s += " (" + o.PrimaryCause + ")"
+	}
+	return s
+}
+
+// String renders the report as e.g. "3 dangling references in 12 checks".
+func (r *ReferenceReport) String() string {
+	if r.OK() {
+		return "no dangling references in " + plural(r.ChecksAudited, "check")
+	}
+	return plural(len(r.Dangling), "dangling reference") + " in " + plural(r.ChecksAudited, "check")
+}
+
+// String renders the report as e.g. "2 unused contacts, 1 stale paused
+// check".
+func (r *OrphanReport) String() string {
+	if r.Empty() {
+		return "no orphans"
+	}
+	var parts []string
+	if n := len(r.UnusedContacts); n > 0 {
+		parts = append(parts, plural(n, "unused contact"))
+	}
+	if n := len(r.UnusedTeams); n > 0 {
+		parts = append(parts, plural(n, "unused team"))
+	}
+	if n := len(r.OrphanedMaintenances); n > 0 {
+		parts = append(parts, plural(n, "orphaned maintenance window"))
+	}
+	if n := len(r.StalePausedChecks); n > 0 {
+		parts = append(parts, plural(n, "stale paused check"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// String renders the delta as e.g. "2 created, 1 modified, 0 deleted".
+func (d *CheckDelta) String() string {
+	if d.Empty() {
+		return "no changes"
+	}
+	return fmt.Sprintf("%d created, %d modified, %d deleted", len(d.Created), len(d.Modified), len(d.Deleted))
+}
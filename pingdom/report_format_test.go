@@ -0,0 +1,79 @@
+package pingdom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                                "0s",
+		400 * time.Millisecond:           "0s",
+		45 * time.Second:                 "45s",
+		21*time.Minute + 40*time.Second:  "21m 40s",
+		5 * time.Minute:                  "5m 0s",
+		3*time.Hour + 5*time.Minute + 10: "3h 5m",
+		50*time.Hour + 30*time.Minute:    "2d 2h",
+		-(90 * time.Second):              "-1m 30s",
+		24*time.Hour + 59*time.Second:    "1d 0h",
+	}
+	for d, want := range tests {
+		assert.Equal(t, want, HumanizeDuration(d), d.String())
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	assert.Equal(t, "99.95%", FormatPercent(99.9499))
+	assert.Equal(t, "100%", FormatPercent(100))
+	assert.Equal(t, "99.9%", FormatPercent(99.9))
+}
+
+func TestFormatFailureMetrics(t *testing.T) {
+	m := FailureMetrics{
+		Outages:  2,
+		Uptime:   30*24*time.Hour - 21*time.Minute - 40*time.Second,
+		Downtime: 21*time.Minute + 40*time.Second,
+	}
+	assert.Equal(t, "99.95% uptime, 2 outages totalling 21m 40s", m.String())
+	assert.Equal(t, "99.95% uptime, 2 outages totalling 21m 40s, worst probe: Frankfurt", FormatFailureMetrics(m, "Frankfurt"))
+
+	m = FailureMetrics{Outages: 1, Uptime: 95 * time.Minute, Downtime: 5 * time.Minute}
+	assert.Equal(t, "95% uptime, 1 outage lasting 5m 0s", m.String())
+	assert.Equal(t, "100% uptime, no outages", FailureMetrics{}.String())
+}
+
+func TestWorstProbe(t *testing.T) {
+	probes := []ProbeResponse{{ID: 1, Name: "Frankfurt 2", City: "Frankfurt"}, {ID: 2, Name: "Dallas 5"}}
+	results := []Result{
+		{ProbeID: 1, Status: "down"},
+		{ProbeID: 2, Status: "down"},
+		{ProbeID: 2, Status: "down"},
+		{ProbeID: 1, Status: "up"},
+		{ProbeID: 3, Status: "unconfirmed_down"},
+	}
+	assert.Equal(t, "Dallas 5", WorstProbe(results, probes))
+	assert.Equal(t, "Frankfurt", WorstProbe(results[:2], probes))
+	assert.Equal(t, "probe 3", WorstProbe(results[4:], probes))
+	assert.Equal(t, "", WorstProbe(results[3:4], probes))
+}
+
+func TestReportStrings(t *testing.T) {
+	o := EnrichedOutage{TimeFrom: 1600000000, TimeTo: 1600000300, PrimaryCause: RootCauseTimeout}
+	assert.Equal(t, "down for 5m 0s from 2020-09-13 12:26 UTC (timeout)", o.String())
+
+	assert.Equal(t, "no dangling references in 1 check", (&ReferenceReport{ChecksAudited: 1}).String())
+	assert.Equal(t, "1 dangling reference in 12 checks",
+		(&ReferenceReport{ChecksAudited: 12, Dangling: []DanglingReference{{}}}).String())
+
+	assert.Equal(t, "no orphans", (&OrphanReport{}).String())
+	assert.Equal(t, "2 unused contacts, 1 stale paused check", (&OrphanReport{
+		UnusedContacts:    []ContactResponse{{}, {}},
+		StalePausedChecks: []CheckResponse{{}},
+	}).String())
+
+	assert.Equal(t, "no changes", (&CheckDelta{}).String())
+	assert.Equal(t, "1 created, 0 modified, 2 deleted",
+		(&CheckDelta{Created: []CheckResponse{{}}, Deleted: []CheckResponse{{}, {}}}).String())
+}
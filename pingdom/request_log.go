@@ -0,0 +1,88 @@
+package pingdom
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// RequestLog describes a single attempt of a request made by the client.
+// Retried requests are logged once per attempt.  It never contains the API
+// token, nor the credentials and custom header values of checks.
+type RequestLog struct {
+	Method string
+	// URL is the requested URL, with its query, secrets masked.
+	URL string
+	// Attempt is 1 for the first attempt and grows with each retry.
+	Attempt int
+	// StatusCode is 0 when no response was received, Err is set then.
+	StatusCode int
+	Duration   time.Duration
+	RequestID  string
+	// RateLimit is the quota reported in the response, nil when missing.
+	RateLimit *RateLimit
+	Err       error
+}
+
+// RequestLogger is called after every attempt of a request made by the
+// client, e.g. to log it or to export metrics.  It is called from the
+// goroutine making the request, so it should not block.
+type RequestLogger interface {
+	LogRequest(l RequestLog)
+}
+
+// RequestLoggerFunc adapts a function to the RequestLogger interface.
+type RequestLoggerFunc func(l RequestLog)
+
+// LogRequest calls f(l).
+func (f RequestLoggerFunc) LogRequest(l RequestLog) {
+	f(l)
+}
+
+// NewStdRequestLogger returns a RequestLogger writing a line per attempt to
+// logger, e.g. "GET https://api.pingdom.com/api/3.1/checks 200 215ms
+// ratelimit=394/43000".
+func NewStdRequestLogger(logger *log.Logger) RequestLogger {
+	return RequestLoggerFunc(func(l RequestLog) {
+		logger.Print(l.String())
+	})
+}
+
+// String renders the log as a single line.
+func (l RequestLog) String() string {
+	s := fmt.Sprintf("%s %s", l.Method, l.URL)
+	if l.Err != nil {
+		s += " error: " + l.Err.Error()
+	} else {
+		s += fmt.Sprintf(" %d", l.StatusCode)
+	}
+	s += " " + l.Duration.Round(time.Millisecond).String()
+	if l.Attempt > 1 {
+		s += fmt.Sprintf(" attempt=%d", l.Attempt)
+	}
+	if l.RequestID != "" {
+		s += " request_id=" + l.RequestID
+	}
+	if l.RateLimit != nil {
+		s += fmt.Sprintf(" ratelimit=%d/%d", l.RateLimit.ShortRemaining, l.RateLimit.LongRemaining)
+	}
+	return s
+}
+
+// logRequest reports an attempt of a request to the logger.
+func (pc *Client) logRequest(req *http.Request, attempt int, start time.Time, resp *http.Response, err error) {
+	l := RequestLog{
+		Method:   req.Method,
+		URL:      pc.redactURL(req.URL),
+		Attempt:  attempt,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if resp != nil {
+		l.StatusCode = resp.StatusCode
+		l.RequestID = Diagnostics(resp).RequestID
+		l.RateLimit = ResponseRateLimit(resp)
+	}
+	pc.logger.LogRequest(l)
+}
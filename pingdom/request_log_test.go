@@ -0,0 +1,108 @@
+package pingdom
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLogString(t *testing.T) {
+	l := RequestLog{
+		Method:     "GET",
+		URL:        "https://api.pingdom.com/api/3.1/checks",
+		Attempt:    2,
+		StatusCode: 200,
+		Duration:   215 * time.Millisecond,
+		RequestID:  "abc",
+		RateLimit:  &RateLimit{ShortRemaining: 394, LongRemaining: 43000},
+	}
+	assert.Equal(t, "GET https://api.pingdom.com/api/3.1/checks 200 215ms attempt=2 request_id=abc ratelimit=394/43000", l.String())
+
+	l = RequestLog{Method: "GET", URL: "https://api.pingdom.com/api/3.1/checks", Attempt: 1, Err: errors.New("connection refused")}
+	assert.Equal(t, "GET https://api.pingdom.com/api/3.1/checks error: connection refused 0s", l.String())
+}
+
+func TestClientLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var logs []RequestLog
+	client.logger = RequestLoggerFunc(func(l RequestLog) { logs = append(logs, l) })
+	client.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	calls := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+		fmt.Fprint(w, `{"checks": []}`)
+	})
+
+	_, err := client.Checks.List()
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "GET", logs[0].Method)
+	assert.Equal(t, 1, logs[0].Attempt)
+	assert.Equal(t, http.StatusTooManyRequests, logs[0].StatusCode)
+	assert.Nil(t, logs[0].RateLimit)
+	assert.Equal(t, 2, logs[1].Attempt)
+	assert.Equal(t, http.StatusOK, logs[1].StatusCode)
+	assert.Equal(t, 394, logs[1].RateLimit.ShortRemaining)
+	assert.True(t, strings.HasSuffix(logs[1].URL, "/checks"))
+}
+
+func TestStdRequestLoggerRedactsToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	client.logger = NewStdRequestLogger(log.New(&buf, "", 0))
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks": []}`)
+	})
+
+	_, err := client.Checks.List(map[string]string{"tags": client.APIToken})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "/checks?tags=REDACTED 200")
+	assert.NotContains(t, buf.String(), client.APIToken)
+}
+
+func TestStdRequestLoggerRedactsCheckSecrets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	client.logger = NewStdRequestLogger(log.New(&buf, "", 0))
+
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "user:hunter2", r.URL.Query().Get("auth"))
+		fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+	})
+
+	_, err := client.Checks.Update(1, &HttpCheck{
+		Name:           "web",
+		Hostname:       "example.com",
+		Resolution:     5,
+		Username:       "user",
+		Password:       "hunter2",
+		RequestHeaders: map[string]string{"X-Api-Key": "key123"},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "auth=REDACTED")
+	assert.Contains(t, buf.String(), "requestheader0=X-Api-Key%3AREDACTED")
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "key123")
+}
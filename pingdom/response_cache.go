@@ -0,0 +1,109 @@
+package pingdom
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// responseCache keeps the bodies of GET responses in a Cache.  Entries
+// younger than ttl are served without calling Pingdom, older ones are
+// revalidated with a conditional request when Pingdom sent an ETag or a
+// Last-Modified header.
+type responseCache struct {
+	cache Cache
+	ttl   time.Duration
+
+	// keys are the entries stored through this client, dropped after any
+	// change since it may affect every list.
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func newResponseCache(cache Cache, ttl time.Duration) *responseCache {
+	return &responseCache{cache: cache, ttl: ttl, keys: map[string]bool{}}
+}
+
+// lookup returns the entry cached for the request and whether it is fresh
+// enough to be used as is.  Stale entries with validators make the request
+// conditional.
+func (c *responseCache) lookup(req *http.Request) (*CacheEntry, bool) {
+	e, err := c.cache.Get(req.URL.String())
+	if err != nil || e == nil {
+		return nil, false
+	}
+	if e.Age() < c.ttl {
+		return e, true
+	}
+	if e.ETag == "" && e.LastModified == "" {
+		return nil, false
+	}
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+	return e, false
+}
+
+// store caches the body of a successful response.  The body is buffered so
+// it can still be decoded.
+func (c *responseCache) store(req *http.Request, resp *http.Response) {
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return
+	}
+
+	key := req.URL.String()
+	err = c.cache.Set(key, &CacheEntry{
+		Data:         b,
+		StoredAt:     time.Now(),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	})
+	if err == nil {
+		c.mu.Lock()
+		c.keys[key] = true
+		c.mu.Unlock()
+	}
+}
+
+// refresh marks a revalidated entry as fresh again.
+func (c *responseCache) refresh(req *http.Request, e *CacheEntry) {
+	refreshed := *e
+	refreshed.StoredAt = time.Now()
+	c.cache.Set(req.URL.String(), &refreshed)
+}
+
+// invalidate drops every entry stored through this client.
+func (c *responseCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.keys {
+		c.cache.Delete(key)
+	}
+	c.keys = map[string]bool{}
+}
+
+// cachedResponse builds the response returned for an entry served from the
+// cache.
+func cachedResponse(req *http.Request, e *CacheEntry) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(e.Data)),
+		Request:    req,
+	}
+}
+
+// decodeCached decodes a cached body like decodeResponse.
+func decodeCached(e *CacheEntry, v interface{}) error {
+	return json.Unmarshal(e.Data, &v)
+}
@@ -0,0 +1,97 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCacheFresh(t *testing.T) {
+	setup()
+	defer teardown()
+	client.responseCache = newResponseCache(NewMemoryCache(), time.Minute)
+
+	calls := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"checks": [{"id": %d, "name": "web"}]}`, calls)
+	})
+
+	for i := 0; i < 3; i++ {
+		checks, err := client.Checks.List()
+		assert.NoError(t, err)
+		assert.Equal(t, []CheckResponse{{ID: 1, Name: "web"}}, checks)
+	}
+	assert.Equal(t, 1, calls)
+
+	_, err := client.Checks.List(map[string]string{"tags": "prod"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestResponseCacheRevalidate(t *testing.T) {
+	setup()
+	defer teardown()
+	client.responseCache = newResponseCache(NewMemoryCache(), 0)
+
+	calls := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		assert.Equal(t, 1, calls)
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"checks": [{"id": 1, "name": "web"}]}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		checks, err := client.Checks.List()
+		assert.NoError(t, err)
+		assert.Equal(t, []CheckResponse{{ID: 1, Name: "web"}}, checks)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestResponseCacheInvalidatedByChanges(t *testing.T) {
+	setup()
+	defer teardown()
+	client.responseCache = newResponseCache(NewMemoryCache(), time.Minute)
+
+	reads := 0
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			fmt.Fprint(w, `{"message": "Deletion of check was successful!"}`)
+			return
+		}
+		reads++
+		fmt.Fprint(w, `{"check": {"id": 12345, "name": "web"}}`)
+	})
+
+	_, err := client.Checks.Read(12345)
+	assert.NoError(t, err)
+	_, err = client.Checks.Read(12345)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reads)
+
+	_, err = client.Checks.Delete(12345)
+	assert.NoError(t, err)
+	_, err = client.Checks.Read(12345)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reads)
+}
+
+func TestResponseCacheConfig(t *testing.T) {
+	c, err := NewClientWithConfig(ClientConfig{ResponseCache: NewMemoryCache(), ResponseCacheTTL: time.Minute})
+	assert.NoError(t, err)
+	assert.NotNil(t, c.responseCache)
+	assert.Equal(t, time.Minute, c.responseCache.ttl)
+
+	c, err = NewClientWithConfig(ClientConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, c.responseCache)
+}
@@ -0,0 +1,170 @@
+package pingdom
+
+import "time"
+
+// The responses of Pingdom hold times as Unix timestamps in seconds and
+// durations as numbers of seconds, milliseconds or minutes.  The accessors
+// below convert them, times are in UTC and are zero when Pingdom sent none.
+
+// unixTime converts a Unix timestamp, zero being no time.
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
+
+func seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
+}
+
+func milliseconds(n int) time.Duration {
+	return time.Duration(n) * time.Millisecond
+}
+
+// CreatedAt returns when the check was created.
+func (c CheckResponse) CreatedAt() time.Time {
+	return unixTime(c.Created)
+}
+
+// LastErrorAt returns when the check last failed.
+func (c CheckResponse) LastErrorAt() time.Time {
+	return unixTime(c.LastErrorTime)
+}
+
+// LastTestAt returns when the check was last tested.
+func (c CheckResponse) LastTestAt() time.Time {
+	return unixTime(c.LastTestTime)
+}
+
+// LastResponseDuration returns the response time of the last test.
+func (c CheckResponse) LastResponseDuration() time.Duration {
+	return time.Duration(c.LastResponseTime) * time.Millisecond
+}
+
+// Interval returns the time between two tests of the check, its resolution.
+func (c CheckResponse) Interval() time.Duration {
+	return time.Duration(c.Resolution) * time.Minute
+}
+
+// Start returns when the maintenance window starts.
+func (m MaintenanceResponse) Start() time.Time {
+	return unixTime(m.From)
+}
+
+// End returns when the maintenance window ends.
+func (m MaintenanceResponse) End() time.Time {
+	return unixTime(m.To)
+}
+
+// EffectiveUntil returns the last time a recurring maintenance window may
+// start.
+func (m MaintenanceResponse) EffectiveUntil() time.Time {
+	return unixTime(m.EffectiveTo)
+}
+
+// Start returns when the occurrence starts.
+func (o OccurrenceResponse) Start() time.Time {
+	return unixTime(o.From)
+}
+
+// End returns when the occurrence ends.
+func (o OccurrenceResponse) End() time.Time {
+	return unixTime(o.To)
+}
+
+// Start returns when the check entered the state.
+func (s SummaryOutageState) Start() time.Time {
+	return unixTime(s.TimeFrom)
+}
+
+// End returns when the check left the state.
+func (s SummaryOutageState) End() time.Time {
+	return unixTime(s.TimeTo)
+}
+
+// Duration returns how long the check stayed in the state.
+func (s SummaryOutageState) Duration() time.Duration {
+	return time.Duration(s.TimeTo-s.TimeFrom) * time.Second
+}
+
+// Start returns when the hour, day or week starts.
+func (s SummaryPerformanceSummary) Start() time.Time {
+	return unixTime(int64(s.StartTime))
+}
+
+// UptimeDuration returns the time the check was up.
+func (s SummaryPerformanceSummary) UptimeDuration() time.Duration {
+	return seconds(s.Uptime)
+}
+
+// DowntimeDuration returns the time the check was down.
+func (s SummaryPerformanceSummary) DowntimeDuration() time.Duration {
+	return seconds(s.Downtime)
+}
+
+// UnmonitoredDuration returns the time the check was not monitored.
+func (s SummaryPerformanceSummary) UnmonitoredDuration() time.Duration {
+	return seconds(s.Unmonitored)
+}
+
+// AvgResponseDuration returns the average response time.
+func (s SummaryPerformanceSummary) AvgResponseDuration() time.Duration {
+	return milliseconds(s.AvgResponse)
+}
+
+// Start returns the start of the time window of the average.
+func (s SummaryResponseTime) Start() time.Time {
+	return unixTime(s.From)
+}
+
+// End returns the end of the time window of the average.
+func (s SummaryResponseTime) End() time.Time {
+	return unixTime(s.To)
+}
+
+// AvgResponseDuration returns the average response time, zero when broken
+// down by country or probe.
+func (s SummaryResponseTime) AvgResponseDuration() time.Duration {
+	return milliseconds(s.AvgResponse)
+}
+
+// TotalUpDuration returns the time the check was up.
+func (s SummaryStatus) TotalUpDuration() time.Duration {
+	return seconds(s.TotalUp)
+}
+
+// TotalDownDuration returns the time the check was down.
+func (s SummaryStatus) TotalDownDuration() time.Duration {
+	return seconds(s.TotalDown)
+}
+
+// TotalUnknownDuration returns the time the status of the check was unknown.
+func (s SummaryStatus) TotalUnknownDuration() time.Duration {
+	return seconds(s.TotalUnknown)
+}
+
+// SentAt returns when the alert was sent.
+func (a AlertEntry) SentAt() time.Time {
+	return unixTime(a.Time)
+}
+
+// FirstTestAt returns when the analysis started, at the first failed test.
+func (a AnalysisResponse) FirstTestAt() time.Time {
+	return unixTime(a.TimeFirstTest)
+}
+
+// ConfirmTestAt returns when the failure was confirmed.
+func (a AnalysisResponse) ConfirmTestAt() time.Time {
+	return unixTime(a.TimeConfirmTest)
+}
+
+// TestedAt returns when the probe tested the check.
+func (r Result) TestedAt() time.Time {
+	return unixTime(int64(r.Time))
+}
+
+// Duration returns the response time of the test.
+func (r SingleResult) Duration() time.Duration {
+	return milliseconds(r.ResponseTime)
+}
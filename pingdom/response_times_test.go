@@ -0,0 +1,74 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResponseTimes(t *testing.T) {
+	var c CheckResponse
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"id": 85975,
+		"created": 1297446423,
+		"lasterrortime": 1297446423,
+		"lasttesttime": 1300977363,
+		"lastresponsetime": 355,
+		"resolution": 5
+	}`), &c))
+
+	assert.Equal(t, time.Date(2011, 2, 11, 17, 47, 3, 0, time.UTC), c.CreatedAt())
+	assert.Equal(t, c.CreatedAt(), c.LastErrorAt())
+	assert.Equal(t, time.Date(2011, 3, 24, 14, 36, 3, 0, time.UTC), c.LastTestAt())
+	assert.Equal(t, 355*time.Millisecond, c.LastResponseDuration())
+	assert.Equal(t, 5*time.Minute, c.Interval())
+	assert.Equal(t, int64(1300977363), c.LastTestTime, "raw fields are kept")
+
+	assert.True(t, CheckResponse{}.LastErrorAt().IsZero())
+}
+
+func TestMaintenanceResponseTimes(t *testing.T) {
+	m := MaintenanceResponse{From: 1000, To: 4600}
+	assert.Equal(t, time.Unix(1000, 0).UTC(), m.Start())
+	assert.Equal(t, time.Hour, m.End().Sub(m.Start()))
+	assert.True(t, m.EffectiveUntil().IsZero())
+
+	o := OccurrenceResponse{From: 1000, To: 4600}
+	assert.Equal(t, m.Start(), o.Start())
+	assert.Equal(t, m.End(), o.End())
+}
+
+func TestSummaryTimes(t *testing.T) {
+	s := SummaryOutageState{Status: "down", TimeFrom: 1000, TimeTo: 1300}
+	assert.Equal(t, time.Unix(1000, 0).UTC(), s.Start())
+	assert.Equal(t, time.Unix(1300, 0).UTC(), s.End())
+	assert.Equal(t, 5*time.Minute, s.Duration())
+
+	p := SummaryPerformanceSummary{StartTime: 3600, AvgResponse: 120, Uptime: 3000, Downtime: 540, Unmonitored: 60}
+	assert.Equal(t, time.Unix(3600, 0).UTC(), p.Start())
+	assert.Equal(t, 120*time.Millisecond, p.AvgResponseDuration())
+	assert.Equal(t, 50*time.Minute, p.UptimeDuration())
+	assert.Equal(t, 9*time.Minute, p.DowntimeDuration())
+	assert.Equal(t, time.Minute, p.UnmonitoredDuration())
+
+	r := SummaryResponseTime{From: 1000, To: 2000, AvgResponse: 250}
+	assert.Equal(t, time.Unix(1000, 0).UTC(), r.Start())
+	assert.Equal(t, time.Unix(2000, 0).UTC(), r.End())
+	assert.Equal(t, 250*time.Millisecond, r.AvgResponseDuration())
+
+	st := SummaryStatus{TotalUp: 3600, TotalDown: 60, TotalUnknown: 1}
+	assert.Equal(t, time.Hour, st.TotalUpDuration())
+	assert.Equal(t, time.Minute, st.TotalDownDuration())
+	assert.Equal(t, time.Second, st.TotalUnknownDuration())
+}
+
+func TestResultTimes(t *testing.T) {
+	assert.Equal(t, time.Unix(1000, 0).UTC(), Result{Time: 1000}.TestedAt())
+	assert.Equal(t, 42*time.Millisecond, SingleResult{ResponseTime: 42}.Duration())
+	assert.Equal(t, time.Unix(1000, 0).UTC(), AlertEntry{Time: 1000}.SentAt())
+
+	a := AnalysisResponse{TimeFirstTest: 1000, TimeConfirmTest: 1060}
+	assert.Equal(t, time.Minute, a.ConfirmTestAt().Sub(a.FirstTestAt()))
+}
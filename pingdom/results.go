@@ -0,0 +1,119 @@
+package pingdom
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ResultsService provides an interface to the raw results of Pingdom checks.
+// It replaces CheckService.Results and CheckService.ResultsWithOptions.
+type ResultsService struct {
+	client *Client
+}
+
+// ResultsRequest is the API request to Pingdom for the raw results of a check.
+type ResultsRequest struct {
+	Id int
+	// From and To bound the results in Unix time, Pingdom defaults to the
+	// last day.
+	From int64
+	To   int64
+	// Probes and Status only return the results of the given probes and
+	// with the given statuses, e.g. "down" or "unconfirmed".
+	Probes []int
+	Status []string
+	// Limit is at most 1000, Pingdom defaults to 1000.
+	Limit           int
+	Offset          int
+	IncludeAnalysis bool
+}
+
+// Valid determines whether a ResultsRequest contains valid fields for the Pingdom API.
+func (rr ResultsRequest) Valid() error {
+	if rr.Id == 0 {
+		return ErrMissingId
+	}
+
+	if rr.From != 0 && rr.To != 0 && rr.From > rr.To {
+		return ErrBadTimeRange
+	}
+
+	for _, s := range rr.Status {
+		if s != "up" && s != "down" && s != "unconfirmed" && s != "unknown" {
+			return ErrBadStatus
+		}
+	}
+
+	if rr.Limit < 0 || rr.Limit > 1000 {
+		return ErrBadLimit
+	}
+	return nil
+}
+
+// GetParams returns the query params for a Pingdom ResultsRequest.
+func (rr ResultsRequest) GetParams() (params url.Values) {
+	params = url.Values{}
+
+	if rr.From != 0 {
+		params.Set("from", strconv.FormatInt(rr.From, 10))
+	}
+
+	if rr.To != 0 {
+		params.Set("to", strconv.FormatInt(rr.To, 10))
+	}
+
+	if len(rr.Probes) > 0 {
+		params.Set("probes", intListToCDString(rr.Probes))
+	}
+
+	if len(rr.Status) > 0 {
+		params.Set("status", strings.Join(rr.Status, ","))
+	}
+
+	if rr.Limit != 0 {
+		params.Set("limit", strconv.Itoa(rr.Limit))
+	}
+
+	if rr.Offset != 0 {
+		params.Set("offset", strconv.Itoa(rr.Offset))
+	}
+
+	if rr.IncludeAnalysis {
+		params.Set("includeanalysis", "true")
+	}
+
+	return
+}
+
+// List returns the raw results of a check along with the probes which were
+// active during the requested period.
+func (rs *ResultsService) List(request ResultsRequest) (*ResultsResponse, error) {
+	return rs.ListWithContext(context.Background(), request)
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (rs *ResultsService) ListWithContext(ctx context.Context, request ResultsRequest) (*ResultsResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+	return rs.list(ctx, request.Id, request.GetParams())
+}
+
+// list reads the results of a check with the given query params.
+func (rs *ResultsService) list(ctx context.Context, id int, params url.Values) (*ResultsResponse, error) {
+	req, err := rs.client.NewRequestWithValues("GET", "/results/"+strconv.Itoa(id), params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &ResultsResponse{}
+	_, err = rs.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
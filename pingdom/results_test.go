@@ -0,0 +1,91 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultsRequestValid(t *testing.T) {
+	assert.NoError(t, ResultsRequest{Id: 1}.Valid())
+	assert.NoError(t, ResultsRequest{Id: 1, From: 10, To: 20, Status: []string{"down", "unconfirmed"}, Limit: 1000}.Valid())
+	assert.Equal(t, ErrMissingId, ResultsRequest{}.Valid())
+	assert.Equal(t, ErrBadTimeRange, ResultsRequest{Id: 1, From: 20, To: 10}.Valid())
+	assert.Equal(t, ErrBadStatus, ResultsRequest{Id: 1, Status: []string{"broken"}}.Valid())
+	assert.Equal(t, ErrBadLimit, ResultsRequest{Id: 1, Limit: 1001}.Valid())
+}
+
+func TestResultsRequestGetParams(t *testing.T) {
+	assert.Equal(t, url.Values{}, ResultsRequest{Id: 1}.GetParams())
+
+	want := url.Values{
+		"from":            {"10"},
+		"to":              {"20"},
+		"probes":          {"42,43"},
+		"status":          {"down,unconfirmed"},
+		"limit":           {"100"},
+		"offset":          {"200"},
+		"includeanalysis": {"true"},
+	}
+	params := ResultsRequest{
+		Id:              1,
+		From:            10,
+		To:              20,
+		Probes:          []int{42, 43},
+		Status:          []string{"down", "unconfirmed"},
+		Limit:           100,
+		Offset:          200,
+		IncludeAnalysis: true,
+	}.GetParams()
+	assert.Equal(t, want, params)
+}
+
+func TestResultsServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "down", r.URL.Query().Get("status"))
+		assert.Equal(t, "true", r.URL.Query().Get("includeanalysis"))
+		fmt.Fprint(w, `{
+			"activeprobes": [32, 45],
+			"results": [
+				{
+					"probeid": 32,
+					"time": 1600000060,
+					"status": "down",
+					"responsetime": 30000,
+					"statusdesc": "Timeout",
+					"statusdesclong": "Timeout (> 30s)",
+					"analysisid": 7
+				}
+			]
+		}`)
+	})
+
+	want := &ResultsResponse{
+		ActiveProbes: []int{32, 45},
+		Results: []Result{
+			{
+				ProbeID:        32,
+				Time:           1600000060,
+				Status:         "down",
+				ResponseTime:   30000,
+				StatusDesc:     "Timeout",
+				StatusDescLong: "Timeout (> 30s)",
+				AnalysisID:     7,
+			},
+		},
+	}
+
+	results, err := client.Results.List(ResultsRequest{Id: 12345, Status: []string{"down"}, IncludeAnalysis: true})
+	assert.NoError(t, err)
+	assert.Equal(t, want, results)
+
+	_, err = client.Results.List(ResultsRequest{})
+	assert.Equal(t, ErrMissingId, err)
+}
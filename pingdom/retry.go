@@ -0,0 +1,108 @@
+package pingdom
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how throttled requests are retried.  Requests
+// answered with 429 Too Many Requests, or 503 Service Unavailable with a
+// Retry-After header, are retried after waiting as long as the response asks
+// or, when it doesn't say, with exponential backoff.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// MinBackoff is the first backoff delay, doubled on every retry.
+	MinBackoff time.Duration
+	// MaxBackoff is the longest the client waits before a retry.  Responses
+	// asking to wait longer, e.g. once the monthly limit is exhausted, are
+	// returned without retrying.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is used unless ClientConfig sets a policy or disables
+// retries.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: time.Second,
+	MaxBackoff: time.Minute,
+}
+
+// retryable reports whether the response asks for the request to be retried.
+func retryable(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return resp.Header.Get("Retry-After") != ""
+	}
+	return false
+}
+
+// wait returns how long to wait before the given retry, starting at 0, of a
+// request which got resp.  The second return value is false when the wait
+// would exceed MaxBackoff.
+func (p *RetryPolicy) wait(retry int, resp *http.Response, now time.Time) (time.Duration, bool) {
+	var d time.Duration
+	if after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+		d = after
+	} else if rl, ok := parseRateLimit(resp.Header); ok && rl.LongRemaining <= 0 && rl.LongReset > 0 {
+		d = rl.LongReset
+	} else if ok && rl.ShortRemaining <= 0 && rl.ShortReset > 0 {
+		d = rl.ShortReset
+	} else {
+		d = p.MinBackoff << uint(retry)
+		if p.MaxBackoff > 0 && (d > p.MaxBackoff || d <= 0) {
+			d = p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return 0, false
+	}
+	return d, true
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// rewindBody prepares the body of a request to be sent again.  It returns
+// false when the body can't be replayed.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+	return true
+}
+
+// discardBody drains and closes a response which won't be returned, so the
+// connection can be reused.
+func discardBody(resp *http.Response) {
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,122 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyWait(t *testing.T) {
+	p := &RetryPolicy{MaxRetries: 3, MinBackoff: time.Second, MaxBackoff: time.Minute}
+	now := time.Unix(1600000000, 0)
+
+	resp := &http.Response{Header: http.Header{}}
+	d, ok := p.wait(0, resp, now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, d)
+	d, _ = p.wait(2, resp, now)
+	assert.Equal(t, 4*time.Second, d)
+	d, _ = p.wait(10, resp, now)
+	assert.Equal(t, time.Minute, d)
+
+	resp.Header.Set("Req-Limit-Short", "Remaining: 0 Time until reset: 12")
+	d, _ = p.wait(0, resp, now)
+	assert.Equal(t, 12*time.Second, d)
+
+	resp.Header.Set("Retry-After", "7")
+	d, _ = p.wait(0, resp, now)
+	assert.Equal(t, 7*time.Second, d)
+
+	resp.Header.Set("Retry-After", now.Add(30*time.Second).UTC().Format(http.TimeFormat))
+	d, _ = p.wait(0, resp, now)
+	assert.Equal(t, 30*time.Second, d)
+
+	resp.Header.Set("Retry-After", "3600")
+	_, ok = p.wait(0, resp, now)
+	assert.False(t, ok)
+
+	resp = &http.Response{Header: http.Header{}}
+	resp.Header.Set("Req-Limit-Long", "Remaining: 0 Time until reset: 86400")
+	_, ok = p.wait(0, resp, now)
+	assert.False(t, ok)
+}
+
+func TestDoRetry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var waits []time.Duration
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	attempts := 0
+	mux.HandleFunc("/alerting/teams/1", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name": "Ops", "member_ids": [2]}`, string(body))
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"message": "ok"}`)
+		}
+	})
+
+	msg, err := client.Teams.Update(1, &Team{Name: "Ops", MemberIDs: []int{2}})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", msg.Message)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, waits)
+}
+
+func TestDoRetryExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retry = &RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond}
+	client.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	attempts := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error": {"statuscode": 429, "statusdesc": "Too Many Requests", "errormessage": "Slow down"}}`)
+	})
+
+	_, err := client.Checks.List()
+	assert.Error(t, err)
+	assert.Equal(t, 429, err.(*PingdomError).StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDoRetryDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retry = nil
+
+	attempts := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := client.Checks.List()
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	c, err := NewClientWithConfig(ClientConfig{DisableRetry: true})
+	assert.NoError(t, err)
+	assert.Nil(t, c.retry)
+	c, err = NewClientWithConfig(ClientConfig{Retry: &RetryPolicy{MaxRetries: 5}})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, c.retry.MaxRetries)
+}
@@ -0,0 +1,123 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// SingleService runs one-off checks from the Pingdom probes, without creating
+// a persistent check.
+type SingleService struct {
+	client *Client
+}
+
+// SingleCheck is the API request to Pingdom for a one-off check.
+type SingleCheck struct {
+	// Type is one of "http", "tcp", "ping" or "dns".
+	Type     string
+	Hostname string
+	// ProbeFilters limits the probe the check runs from, e.g. "region: EU".
+	ProbeFilters string
+	IPv6         bool
+
+	// HTTP only.
+	URL           string
+	Encryption    bool
+	ShouldContain string
+
+	// TCP only, Port is also used by HTTP checks.
+	Port int
+
+	// DNS only.
+	ExpectedIP string
+	NameServer string
+}
+
+// Valid determines whether the SingleCheck contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (sc SingleCheck) Valid() error {
+	if sc.Hostname == "" {
+		return fmt.Errorf("Invalid value for `Hostname`.  Must contain non-empty string")
+	}
+
+	switch sc.Type {
+	case CheckKindHTTP, CheckKindPing:
+	case CheckKindTCP:
+		if sc.Port < 1 {
+			return fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1")
+		}
+	case "dns":
+		if sc.ExpectedIP == "" || sc.NameServer == "" {
+			return fmt.Errorf("Invalid value for `ExpectedIP` and `NameServer`.  Must contain non-empty strings")
+		}
+	default:
+		return fmt.Errorf("Invalid value for `Type`.  Must be either 'http', 'tcp', 'ping' or 'dns'")
+	}
+	return nil
+}
+
+// PostParams returns a map of parameters for a SingleCheck that can be sent
+// along with an HTTP POST request.
+func (sc SingleCheck) PostParams() map[string]string {
+	params := map[string]string{
+		"type": sc.Type,
+		"host": sc.Hostname,
+	}
+
+	if sc.ProbeFilters != "" {
+		params["probe_filters"] = sc.ProbeFilters
+	}
+
+	if sc.IPv6 {
+		params["ipv6"] = "true"
+	}
+
+	if sc.Port != 0 {
+		params["port"] = strconv.Itoa(sc.Port)
+	}
+
+	switch sc.Type {
+	case CheckKindHTTP:
+		if sc.URL != "" {
+			params["url"] = sc.URL
+		}
+		if sc.Encryption {
+			params["encryption"] = "true"
+		}
+		if sc.ShouldContain != "" {
+			params["shouldcontain"] = sc.ShouldContain
+		}
+	case "dns":
+		params["expectedip"] = sc.ExpectedIP
+		params["nameserver"] = sc.NameServer
+	}
+	return params
+}
+
+// Run runs the check once and returns its result.  A check which ran but
+// failed is not an error, it is reported by the status of the result.
+func (ss *SingleService) Run(check SingleCheck) (*SingleResult, error) {
+	return ss.RunWithContext(context.Background(), check)
+}
+
+// RunWithContext is like Run, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ss *SingleService) RunWithContext(ctx context.Context, check SingleCheck) (*SingleResult, error) {
+	if err := check.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := ss.client.NewRequest("POST", "/single", check.PostParams())
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &singleJSONResponse{}
+	_, err = ss.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return &m.Result, nil
+}
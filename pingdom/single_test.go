@@ -0,0 +1,90 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleCheckValid(t *testing.T) {
+	assert.NoError(t, SingleCheck{Type: "http", Hostname: "example.com"}.Valid())
+	assert.NoError(t, SingleCheck{Type: "tcp", Hostname: "example.com", Port: 443}.Valid())
+	assert.NoError(t, SingleCheck{Type: "dns", Hostname: "example.com", ExpectedIP: "192.0.2.1", NameServer: "ns1.example.com"}.Valid())
+
+	assert.Error(t, SingleCheck{Type: "http"}.Valid())
+	assert.Error(t, SingleCheck{Type: "smtp", Hostname: "example.com"}.Valid())
+	assert.Error(t, SingleCheck{Type: "tcp", Hostname: "example.com"}.Valid())
+	assert.Error(t, SingleCheck{Type: "dns", Hostname: "example.com", ExpectedIP: "192.0.2.1"}.Valid())
+}
+
+func TestSingleCheckPostParams(t *testing.T) {
+	want := map[string]string{
+		"type":          "http",
+		"host":          "example.com",
+		"probe_filters": "region: EU",
+		"url":           "/health",
+		"encryption":    "true",
+		"shouldcontain": "ok",
+	}
+	params := SingleCheck{
+		Type:          "http",
+		Hostname:      "example.com",
+		ProbeFilters:  "region: EU",
+		URL:           "/health",
+		Encryption:    true,
+		ShouldContain: "ok",
+		ExpectedIP:    "192.0.2.1",
+	}.PostParams()
+	assert.Equal(t, want, params)
+
+	want = map[string]string{
+		"type":       "dns",
+		"host":       "example.com",
+		"expectedip": "192.0.2.1",
+		"nameserver": "ns1.example.com",
+	}
+	params = SingleCheck{
+		Type:       "dns",
+		Hostname:   "example.com",
+		URL:        "/ignored",
+		ExpectedIP: "192.0.2.1",
+		NameServer: "ns1.example.com",
+	}.PostParams()
+	assert.Equal(t, want, params)
+}
+
+func TestSingleServiceRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/single", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		assert.Equal(t, "example.com", r.FormValue("host"))
+		assert.Equal(t, "region: EU", r.FormValue("probe_filters"))
+		fmt.Fprint(w, `{
+			"result": {
+				"status": "up",
+				"responsetime": 215,
+				"statusdesc": "OK",
+				"statusdesclong": "OK",
+				"probeid": 33,
+				"probedesc": "Amsterdam 2, Netherlands"
+			}
+		}`)
+	})
+
+	want := &SingleResult{
+		Status:         "up",
+		ResponseTime:   215,
+		StatusDesc:     "OK",
+		StatusDescLong: "OK",
+		ProbeID:        33,
+		ProbeDesc:      "Amsterdam 2, Netherlands",
+	}
+
+	result, err := client.Single.Run(SingleCheck{Type: "http", Hostname: "example.com", ProbeFilters: "region: EU"})
+	assert.NoError(t, err)
+	assert.Equal(t, want, result)
+}
@@ -0,0 +1,95 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SLAReport is the availability of a check over a period, as computed by
+// CheckService.CalculateSLA.
+type SLAReport struct {
+	CheckID int
+	From    time.Time
+	To      time.Time
+	// Availability is the percentage of monitored time the check was up,
+	// 100 when it was not monitored at all.
+	Availability float64
+	// Maintenance is the time left out for maintenance windows, zero when
+	// the report is not maintenance aware.
+	Maintenance time.Duration
+	// Unmonitored is the time of the period the check was neither up nor
+	// down outside of maintenance, e.g. while paused.
+	Unmonitored time.Duration
+
+	FailureMetrics
+}
+
+// Meets returns whether the availability reached the target of the SLO.
+func (r *SLAReport) Meets(slo SLO) bool {
+	return r.Availability >= slo.Target
+}
+
+// String renders the report as e.g. "check 12345: 99.95% uptime, 2 outages
+// totalling 21m 40s, MTTR 10m 50s, MTBF 14d 3h".
+func (r *SLAReport) String() string {
+	s := fmt.Sprintf("check %d: %s", r.CheckID, r.FailureMetrics.String())
+	if r.Outages > 0 {
+		s += fmt.Sprintf(", MTTR %s, MTBF %s", HumanizeDuration(r.MTTR), HumanizeDuration(r.MTBF))
+	}
+	return s
+}
+
+// CalculateSLA computes the availability, MTTR and MTBF of an uptime check
+// between from and to from its outage history.  With maintenanceAware set,
+// the maintenance windows covering the check are left out of the
+// computation, see FailureMetrics.
+func (cs *CheckService) CalculateSLA(id int, from, to time.Time, maintenanceAware bool) (*SLAReport, error) {
+	return cs.CalculateSLAWithContext(context.Background(), id, from, to, maintenanceAware)
+}
+
+// CalculateSLAWithContext is like CalculateSLA, the requests are bound to ctx
+// so they can be canceled or given a deadline.
+func (cs *CheckService) CalculateSLAWithContext(ctx context.Context, id int, from, to time.Time, maintenanceAware bool) (*SLAReport, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("Invalid value for `From`.  Must be before `To`")
+	}
+
+	summary, err := cs.SummaryOutageWithContext(ctx, SummaryOutageRequest{
+		Id:   id,
+		From: from.Unix(),
+		To:   to.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var excluded []TimeWindow
+	if maintenanceAware {
+		excluded, err = cs.client.Maintenances.checkWindows(ctx, id, from.Unix(), to.Unix())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return computeSLAReport(id, summary.Summary.States, from, to, excluded), nil
+}
+
+// computeSLAReport builds the report of a check from the states of a
+// summary outage.
+func computeSLAReport(id int, states []SummaryOutageState, from, to time.Time, excluded []TimeWindow) *SLAReport {
+	window := TimeWindow{from.Unix(), to.Unix()}
+	m := ComputeFailureMetrics(states, window.From, window.To, excluded)
+	remaining := remainingSeconds(window, mergeIntervals(excluded))
+
+	r := &SLAReport{
+		CheckID:        id,
+		From:           from,
+		To:             to,
+		Availability:   m.Availability(),
+		Maintenance:    time.Duration(window.To-window.From-remaining) * time.Second,
+		FailureMetrics: m,
+	}
+	r.Unmonitored = time.Duration(remaining)*time.Second - m.Uptime - m.Downtime
+	return r
+}
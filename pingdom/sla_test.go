@@ -0,0 +1,100 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeSLAReport(t *testing.T) {
+	states := []SummaryOutageState{
+		{Status: "up", TimeFrom: 0, TimeTo: 3600},
+		{Status: "down", TimeFrom: 3600, TimeTo: 3900},
+		{Status: "up", TimeFrom: 3900, TimeTo: 7200},
+		{Status: "unknown", TimeFrom: 7200, TimeTo: 7300},
+		{Status: "down", TimeFrom: 7300, TimeTo: 7400},
+		{Status: "up", TimeFrom: 7400, TimeTo: 10000},
+	}
+
+	r := computeSLAReport(1, states, time.Unix(0, 0), time.Unix(10000, 0), nil)
+	assert.Equal(t, 2, r.Outages)
+	assert.InDelta(t, 95.96, r.Availability, 0.01)
+	assert.Equal(t, time.Duration(0), r.Maintenance)
+	assert.Equal(t, 100*time.Second, r.Unmonitored)
+
+	r = computeSLAReport(1, states, time.Unix(0, 0), time.Unix(10000, 0), []TimeWindow{{7200, 7500}})
+	assert.Equal(t, 1, r.Outages)
+	assert.InDelta(t, 96.91, r.Availability, 0.01)
+	assert.Equal(t, 300*time.Second, r.Maintenance)
+	assert.Equal(t, time.Duration(0), r.Unmonitored)
+	assert.True(t, r.Meets(SLO{Target: 95, Window: time.Hour}))
+	assert.False(t, r.Meets(SLO{Target: 99, Window: time.Hour}))
+	assert.Equal(t, "check 1: 96.91% uptime, 1 outage lasting 5m 0s, MTTR 5m 0s, MTBF 2h 36m", r.String())
+}
+
+func TestCheckServiceCalculateSLA(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "9000", r.URL.Query().Get("to"))
+		fmt.Fprint(w, `{"summary": {"states": [
+			{"status": "up", "timefrom": 0, "timeto": 3600},
+			{"status": "down", "timefrom": 3600, "timeto": 3900},
+			{"status": "up", "timefrom": 3900, "timeto": 7200},
+			{"status": "down", "timefrom": 7200, "timeto": 7500},
+			{"status": "up", "timefrom": 7500, "timeto": 9000}
+		]}}`)
+	})
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"maintenance": [
+			{"id": 1, "from": 7200, "to": 7500, "recurrencetype": "none", "checks": {"uptime": [12345]}}
+		]}`)
+	})
+
+	r, err := client.Checks.CalculateSLA(12345, time.Unix(0, 0), time.Unix(9000, 0), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 12345, r.CheckID)
+	assert.Equal(t, 2, r.Outages)
+	assert.InDelta(t, 93.33, r.Availability, 0.01)
+
+	r, err = client.Checks.CalculateSLA(12345, time.Unix(0, 0), time.Unix(9000, 0), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, r.Outages)
+	assert.Equal(t, 5*time.Minute, r.Maintenance)
+	assert.Equal(t, 5*time.Minute, r.MTTR)
+
+	_, err = client.Checks.CalculateSLA(12345, time.Unix(9000, 0), time.Unix(0, 0), false)
+	assert.Error(t, err)
+}
+
+func TestCheckServiceCalculateSLAWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"summary": {"states": [{"status": "up", "timefrom": 0, "timeto": 9000}]}}`)
+	})
+	var maintenance []string
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		maintenance = append(maintenance, r.URL.Query().Get("offset"))
+		fmt.Fprint(w, `{"maintenance": []}`)
+	})
+
+	r, err := client.Checks.CalculateSLAWithContext(context.Background(), 12345, time.Unix(0, 0), time.Unix(9000, 0), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, r.Availability)
+	assert.Equal(t, []string{"0"}, maintenance, "all the pages of windows are listed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Checks.CalculateSLAWithContext(ctx, 12345, time.Unix(0, 0), time.Unix(9000, 0), true)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), context.Canceled.Error())
+	}
+}
@@ -0,0 +1,229 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SLO is an availability objective, e.g. 99.9% over 30 days.
+type SLO struct {
+	// Target is the availability objective in percent.
+	Target float64
+	// Window is the period the objective applies to.
+	Window time.Duration
+}
+
+// Valid determines whether the SLO contains valid fields.
+func (s SLO) Valid() error {
+	if s.Target <= 0 || s.Target >= 100 {
+		return fmt.Errorf("Invalid value for `Target`.  Must be between 0 and 100 exclusive")
+	}
+	if s.Window <= 0 {
+		return fmt.Errorf("Invalid value for `Window`.  Must be positive")
+	}
+	return nil
+}
+
+// ErrorBudget returns the downtime the SLO allows over its window.
+func (s SLO) ErrorBudget() time.Duration {
+	return time.Duration(float64(s.Window) * (1 - s.Target/100))
+}
+
+// BurnRate returns how fast the error budget was consumed between from and
+// to: 1 means the budget would be exactly exhausted at the end of the
+// window, 10 that it is consumed ten times as fast.  Unmonitored time is not
+// counted.
+func (s SLO) BurnRate(states []SummaryOutageState, from, to int64) float64 {
+	m := ComputeFailureMetrics(states, from, to, nil)
+	total := m.Uptime + m.Downtime
+	if total == 0 {
+		return 0
+	}
+	errorRate := float64(m.Downtime) / float64(total)
+	return errorRate / (1 - s.Target/100)
+}
+
+// BurnRateRule alerts when the burn rate exceeds Threshold over both the long
+// and the short window.  The short window makes the alert reset soon after
+// the problem is fixed.  Name identifies the rule within an alerter.
+type BurnRateRule struct {
+	Name        string
+	LongWindow  time.Duration
+	ShortWindow time.Duration
+	Threshold   float64
+}
+
+// Valid determines whether the rule contains valid fields.
+func (r BurnRateRule) Valid() error {
+	if r.Name == "" {
+		return fmt.Errorf("Invalid value for `Name`.  Must not be empty")
+	}
+	if r.ShortWindow <= 0 || r.LongWindow < r.ShortWindow {
+		return fmt.Errorf("Invalid value for `LongWindow` and `ShortWindow` of rule %q.  Must be positive, the long window at least as long as the short one", r.Name)
+	}
+	if r.Threshold <= 0 {
+		return fmt.Errorf("Invalid value for `Threshold` of rule %q.  Must be positive", r.Name)
+	}
+	return nil
+}
+
+// DefaultBurnRateRules are the multi-window rules recommended for a 30 day
+// SLO: paging when 2% or 5% of the budget is spent in an hour or six hours,
+// and a ticket when 10% is spent in three days.
+var DefaultBurnRateRules = []BurnRateRule{
+	{Name: "page-fast", LongWindow: time.Hour, ShortWindow: 5 * time.Minute, Threshold: 14.4},
+	{Name: "page-slow", LongWindow: 6 * time.Hour, ShortWindow: 30 * time.Minute, Threshold: 6},
+	{Name: "ticket", LongWindow: 3 * 24 * time.Hour, ShortWindow: 6 * time.Hour, Threshold: 1},
+}
+
+// BurnRateEvent reports that a rule started or stopped firing.
+type BurnRateEvent struct {
+	Rule          BurnRateRule
+	Firing        bool
+	LongBurnRate  float64
+	ShortBurnRate float64
+	Time          time.Time
+	// Err is set when a poll of BurnRateAlerter.Watch failed, the rule is
+	// then empty.
+	Err error
+}
+
+// BurnRateAlerter evaluates burn rate rules against the status history of a
+// check and reports the rules crossing their threshold.  It remembers which
+// rules fire between evaluations, so it must be reused across them.
+type BurnRateAlerter struct {
+	SLO   SLO
+	Rules []BurnRateRule
+
+	firing map[string]bool
+}
+
+// NewBurnRateAlerter returns an alerter for the given SLO.  DefaultBurnRateRules
+// are used when no rules are given.  The rules must have distinct names.
+func NewBurnRateAlerter(slo SLO, rules ...BurnRateRule) (*BurnRateAlerter, error) {
+	if err := slo.Valid(); err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		rules = DefaultBurnRateRules
+	}
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if err := rule.Valid(); err != nil {
+			return nil, err
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("Invalid value for `Name`.  Rule %q is given more than once", rule.Name)
+		}
+		names[rule.Name] = true
+	}
+	return &BurnRateAlerter{SLO: slo, Rules: rules, firing: map[string]bool{}}, nil
+}
+
+// Evaluate computes the burn rates of every rule at now from the given
+// states and returns an event for each rule which started or stopped firing
+// since the previous evaluation.
+func (a *BurnRateAlerter) Evaluate(states []SummaryOutageState, now time.Time) []BurnRateEvent {
+	if a.firing == nil {
+		a.firing = map[string]bool{}
+	}
+	var events []BurnRateEvent
+	for _, rule := range a.Rules {
+		long := a.SLO.BurnRate(states, now.Add(-rule.LongWindow).Unix(), now.Unix())
+		short := a.SLO.BurnRate(states, now.Add(-rule.ShortWindow).Unix(), now.Unix())
+		firing := long >= rule.Threshold && short >= rule.Threshold
+
+		if firing != a.firing[rule.Name] {
+			a.firing[rule.Name] = firing
+			events = append(events, BurnRateEvent{
+				Rule:          rule,
+				Firing:        firing,
+				LongBurnRate:  long,
+				ShortBurnRate: short,
+				Time:          now,
+			})
+		}
+	}
+	return events
+}
+
+// Poll fetches the status history of a check covering the longest rule
+// window and evaluates the rules at now.
+func (a *BurnRateAlerter) Poll(cs *CheckService, checkID int, now time.Time) ([]BurnRateEvent, error) {
+	return a.PollWithContext(context.Background(), cs, checkID, now)
+}
+
+// PollWithContext is like Poll, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (a *BurnRateAlerter) PollWithContext(ctx context.Context, cs *CheckService, checkID int, now time.Time) ([]BurnRateEvent, error) {
+	var longest time.Duration
+	for _, rule := range a.Rules {
+		if rule.LongWindow > longest {
+			longest = rule.LongWindow
+		}
+	}
+
+	summary, err := cs.SummaryOutageWithContext(ctx, SummaryOutageRequest{
+		Id:   checkID,
+		From: now.Add(-longest).Unix(),
+		To:   now.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.Evaluate(summary.Summary.States, now), nil
+}
+
+// Watch polls the status history of a check every interval, like
+// CheckService.Watch polls the checks, and sends an event each time a rule
+// starts or stops firing.  Polls failing are reported as events with Err
+// set, watching goes on.  An interval which isn't positive is reported as a
+// single event with Err set, then the channel is closed.
+//
+// The returned channel is closed once ctx is done.  Events are not dropped,
+// a slow receiver delays the next poll.  The alerter must not be evaluated
+// elsewhere while watching.
+func (a *BurnRateAlerter) Watch(ctx context.Context, cs *CheckService, checkID int, interval time.Duration) <-chan BurnRateEvent {
+	events := make(chan BurnRateEvent)
+	go func() {
+		defer close(events)
+
+		if interval <= 0 {
+			err := fmt.Errorf("Invalid value for `interval`.  Must be a positive duration")
+			select {
+			case events <- BurnRateEvent{Time: time.Now(), Err: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			now := time.Now()
+			batch, err := a.PollWithContext(ctx, cs, checkID, now)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				batch = []BurnRateEvent{{Time: now, Err: err}}
+			}
+
+			for _, e := range batch {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
@@ -0,0 +1,143 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLO(t *testing.T) {
+	slo := SLO{Target: 99.9, Window: 30 * 24 * time.Hour}
+	assert.NoError(t, slo.Valid())
+	assert.Equal(t, 43*time.Minute+12*time.Second, slo.ErrorBudget().Round(time.Second))
+
+	assert.Error(t, SLO{Target: 100, Window: time.Hour}.Valid())
+	assert.Error(t, SLO{Target: 99}.Valid())
+
+	// 6 minutes down in an hour: an error rate of 10%, 100 times the budget.
+	states := []SummaryOutageState{
+		{Status: "up", TimeFrom: 0, TimeTo: 3240},
+		{Status: "down", TimeFrom: 3240, TimeTo: 3600},
+	}
+	assert.InDelta(t, 100, slo.BurnRate(states, 0, 3600), 0.001)
+	assert.Equal(t, 0.0, slo.BurnRate(nil, 0, 3600))
+}
+
+func TestBurnRateAlerter(t *testing.T) {
+	slo := SLO{Target: 99.9, Window: 30 * 24 * time.Hour}
+	_, err := NewBurnRateAlerter(SLO{})
+	assert.Error(t, err)
+
+	_, err = NewBurnRateAlerter(slo, BurnRateRule{LongWindow: time.Hour, ShortWindow: time.Minute, Threshold: 1})
+	assert.Error(t, err, "rules must be named")
+	rule := BurnRateRule{Name: "page", LongWindow: time.Hour, ShortWindow: time.Minute, Threshold: 1}
+	_, err = NewBurnRateAlerter(slo, rule, rule)
+	assert.EqualError(t, err, "Invalid value for `Name`.  Rule \"page\" is given more than once")
+
+	a, err := NewBurnRateAlerter(slo)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultBurnRateRules, a.Rules)
+
+	start := int64(1600000000)
+	states := []SummaryOutageState{
+		{Status: "up", TimeFrom: start - 7*24*3600, TimeTo: start},
+		{Status: "down", TimeFrom: start, TimeTo: start + 600},
+		{Status: "up", TimeFrom: start + 600, TimeTo: start + 7*24*3600},
+	}
+
+	// Ten minutes down exceeds every threshold.
+	events := a.Evaluate(states, time.Unix(start+600, 0))
+	assert.Len(t, events, 3)
+	assert.Equal(t, "page-fast", events[0].Rule.Name)
+	assert.True(t, events[0].Firing)
+	assert.Equal(t, "page-slow", events[1].Rule.Name)
+	assert.Equal(t, "ticket", events[2].Rule.Name)
+
+	// No crossing while the state doesn't change.
+	assert.Empty(t, a.Evaluate(states, time.Unix(start+600, 0)))
+
+	// Once recovered, the short windows reset the paging alerts while the
+	// ticket keeps firing.
+	events = a.Evaluate(states, time.Unix(start+2400, 0))
+	assert.Len(t, events, 2)
+	assert.Equal(t, "page-fast", events[0].Rule.Name)
+	assert.False(t, events[0].Firing)
+	assert.Equal(t, "page-slow", events[1].Rule.Name)
+	assert.False(t, events[1].Firing)
+}
+
+func TestBurnRateAlerterZeroValue(t *testing.T) {
+	a := &BurnRateAlerter{SLO: SLO{Target: 99.9, Window: 30 * 24 * time.Hour}, Rules: DefaultBurnRateRules}
+	states := []SummaryOutageState{{Status: "down", TimeFrom: 1000, TimeTo: 10000}}
+	assert.Len(t, a.Evaluate(states, time.Unix(10000, 0)), 3)
+}
+
+func TestBurnRateAlerterPoll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Unix(1600000600, 0)
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1599997000", r.URL.Query().Get("from"))
+		assert.Equal(t, "1600000600", r.URL.Query().Get("to"))
+		fmt.Fprint(w, `{"summary": {"states": [
+			{"status": "up", "timefrom": 1599997000, "timeto": 1600000000},
+			{"status": "down", "timefrom": 1600000000, "timeto": 1600000600}
+		]}}`)
+	})
+
+	a, _ := NewBurnRateAlerter(SLO{Target: 99.9, Window: 30 * 24 * time.Hour}, DefaultBurnRateRules[0])
+	events, err := a.Poll(client.Checks, 12345, now)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.True(t, events[0].Firing)
+	assert.InDelta(t, 1000, events[0].ShortBurnRate, 0.001)
+}
+
+func TestBurnRateAlerterWatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error": {"statuscode": 500, "statusdesc": "Internal Server Error", "errormessage": "oops"}}`)
+			return
+		}
+		to, _ := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+		fmt.Fprintf(w, `{"summary": {"states": [
+			{"status": "up", "timefrom": %d, "timeto": %d},
+			{"status": "down", "timefrom": %d, "timeto": %d}
+		]}}`, to-3600, to-600, to-600, to)
+	})
+
+	a, _ := NewBurnRateAlerter(SLO{Target: 99.9, Window: 30 * 24 * time.Hour}, DefaultBurnRateRules[0])
+	ctx, cancel := context.WithCancel(context.Background())
+	events := a.Watch(ctx, client.Checks, 12345, time.Millisecond)
+
+	e := <-events
+	assert.NoError(t, e.Err)
+	assert.Equal(t, "page-fast", e.Rule.Name)
+	assert.True(t, e.Firing)
+	e = <-events
+	assert.True(t, IsServerError(e.Err))
+
+	cancel()
+	for range events {
+	}
+
+	e = <-a.Watch(context.Background(), client.Checks, 12345, 0)
+	assert.EqualError(t, e.Err, "Invalid value for `interval`.  Must be a positive duration")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err := a.PollWithContext(ctx, client.Checks, 12345, time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package pingdom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// stream sends the request and calls fn with the decoder positioned on each
+// element of the array found at path, a list of object keys, so that long
+// reports are decoded one entry at a time instead of being held in memory.
+// A missing or null array has no elements.  The response cache is bypassed.
+// Streaming stops at the first error, either from the response or from fn,
+// which is returned.
+func (pc *Client) stream(req *http.Request, path []string, fn func(*json.Decoder) error) error {
+	resp, err := pc.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := validateResponse(resp); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	if pc.strictDecoding {
+		dec.DisallowUnknownFields()
+	}
+	found, err := seekArray(dec, path)
+	if err != nil || !found {
+		return err
+	}
+	for dec.More() {
+		if err := fn(dec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seekArray advances dec past the opening bracket of the array at path.
+// It returns false when the array is missing or null.
+func seekArray(dec *json.Decoder, path []string) (bool, error) {
+	for _, key := range path {
+		t, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+		if t == nil {
+			return false, nil
+		}
+		if d, ok := t.(json.Delim); !ok || d != '{' {
+			return false, fmt.Errorf("response has %v instead of an object holding %q", t, key)
+		}
+		for {
+			if !dec.More() {
+				return false, nil
+			}
+			t, err := dec.Token()
+			if err != nil {
+				return false, err
+			}
+			if t == key {
+				break
+			}
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	t, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+	if t == nil {
+		return false, nil
+	}
+	if d, ok := t.(json.Delim); !ok || d != '[' {
+		return false, fmt.Errorf("response has %v instead of an array", t)
+	}
+	return true, nil
+}
+
+// EachOutageState calls fn with each state of the check over the time
+// window, like Outage but decoding the states as they are read.  It stops at
+// the first error, either from Pingdom or from fn, which is returned.
+func (ss *SummaryService) EachOutageState(request SummaryOutageRequest, fn func(SummaryOutageState) error) error {
+	return ss.EachOutageStateWithContext(context.Background(), request, fn)
+}
+
+// EachOutageStateWithContext is like EachOutageState, the request is bound to
+// ctx so it can be canceled or given a deadline.
+func (ss *SummaryService) EachOutageStateWithContext(ctx context.Context, request SummaryOutageRequest, fn func(SummaryOutageState) error) error {
+	if err := request.Valid(); err != nil {
+		return err
+	}
+	return ss.stream(ctx, "/summary.outage/", request.Id, request.GetParams(), []string{"summary", "states"}, func(dec *json.Decoder) error {
+		var state SummaryOutageState
+		if err := dec.Decode(&state); err != nil {
+			return err
+		}
+		return fn(state)
+	})
+}
+
+// EachPerformance calls fn with each hour, day or week of the performance of
+// the check, like Performance but decoding them as they are read.  It stops
+// at the first error, either from Pingdom or from fn, which is returned.
+func (ss *SummaryService) EachPerformance(request SummaryPerformanceRequest, fn func(SummaryPerformanceSummary) error) error {
+	return ss.EachPerformanceWithContext(context.Background(), request, fn)
+}
+
+// EachPerformanceWithContext is like EachPerformance, the request is bound to
+// ctx so it can be canceled or given a deadline.
+func (ss *SummaryService) EachPerformanceWithContext(ctx context.Context, request SummaryPerformanceRequest, fn func(SummaryPerformanceSummary) error) error {
+	if err := request.Valid(); err != nil {
+		return err
+	}
+	key := "hours"
+	switch request.Resolution {
+	case "day":
+		key = "days"
+	case "week":
+		key = "weeks"
+	}
+	return ss.stream(ctx, "/summary.performance/", request.Id, request.GetParams(), []string{"summary", key}, func(dec *json.Decoder) error {
+		var summary SummaryPerformanceSummary
+		if err := dec.Decode(&summary); err != nil {
+			return err
+		}
+		return fn(summary)
+	})
+}
+
+// stream streams the array at path of the summary of a check.
+func (ss *SummaryService) stream(ctx context.Context, endpoint string, id int, params url.Values, path []string, fn func(*json.Decoder) error) error {
+	req, err := ss.client.NewRequestWithValues("GET", endpoint+strconv.Itoa(id), params)
+	if err != nil {
+		return err
+	}
+	return ss.client.stream(req.WithContext(ctx), path, fn)
+}
+
+// Each calls fn with each result matching the request, walking the pages
+// like Pages but decoding the results as they are read rather than holding a
+// page in memory.  It stops at the first error, either from Pingdom or from
+// fn, which is returned.
+func (rs *ResultsService) Each(request ResultsRequest, fn func(Result) error) error {
+	return rs.EachWithContext(context.Background(), request, fn)
+}
+
+// EachWithContext is like Each, the requests are bound to ctx so they can be
+// canceled or given a deadline.
+func (rs *ResultsService) EachWithContext(ctx context.Context, request ResultsRequest, fn func(Result) error) error {
+	if request.Limit == 0 {
+		request.Limit = resultsPageSize
+	}
+	return paginate(request.Limit, request.Offset, func(limit, offset int) (int, error) {
+		request.Limit, request.Offset = limit, offset
+		if err := request.Valid(); err != nil {
+			return 0, err
+		}
+		req, err := rs.client.NewRequestWithValues("GET", "/results/"+strconv.Itoa(request.Id), request.GetParams())
+		if err != nil {
+			return 0, err
+		}
+
+		n := 0
+		err = rs.client.stream(req.WithContext(ctx), []string{"results"}, func(dec *json.Decoder) error {
+			var result Result
+			if err := dec.Decode(&result); err != nil {
+				return err
+			}
+			n++
+			return fn(result)
+		})
+		return n, err
+	})
+}
@@ -0,0 +1,124 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryServiceEachOutageState(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"extra": {"nested": [1, 2, {"a": "b"}]},
+			"summary": {
+				"other": "x",
+				"states": [
+					{"status": "up", "timefrom": 1, "timeto": 10},
+					{"status": "down", "timefrom": 10, "timeto": 20},
+					{"status": "up", "timefrom": 20, "timeto": 30}
+				]
+			}
+		}`)
+	})
+
+	var states []SummaryOutageState
+	err := client.Summary.EachOutageState(SummaryOutageRequest{Id: 12345}, func(s SummaryOutageState) error {
+		states = append(states, s)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []SummaryOutageState{
+		{Status: "up", TimeFrom: 1, TimeTo: 10},
+		{Status: "down", TimeFrom: 10, TimeTo: 20},
+		{Status: "up", TimeFrom: 20, TimeTo: 30},
+	}, states)
+
+	stop := errors.New("stop")
+	n := 0
+	err = client.Summary.EachOutageState(SummaryOutageRequest{Id: 12345}, func(s SummaryOutageState) error {
+		n++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, n)
+
+	assert.Error(t, client.Summary.EachOutageState(SummaryOutageRequest{}, func(SummaryOutageState) error { return nil }))
+}
+
+func TestSummaryServiceEachPerformance(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.performance/12345", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "day", r.URL.Query().Get("resolution"))
+		fmt.Fprint(w, `{"summary": {"days": [{"starttime": 1, "avgresponse": 100}, {"starttime": 2, "avgresponse": 120}]}}`)
+	})
+
+	var days []SummaryPerformanceSummary
+	err := client.Summary.EachPerformance(SummaryPerformanceRequest{Id: 12345, Resolution: "day"}, func(s SummaryPerformanceSummary) error {
+		days = append(days, s)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []SummaryPerformanceSummary{{StartTime: 1, AvgResponse: 100}, {StartTime: 2, AvgResponse: 120}}, days)
+}
+
+func TestResultsServiceEach(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"activeprobes": [1], "results": [{"probeid": 1, "time": 3}, {"probeid": 1, "time": 2}]}`)
+		case "2":
+			fmt.Fprint(w, `{"results": [{"probeid": 1, "time": 1}], "activeprobes": [1]}`)
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	})
+
+	var times []int
+	err := client.Results.Each(ResultsRequest{Id: 12345, Limit: 2}, func(r Result) error {
+		times = append(times, r.Time)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 2, 1}, times)
+}
+
+func TestStreamErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"statuscode": 404, "statusdesc": "Not Found", "errormessage": "Check not found"}}`)
+	})
+	mux.HandleFunc("/summary.outage/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"summary": {"states": null}}`)
+	})
+	mux.HandleFunc("/summary.outage/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"summary": {"states": "none"}}`)
+	})
+	mux.HandleFunc("/summary.outage/4", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"summary": {}}`)
+	})
+
+	none := func(SummaryOutageState) error {
+		t.Error("unexpected state")
+		return nil
+	}
+	err := client.Summary.EachOutageState(SummaryOutageRequest{Id: 1}, none)
+	assert.True(t, IsNotFound(err))
+	assert.NoError(t, client.Summary.EachOutageState(SummaryOutageRequest{Id: 2}, none))
+	assert.Error(t, client.Summary.EachOutageState(SummaryOutageRequest{Id: 3}, none))
+	assert.NoError(t, client.Summary.EachOutageState(SummaryOutageRequest{Id: 4}, none))
+}
@@ -0,0 +1,63 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// UnknownFieldError is returned with StrictDecoding when a response holds a
+// field the response types of this package don't represent, which usually
+// means the API changed and the field is silently dropped otherwise.
+type UnknownFieldError struct {
+	Method string
+	Path   string
+	// Field is the unknown field as reported by encoding/json, quoted.
+	Field string
+	Err   error
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("%s %s: response has unknown field %s", e.Method, e.Path, e.Field)
+}
+
+// Unwrap returns the decoding error.
+func (e *UnknownFieldError) Unwrap() error {
+	return e.Err
+}
+
+// decodeStrict decodes a response like decodeResponse, failing on unknown
+// fields.  Types with their own UnmarshalJSON are decoded leniently, as
+// encoding/json does not pass the option down.
+func decodeStrict(req *http.Request, r *http.Response, v interface{}) error {
+	if v == nil {
+		return fmt.Errorf("nil interface provided to decodeResponse")
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	err := d.Decode(v)
+	if err != nil && strings.HasPrefix(err.Error(), "json: unknown field ") {
+		return &UnknownFieldError{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Field:  strings.TrimPrefix(err.Error(), "json: unknown field "),
+			Err:    err,
+		}
+	}
+	return err
+}
+
+// logDecodeError reports a response which could not be decoded to the logger.
+func (pc *Client) logDecodeError(req *http.Request, resp *http.Response, err error) {
+	l := RequestLog{
+		Method:     req.Method,
+		URL:        pc.redactURL(req.URL),
+		Attempt:    1,
+		StatusCode: resp.StatusCode,
+		RequestID:  Diagnostics(resp).RequestID,
+		Err:        err,
+	}
+	pc.logger.LogRequest(l)
+}
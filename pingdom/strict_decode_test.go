@@ -0,0 +1,57 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var logs []RequestLog
+	client.strictDecoding = true
+	client.logger = RequestLoggerFunc(func(l RequestLog) {
+		logs = append(logs, l)
+	})
+
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"probes": [{"id": 1, "name": "Stockholm", "newfield": true}]}`)
+	})
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 1, "name": "Example", "type": {"http": {"url": "/"}}}}`)
+	})
+
+	_, err := client.Probes.List(nil)
+	var ufe *UnknownFieldError
+	if assert.True(t, errors.As(err, &ufe)) {
+		assert.Equal(t, "GET", ufe.Method)
+		assert.Equal(t, "/probes", ufe.Path)
+		assert.Equal(t, `"newfield"`, ufe.Field)
+		assert.EqualError(t, err, `GET /probes: response has unknown field "newfield"`)
+	}
+	if assert.Len(t, logs, 2) {
+		assert.Equal(t, err, logs[1].Err)
+	}
+
+	check, err := client.Checks.Read(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Example", check.Name)
+}
+
+func TestStrictDecodingDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"probes": [{"id": 1, "name": "Stockholm", "newfield": true}]}`)
+	})
+
+	probes, err := client.Probes.List(nil)
+	assert.NoError(t, err)
+	assert.Len(t, probes, 1)
+}
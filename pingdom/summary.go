@@ -0,0 +1,203 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// SummaryService provides an interface to the Pingdom summary reports of
+// uptime checks.
+type SummaryService struct {
+	client *Client
+}
+
+// SummaryAverageRequest is the API request to Pingdom for a summary average.
+type SummaryAverageRequest struct {
+	Id   int
+	From int64
+	To   int64
+	// Probes limits the average to the given probes.
+	Probes        []int
+	IncludeUptime bool
+	// ByCountry and ByProbe break the average down, only one of them can be
+	// set.
+	ByCountry bool
+	ByProbe   bool
+}
+
+// Valid determines whether a SummaryAverageRequest contains valid fields for the Pingdom API.
+func (sar SummaryAverageRequest) Valid() error {
+	if sar.Id == 0 {
+		return ErrMissingId
+	}
+
+	if sar.From != 0 && sar.To != 0 && sar.From > sar.To {
+		return ErrBadTimeRange
+	}
+
+	if sar.ByCountry && sar.ByProbe {
+		return fmt.Errorf("`ByCountry` and `ByProbe` must not be declared at the same time")
+	}
+	return nil
+}
+
+// GetParams returns the query params for a Pingdom SummaryAverageRequest.
+func (sar SummaryAverageRequest) GetParams() (params url.Values) {
+	params = url.Values{}
+
+	if sar.From != 0 {
+		params.Set("from", strconv.FormatInt(sar.From, 10))
+	}
+
+	if sar.To != 0 {
+		params.Set("to", strconv.FormatInt(sar.To, 10))
+	}
+
+	if len(sar.Probes) > 0 {
+		params.Set("probes", intListToCDString(sar.Probes))
+	}
+
+	if sar.IncludeUptime {
+		params.Set("includeuptime", "true")
+	}
+
+	if sar.ByCountry {
+		params.Set("bycountry", "true")
+	}
+
+	if sar.ByProbe {
+		params.Set("byprobe", "true")
+	}
+
+	return
+}
+
+// SummaryProbesRequest is the API request to Pingdom for the probes which
+// tested a check.
+type SummaryProbesRequest struct {
+	Id   int
+	From int64
+	To   int64
+}
+
+// Valid determines whether a SummaryProbesRequest contains valid fields for the Pingdom API.
+func (spr SummaryProbesRequest) Valid() error {
+	if spr.Id == 0 {
+		return ErrMissingId
+	}
+
+	if spr.From == 0 {
+		return fmt.Errorf("Invalid value for `From`.  Must contain a Unix timestamp")
+	}
+
+	if spr.To != 0 && spr.From > spr.To {
+		return ErrBadTimeRange
+	}
+	return nil
+}
+
+// GetParams returns the query params for a Pingdom SummaryProbesRequest.
+func (spr SummaryProbesRequest) GetParams() (params url.Values) {
+	params = url.Values{}
+	params.Set("from", strconv.FormatInt(spr.From, 10))
+
+	if spr.To != 0 {
+		params.Set("to", strconv.FormatInt(spr.To, 10))
+	}
+
+	return
+}
+
+// Average returns the average response time of a check and, optionally, its
+// uptime over a time window.
+func (ss *SummaryService) Average(request SummaryAverageRequest) (*SummaryAverageResponse, error) {
+	return ss.AverageWithContext(context.Background(), request)
+}
+
+// AverageWithContext is like Average, the request is bound to ctx so it can
+// be canceled or given a deadline.
+func (ss *SummaryService) AverageWithContext(ctx context.Context, request SummaryAverageRequest) (*SummaryAverageResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	m := &SummaryAverageResponse{}
+	if err := ss.get(ctx, "/summary.average/", request.Id, request.GetParams(), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Outage returns the list of states (up, down, unknown) a check had over a
+// time window.
+func (ss *SummaryService) Outage(request SummaryOutageRequest) (*SummaryOutageResponse, error) {
+	return ss.OutageWithContext(context.Background(), request)
+}
+
+// OutageWithContext is like Outage, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ss *SummaryService) OutageWithContext(ctx context.Context, request SummaryOutageRequest) (*SummaryOutageResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	m := &SummaryOutageResponse{}
+	if err := ss.get(ctx, "/summary.outage/", request.Id, request.GetParams(), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Performance returns the response time and uptime of a check in hour, day
+// or week buckets.
+func (ss *SummaryService) Performance(request SummaryPerformanceRequest) (*SummaryPerformanceResponse, error) {
+	return ss.PerformanceWithContext(context.Background(), request)
+}
+
+// PerformanceWithContext is like Performance, the request is bound to ctx so
+// it can be canceled or given a deadline.
+func (ss *SummaryService) PerformanceWithContext(ctx context.Context, request SummaryPerformanceRequest) (*SummaryPerformanceResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	m := &SummaryPerformanceResponse{}
+	if err := ss.get(ctx, "/summary.performance/", request.Id, request.GetParams(), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Probes returns the ids of the probes which tested a check over a time
+// window.
+func (ss *SummaryService) Probes(request SummaryProbesRequest) ([]int, error) {
+	return ss.ProbesWithContext(context.Background(), request)
+}
+
+// ProbesWithContext is like Probes, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ss *SummaryService) ProbesWithContext(ctx context.Context, request SummaryProbesRequest) ([]int, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	m := &listSummaryProbesJSONResponse{}
+	if err := ss.get(ctx, "/summary.probes/", request.Id, request.GetParams(), m); err != nil {
+		return nil, err
+	}
+	return m.Probes, nil
+}
+
+// get reads the summary of a check from the given endpoint into v.
+func (ss *SummaryService) get(ctx context.Context, endpoint string, id int, params url.Values, v interface{}) error {
+	req, err := ss.client.NewRequestWithValues("GET", endpoint+strconv.Itoa(id), params)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	_, err = ss.client.Do(req, v)
+	return err
+}
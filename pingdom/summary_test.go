@@ -0,0 +1,134 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryAverageRequestValid(t *testing.T) {
+	assert.NoError(t, SummaryAverageRequest{Id: 1, ByProbe: true}.Valid())
+	assert.Equal(t, ErrMissingId, SummaryAverageRequest{}.Valid())
+	assert.Equal(t, ErrBadTimeRange, SummaryAverageRequest{Id: 1, From: 20, To: 10}.Valid())
+	assert.Error(t, SummaryAverageRequest{Id: 1, ByCountry: true, ByProbe: true}.Valid())
+}
+
+func TestSummaryAverageRequestGetParams(t *testing.T) {
+	want := url.Values{
+		"from":          {"10"},
+		"to":            {"20"},
+		"probes":        {"42,43"},
+		"includeuptime": {"true"},
+		"bycountry":     {"true"},
+	}
+	params := SummaryAverageRequest{
+		Id:            1,
+		From:          10,
+		To:            20,
+		Probes:        []int{42, 43},
+		IncludeUptime: true,
+		ByCountry:     true,
+	}.GetParams()
+	assert.Equal(t, want, params)
+}
+
+func TestSummaryProbesRequestValid(t *testing.T) {
+	assert.NoError(t, SummaryProbesRequest{Id: 1, From: 10}.Valid())
+	assert.Equal(t, ErrMissingId, SummaryProbesRequest{From: 10}.Valid())
+	assert.Error(t, SummaryProbesRequest{Id: 1}.Valid())
+	assert.Equal(t, ErrBadTimeRange, SummaryProbesRequest{Id: 1, From: 20, To: 10}.Valid())
+}
+
+func TestSummaryServiceAverage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.average/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "true", r.URL.Query().Get("includeuptime"))
+		fmt.Fprint(w, `{
+			"summary": {
+				"responsetime": {"from": 1600000000, "to": 1600003600, "avgresponse": 245},
+				"status": {"totalup": 3500, "totaldown": 100, "totalunknown": 0}
+			}
+		}`)
+	})
+
+	summary, err := client.Summary.Average(SummaryAverageRequest{Id: 12345, IncludeUptime: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &SummaryAverageResponse{Summary: SummaryAverage{
+		ResponseTime: SummaryResponseTime{From: 1600000000, To: 1600003600, AvgResponse: 245},
+		Status:       &SummaryStatus{TotalUp: 3500, TotalDown: 100},
+	}}, summary)
+}
+
+func TestSummaryServiceAverageByCountry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.average/12345", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("bycountry"))
+		fmt.Fprint(w, `{
+			"summary": {
+				"responsetime": {
+					"from": 1600000000,
+					"to": 1600003600,
+					"avgresponse": [
+						{"countryiso": "US", "avgresponse": 210},
+						{"countryiso": "DE", "avgresponse": 320}
+					]
+				}
+			}
+		}`)
+	})
+
+	summary, err := client.Summary.Average(SummaryAverageRequest{Id: 12345, ByCountry: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []SummaryCountryAverage{
+		{CountryISO: "US", AvgResponse: 210},
+		{CountryISO: "DE", AvgResponse: 320},
+	}, summary.Summary.ResponseTime.ByCountry)
+	assert.Nil(t, summary.Summary.ResponseTime.ByProbe)
+	assert.Nil(t, summary.Summary.Status)
+}
+
+func TestSummaryServiceAverageByProbe(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.average/12345", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("byprobe"))
+		fmt.Fprint(w, `{
+			"summary": {
+				"responsetime": {
+					"from": 1600000000,
+					"to": 1600003600,
+					"avgresponse": [{"probeid": 32, "avgresponse": 198, "n": 60}]
+				}
+			}
+		}`)
+	})
+
+	summary, err := client.Summary.Average(SummaryAverageRequest{Id: 12345, ByProbe: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []SummaryProbeAverage{{ProbeID: 32, AvgResponse: 198, N: 60}}, summary.Summary.ResponseTime.ByProbe)
+	assert.Nil(t, summary.Summary.ResponseTime.ByCountry)
+}
+
+func TestSummaryServiceProbes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.probes/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "1600000000", r.URL.Query().Get("from"))
+		fmt.Fprint(w, `{"probes": [32, 45, 61]}`)
+	})
+
+	probes, err := client.Summary.Probes(SummaryProbesRequest{Id: 12345, From: 1600000000})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{32, 45, 61}, probes)
+}
@@ -0,0 +1,62 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// JoinTags renders tags as the comma separated list sent in the Tags field of
+// checks.  Pingdom splits the list on commas, so tags containing one, or
+// empty tags, are rejected instead of being silently split or dropped.
+func JoinTags(tags ...string) (string, error) {
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return "", fmt.Errorf("Invalid value for `Tags`.  Must not contain empty tags")
+		}
+		if strings.Contains(tag, ",") {
+			return "", fmt.Errorf("Invalid value %q for `Tags`.  Must not contain commas", tag)
+		}
+	}
+	return strings.Join(tags, ","), nil
+}
+
+// SplitTags parses the comma separated Tags field of a check.  Empty entries
+// are dropped, so an empty string has no tags.
+func SplitTags(tags string) []string {
+	var list []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			list = append(list, tag)
+		}
+	}
+	return list
+}
+
+// TagNames returns the names of the tags of the check.
+func (c CheckResponse) TagNames() []string {
+	var names []string
+	for _, tag := range c.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// ListByTags returns the checks having any of the given tags, with their
+// tags included.
+func (cs *CheckService) ListByTags(tags ...string) ([]CheckResponse, error) {
+	return cs.ListByTagsWithContext(context.Background(), tags...)
+}
+
+// ListByTagsWithContext is like ListByTags, the request is bound to ctx so it
+// can be canceled or given a deadline.
+func (cs *CheckService) ListByTagsWithContext(ctx context.Context, tags ...string) ([]CheckResponse, error) {
+	filter, err := JoinTags(tags...)
+	if err != nil {
+		return nil, err
+	}
+	if filter == "" {
+		return nil, fmt.Errorf("Invalid value for `Tags`.  Must contain at least one tag")
+	}
+	return cs.ListWithContext(ctx, map[string]string{"tags": filter, "include_tags": "true"})
+}
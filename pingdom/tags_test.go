@@ -0,0 +1,57 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinTags(t *testing.T) {
+	tags, err := JoinTags("prod", "web")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod,web", tags)
+
+	tags, err = JoinTags()
+	assert.NoError(t, err)
+	assert.Equal(t, "", tags)
+
+	_, err = JoinTags("prod", "a,b")
+	assert.Error(t, err)
+	_, err = JoinTags("prod", " ")
+	assert.Error(t, err)
+}
+
+func TestSplitTags(t *testing.T) {
+	assert.Equal(t, []string{"prod", "web"}, SplitTags("prod, web,"))
+	assert.Nil(t, SplitTags(""))
+}
+
+func TestCheckResponseTagNames(t *testing.T) {
+	c := CheckResponse{Tags: []CheckResponseTag{{Name: "prod", Type: "a"}, {Name: "web", Type: "u"}}}
+	assert.Equal(t, []string{"prod", "web"}, c.TagNames())
+	assert.Nil(t, CheckResponse{}.TagNames())
+}
+
+func TestCheckServiceListByTags(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "prod,web", r.URL.Query().Get("tags"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+		fmt.Fprint(w, `{"checks": [{"id": 1, "name": "web", "tags": [{"name": "prod", "type": "a", "count": 2}]}]}`)
+	})
+
+	checks, err := client.Checks.ListByTags("prod", "web")
+	assert.NoError(t, err)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, []string{"prod"}, checks[0].TagNames())
+
+	_, err = client.Checks.ListByTags()
+	assert.Error(t, err)
+	_, err = client.Checks.ListByTags("a,b")
+	assert.Error(t, err)
+}
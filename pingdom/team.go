@@ -0,0 +1,186 @@
+package pingdom
+
+import (
+	"context"
+	"sort"
+	"strconv"
+)
+
+// TeamService provides an interface to Pingdom alerting teams.
+type TeamService struct {
+	client *Client
+}
+
+// List returns the alerting teams of the account.
+func (ts *TeamService) List() ([]TeamResponse, error) {
+	return ts.ListWithContext(context.Background())
+}
+
+// ListWithContext is like List, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ts *TeamService) ListWithContext(ctx context.Context) ([]TeamResponse, error) {
+	req, err := ts.client.NewRequest("GET", "/alerting/teams", nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &listTeamsJSONResponse{}
+	_, err = ts.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Teams, err
+}
+
+// Read returns the team for the given ID along with its members.
+func (ts *TeamService) Read(id int) (*TeamResponse, error) {
+	return ts.ReadWithContext(context.Background(), id)
+}
+
+// ReadWithContext is like Read, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ts *TeamService) ReadWithContext(ctx context.Context, id int) (*TeamResponse, error) {
+	req, err := ts.client.NewRequest("GET", "/alerting/teams/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &teamDetailsJSONResponse{}
+	_, err = ts.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Team, err
+}
+
+// Create a new team.  Only the ID of the returned team is set.
+func (ts *TeamService) Create(team *Team) (*TeamResponse, error) {
+	return ts.CreateWithContext(context.Background(), team)
+}
+
+// CreateWithContext is like Create, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ts *TeamService) CreateWithContext(ctx context.Context, team *Team) (*TeamResponse, error) {
+	if err := team.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := ts.client.NewJSONRequest("POST", "/alerting/teams", team)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &teamDetailsJSONResponse{}
+	_, err = ts.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Team, err
+}
+
+// Update will update the team represented by the given ID with the values
+// in the given team.  The member list replaces the current members.
+func (ts *TeamService) Update(id int, team *Team) (*PingdomResponse, error) {
+	return ts.UpdateWithContext(context.Background(), id, team)
+}
+
+// UpdateWithContext is like Update, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ts *TeamService) UpdateWithContext(ctx context.Context, id int, team *Team) (*PingdomResponse, error) {
+	if err := team.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := ts.client.NewJSONRequest("PUT", "/alerting/teams/"+strconv.Itoa(id), team)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &PingdomResponse{}
+	_, err = ts.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, err
+}
+
+// Delete will delete the team for the given ID.  Its members are kept.
+func (ts *TeamService) Delete(id int) (*PingdomResponse, error) {
+	return ts.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ts *TeamService) DeleteWithContext(ctx context.Context, id int) (*PingdomResponse, error) {
+	req, err := ts.client.NewRequest("DELETE", "/alerting/teams/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &PingdomResponse{}
+	_, err = ts.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, err
+}
+
+// AddMembers adds the contacts with the given ids to the team.  The API only
+// replaces the member list, so the team is read and updated; concurrent
+// changes to the team may be lost.
+func (ts *TeamService) AddMembers(id int, contactIDs ...int) (*PingdomResponse, error) {
+	return ts.AddMembersWithContext(context.Background(), id, contactIDs...)
+}
+
+// AddMembersWithContext is like AddMembers, the requests are bound to ctx so
+// they can be canceled or given a deadline.
+func (ts *TeamService) AddMembersWithContext(ctx context.Context, id int, contactIDs ...int) (*PingdomResponse, error) {
+	return ts.updateMembers(ctx, id, func(members map[int]bool) {
+		for _, c := range contactIDs {
+			members[c] = true
+		}
+	})
+}
+
+// RemoveMembers removes the contacts with the given ids from the team, see
+// AddMembers.
+func (ts *TeamService) RemoveMembers(id int, contactIDs ...int) (*PingdomResponse, error) {
+	return ts.RemoveMembersWithContext(context.Background(), id, contactIDs...)
+}
+
+// RemoveMembersWithContext is like RemoveMembers, the requests are bound to
+// ctx so they can be canceled or given a deadline.
+func (ts *TeamService) RemoveMembersWithContext(ctx context.Context, id int, contactIDs ...int) (*PingdomResponse, error) {
+	return ts.updateMembers(ctx, id, func(members map[int]bool) {
+		for _, c := range contactIDs {
+			delete(members, c)
+		}
+	})
+}
+
+// updateMembers reads the team, applies change to its member ids and writes
+// it back with its members sorted by id.
+func (ts *TeamService) updateMembers(ctx context.Context, id int, change func(members map[int]bool)) (*PingdomResponse, error) {
+	t, err := ts.ReadWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members := map[int]bool{}
+	for _, m := range t.Members {
+		members[m.ID] = true
+	}
+	change(members)
+
+	team := &Team{Name: t.Name, MemberIDs: []int{}}
+	for m := range members {
+		team.MemberIDs = append(team.MemberIDs, m)
+	}
+	sort.Ints(team.MemberIDs)
+	return ts.UpdateWithContext(ctx, id, team)
+}
@@ -0,0 +1,141 @@
+package pingdom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"teams": [
+				{
+					"id": 7,
+					"name": "Ops",
+					"members": [
+						{"id": 1, "name": "John Doe", "type": "user"}
+					]
+				}
+			]
+		}`)
+	})
+
+	want := []TeamResponse{
+		{
+			ID:   7,
+			Name: "Ops",
+			Members: []TeamMemberResponse{
+				{ID: 1, Name: "John Doe", Type: "user"},
+			},
+		},
+	}
+
+	teams, err := client.Teams.List()
+	assert.NoError(t, err)
+	assert.Equal(t, want, teams)
+}
+
+func TestTeamServiceUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/teams/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name": "Ops", "member_ids": [1, 2]}`, string(body))
+		fmt.Fprint(w, `{"message": "Modification of team was successful!"}`)
+	})
+
+	msg, err := client.Teams.Update(7, &Team{Name: "Ops", MemberIDs: []int{1, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Modification of team was successful!"}, msg)
+
+	_, err = client.Teams.Update(7, &Team{})
+	assert.Error(t, err)
+}
+
+func TestTeamServiceRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/teams/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"team": {"id": 7, "name": "Ops", "members": [{"id": 1, "name": "John Doe", "type": "user"}]}}`)
+	})
+
+	team, err := client.Teams.Read(7)
+	assert.NoError(t, err)
+	assert.Equal(t, &TeamResponse{
+		ID:      7,
+		Name:    "Ops",
+		Members: []TeamMemberResponse{{ID: 1, Name: "John Doe", Type: "user"}},
+	}, team)
+}
+
+func TestTeamServiceCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name": "Ops", "member_ids": [1]}`, string(body))
+		fmt.Fprint(w, `{"team": {"id": 8}}`)
+	})
+
+	team, err := client.Teams.Create(&Team{Name: "Ops", MemberIDs: []int{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, &TeamResponse{ID: 8}, team)
+
+	_, err = client.Teams.Create(&Team{})
+	assert.Error(t, err)
+}
+
+func TestTeamServiceDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/teams/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"message": "Deletion of team was successful!"}`)
+	})
+
+	msg, err := client.Teams.Delete(7)
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Deletion of team was successful!"}, msg)
+}
+
+func TestTeamServiceMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var updates []string
+	mux.HandleFunc("/alerting/teams/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			body, _ := ioutil.ReadAll(r.Body)
+			updates = append(updates, string(body))
+			fmt.Fprint(w, `{"message": "Modification of team was successful!"}`)
+			return
+		}
+		fmt.Fprint(w, `{"team": {"id": 7, "name": "Ops", "members": [{"id": 3}, {"id": 1}]}}`)
+	})
+
+	_, err := client.Teams.AddMembers(7, 2, 1)
+	assert.NoError(t, err)
+	_, err = client.Teams.RemoveMembers(7, 1, 3)
+	assert.NoError(t, err)
+
+	if assert.Len(t, updates, 2) {
+		assert.JSONEq(t, `{"name": "Ops", "member_ids": [1, 2, 3]}`, updates[0])
+		assert.JSONEq(t, `{"name": "Ops", "member_ids": []}`, updates[1])
+	}
+}
@@ -0,0 +1,18 @@
+package pingdom
+
+import "fmt"
+
+// Team represents a Pingdom alerting team.
+type Team struct {
+	Name      string `json:"name"`
+	MemberIDs []int  `json:"member_ids"`
+}
+
+// Valid determines whether the Team contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (t *Team) Valid() error {
+	if t.Name == "" {
+		return fmt.Errorf("Invalid value for `Name`.  Must contain non-empty string")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package pingdom
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Throttle configures adaptive throttling of outgoing requests.  When the
+// remaining request quota reported by Pingdom drops to a threshold, the
+// client spaces out its requests so that the remaining quota lasts until the
+// limit resets, and pauses entirely once it is exhausted.
+type Throttle struct {
+	// ShortThreshold and LongThreshold are the remaining quota of the
+	// short (hourly) and long (monthly) limits below which requests are
+	// slowed down.  Zero disables throttling for that limit.
+	ShortThreshold int
+	LongThreshold  int
+	// MaxDelay caps the delay added to a single request, zero means no cap.
+	MaxDelay time.Duration
+}
+
+// rateLimitState is the last rate limit reported by Pingdom.
+type rateLimitState struct {
+	mu       sync.Mutex
+	limit    *RateLimit
+	recorded time.Time
+}
+
+func (s *rateLimitState) record(rl RateLimit, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = &rl
+	s.recorded = at
+}
+
+func (s *rateLimitState) get() (*RateLimit, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limit == nil {
+		return nil, time.Time{}
+	}
+	rl := *s.limit
+	return &rl, s.recorded
+}
+
+// delay returns for how long the next request should wait given the last
+// known rate limit.
+func (t *Throttle) delay(rl *RateLimit, recorded, now time.Time) time.Duration {
+	if t == nil || rl == nil {
+		return 0
+	}
+	elapsed := now.Sub(recorded)
+
+	d := throttleDelay(rl.ShortRemaining, rl.ShortReset-elapsed, t.ShortThreshold)
+	if long := throttleDelay(rl.LongRemaining, rl.LongReset-elapsed, t.LongThreshold); long > d {
+		d = long
+	}
+	if t.MaxDelay > 0 && d > t.MaxDelay {
+		d = t.MaxDelay
+	}
+	return d
+}
+
+func throttleDelay(remaining int, untilReset time.Duration, threshold int) time.Duration {
+	if threshold <= 0 || remaining > threshold || untilReset <= 0 {
+		return 0
+	}
+	if remaining <= 0 {
+		return untilReset
+	}
+	// Spread the remaining requests evenly until the reset.
+	return untilReset / time.Duration(remaining+1)
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
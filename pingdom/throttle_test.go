@@ -0,0 +1,82 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottleDelay(t *testing.T) {
+	now := time.Unix(1000, 0)
+	throttle := &Throttle{ShortThreshold: 10, LongThreshold: 100}
+
+	tests := []struct {
+		name     string
+		limit    *RateLimit
+		recorded time.Time
+		want     time.Duration
+	}{
+		{"unknown limit", nil, now, 0},
+		{"above thresholds", &RateLimit{ShortRemaining: 50, ShortReset: time.Hour, LongRemaining: 5000, LongReset: time.Hour}, now, 0},
+		{"short below threshold", &RateLimit{ShortRemaining: 9, ShortReset: 100 * time.Second, LongRemaining: 5000}, now, 10 * time.Second},
+		{"short exhausted", &RateLimit{ShortRemaining: 0, ShortReset: 100 * time.Second, LongRemaining: 5000}, now, 100 * time.Second},
+		{"reset partly elapsed", &RateLimit{ShortRemaining: 0, ShortReset: 100 * time.Second, LongRemaining: 5000}, now.Add(-40 * time.Second), 60 * time.Second},
+		{"reset passed", &RateLimit{ShortRemaining: 0, ShortReset: 100 * time.Second, LongRemaining: 5000}, now.Add(-200 * time.Second), 0},
+		{"long dominates", &RateLimit{ShortRemaining: 9, ShortReset: 100 * time.Second, LongRemaining: 1, LongReset: 1000 * time.Second}, now, 500 * time.Second},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, throttle.delay(tc.limit, tc.recorded, now), tc.name)
+	}
+
+	capped := &Throttle{ShortThreshold: 10, MaxDelay: time.Second}
+	assert.Equal(t, time.Second, capped.delay(&RateLimit{ShortReset: time.Hour}, now, now))
+
+	var disabled *Throttle
+	assert.Equal(t, time.Duration(0), disabled.delay(&RateLimit{ShortReset: time.Hour}, now, now))
+}
+
+func TestClientThrottle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Req-Limit-Short", "Remaining: 1 Time until reset: 3600")
+		w.Header().Set("Req-Limit-Long", "Remaining: 71994 Time until reset: 2591989")
+		fmt.Fprint(w, `{"checks": []}`)
+	})
+
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "my_api_key",
+		Throttle: &Throttle{ShortThreshold: 5},
+	})
+	assert.NoError(t, err)
+	c.BaseURL, _ = url.Parse(server.URL)
+
+	var delays []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	_, err = c.Checks.List()
+	assert.NoError(t, err)
+	_, err = c.Checks.List()
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(delays))
+	assert.Equal(t, time.Duration(0), delays[0])
+	assert.InDelta(t, float64(30*time.Minute), float64(delays[1]), float64(time.Second))
+}
+
+func TestSleepContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, sleepContext(ctx, time.Hour))
+	assert.NoError(t, sleepContext(context.Background(), 0))
+}
@@ -0,0 +1,92 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TracerouteService runs traceroutes from the Pingdom probes.
+type TracerouteService struct {
+	client *Client
+}
+
+// TracerouteHop is a line of a traceroute.  Host and IP are empty when the
+// hop did not answer.
+type TracerouteHop struct {
+	Number int
+	Host   string
+	IP     string
+	RTTs   []time.Duration
+}
+
+// Hops parses the traceroute output into its hops.  Lines which are not hops,
+// such as the header, are skipped.
+func (tr *TracerouteResponse) Hops() []TracerouteHop {
+	var hops []TracerouteHop
+	for _, line := range strings.Split(tr.Result, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+
+		hop := TracerouteHop{Number: n}
+		for i := 1; i < len(fields); i++ {
+			f := fields[i]
+			switch {
+			case f == "*":
+			case f == "ms":
+				if ms, err := strconv.ParseFloat(fields[i-1], 64); err == nil {
+					hop.RTTs = append(hop.RTTs, time.Duration(ms*float64(time.Millisecond)))
+				}
+			case strings.HasPrefix(f, "(") && strings.HasSuffix(f, ")"):
+				hop.IP = strings.Trim(f, "()")
+			case hop.Host == "" && i+1 < len(fields) && fields[i+1] != "ms":
+				hop.Host = f
+			}
+		}
+		if hop.Host != "" && hop.IP == "" {
+			hop.IP = hop.Host
+		}
+		hops = append(hops, hop)
+	}
+	return hops
+}
+
+// Run runs a traceroute to host from the given probe.  Pingdom picks a probe
+// when probeID is 0.
+func (ts *TracerouteService) Run(host string, probeID int) (*TracerouteResponse, error) {
+	return ts.RunWithContext(context.Background(), host, probeID)
+}
+
+// RunWithContext is like Run, the request is bound to ctx so it can be
+// canceled or given a deadline.
+func (ts *TracerouteService) RunWithContext(ctx context.Context, host string, probeID int) (*TracerouteResponse, error) {
+	if host == "" {
+		return nil, fmt.Errorf("Invalid value for `host`.  Must contain non-empty string")
+	}
+
+	params := map[string]string{"host": host}
+	if probeID != 0 {
+		params["probeid"] = strconv.Itoa(probeID)
+	}
+
+	req, err := ts.client.NewRequest("GET", "/traceroute", params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	m := &tracerouteJSONResponse{}
+	_, err = ts.client.Do(req, m)
+	if err != nil {
+		return nil, err
+	}
+	return &m.Traceroute, nil
+}
@@ -0,0 +1,56 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracerouteResponseHops(t *testing.T) {
+	tr := &TracerouteResponse{Result: `traceroute to example.com (93.184.216.34), 30 hops max, 60 byte packets
+ 1  gw.probe.net (10.0.0.1)  0.456 ms  0.312 ms
+ 2  10.0.0.2  1.5 ms * 1.25 ms
+ 3  * * *
+`}
+
+	want := []TracerouteHop{
+		{Number: 1, Host: "gw.probe.net", IP: "10.0.0.1", RTTs: []time.Duration{456 * time.Microsecond, 312 * time.Microsecond}},
+		{Number: 2, Host: "10.0.0.2", IP: "10.0.0.2", RTTs: []time.Duration{1500 * time.Microsecond, 1250 * time.Microsecond}},
+		{Number: 3},
+	}
+	assert.Equal(t, want, tr.Hops())
+}
+
+func TestTracerouteServiceRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/traceroute", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "example.com", r.URL.Query().Get("host"))
+		assert.Equal(t, "33", r.URL.Query().Get("probeid"))
+		fmt.Fprint(w, `{
+			"traceroute": {
+				"result": "traceroute to example.com",
+				"probeid": 33,
+				"probedescription": "Amsterdam 2, Netherlands"
+			}
+		}`)
+	})
+
+	want := &TracerouteResponse{
+		Result:           "traceroute to example.com",
+		ProbeID:          33,
+		ProbeDescription: "Amsterdam 2, Netherlands",
+	}
+
+	traceroute, err := client.Traceroute.Run("example.com", 33)
+	assert.NoError(t, err)
+	assert.Equal(t, want, traceroute)
+
+	_, err = client.Traceroute.Run("", 33)
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+package pingdom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationErrors holds every violation found by the Valid method of a
+// check, in the order of the fields.  Valid returns the error itself when
+// there is a single violation.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// add records err unless it is nil.
+func (e *ValidationErrors) add(err error) {
+	if err != nil {
+		*e = append(*e, err)
+	}
+}
+
+// err returns nil without violations, the violation when there is one and
+// the ValidationErrors otherwise.
+func (e ValidationErrors) err() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return e[0]
+	}
+	return e
+}
+
+// Region is a region of the Pingdom probes, used to restrict the probes of a
+// check with a probe filter.
+type Region string
+
+// Regions of the Pingdom probes.
+const (
+	RegionNorthAmerica Region = "NA"
+	RegionEurope       Region = "EU"
+	RegionAsiaPacific  Region = "APAC"
+	RegionLatinAmerica Region = "LATAM"
+)
+
+// AllowedRegions returns the regions of the Pingdom probes.
+func AllowedRegions() []Region {
+	return []Region{RegionNorthAmerica, RegionEurope, RegionAsiaPacific, RegionLatinAmerica}
+}
+
+// Valid determines whether the region is one of AllowedRegions.
+func (r Region) Valid() error {
+	for _, allowed := range AllowedRegions() {
+		if r == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid value %q for `Region`.  Must be one of NA, EU, APAC or LATAM", string(r))
+}
+
+// ProbeFilter returns the probe filter restricting a check to the region,
+// e.g. "region: EU".
+func (r Region) ProbeFilter() string {
+	return "region: " + string(r)
+}
+
+// AllowedResolutions returns the allowed values of the Resolution of checks,
+// the minutes between two tests.
+func AllowedResolutions() []int {
+	return []int{1, 5, 15, 30, 60}
+}
+
+// AllowedSeverities returns the allowed values of the Severity of the
+// notification targets of contacts.
+func AllowedSeverities() []string {
+	return []string{SeverityHigh, SeverityLow}
+}
+
+// validCheck validates the parameters shared by every check type.
+func validCheck(name, hostname string, resolution int, probeFilters string) ValidationErrors {
+	var errs ValidationErrors
+	if name == "" {
+		errs.add(fmt.Errorf("Invalid value for `Name`.  Must contain non-empty string"))
+	}
+
+	if hostname == "" {
+		errs.add(fmt.Errorf("Invalid value for `Hostname`.  Must contain non-empty string"))
+	}
+
+	errs.add(validResolution(resolution))
+	errs.add(validProbeFilters(probeFilters))
+	return errs
+}
+
+func validResolution(resolution int) error {
+	for _, allowed := range AllowedResolutions() {
+		if resolution == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %v for `Resolution`, allowed values are [1,5,15,30,60]", resolution)
+}
+
+// ParseProbeFilter returns the region of a probe filter like "region: EU",
+// the reverse of Region.ProbeFilter.  The region is not validated, ok is
+// false when filter does not restrict the region.
+func ParseProbeFilter(filter string) (region Region, ok bool) {
+	parts := strings.SplitN(filter, ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) != "region" {
+		return "", false
+	}
+	return Region(strings.TrimSpace(parts[1])), true
+}
+
+// validProbeFilters checks the regions of comma separated probe filters.
+func validProbeFilters(filters string) error {
+	for _, f := range SplitTags(filters) {
+		region, ok := ParseProbeFilter(f)
+		if !ok {
+			return fmt.Errorf("Invalid value %q for `ProbeFilters`.  Must be like \"region: EU\"", f)
+		}
+		if err := region.Valid(); err != nil {
+			return fmt.Errorf("Invalid value %q for `ProbeFilters`.  Region must be one of NA, EU, APAC or LATAM", f)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package pingdom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion(t *testing.T) {
+	for _, r := range AllowedRegions() {
+		assert.NoError(t, r.Valid())
+	}
+	assert.Error(t, Region("us-east").Valid())
+	assert.Equal(t, "region: APAC", RegionAsiaPacific.ProbeFilter())
+
+	r, ok := ParseProbeFilter(RegionAsiaPacific.ProbeFilter())
+	assert.True(t, ok)
+	assert.Equal(t, RegionAsiaPacific, r)
+	_, ok = ParseProbeFilter("country: US")
+	assert.False(t, ok)
+}
+
+func TestAllowedValues(t *testing.T) {
+	for _, r := range AllowedResolutions() {
+		assert.NoError(t, (&PingCheck{Name: "ping", Hostname: "example.com", Resolution: r}).Valid())
+	}
+	assert.Equal(t, []string{"HIGH", "LOW"}, AllowedSeverities())
+}
+
+func TestCheckValidReportsAllViolations(t *testing.T) {
+	err := (&HttpCheck{Resolution: 2, ShouldContain: "a", ShouldNotContain: "b"}).Valid()
+	errs, ok := err.(ValidationErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 4)
+	assert.EqualError(t, err, "Invalid value for `Name`.  Must contain non-empty string; "+
+		"Invalid value for `Hostname`.  Must contain non-empty string; "+
+		"invalid value 2 for `Resolution`, allowed values are [1,5,15,30,60]; "+
+		"`ShouldContain` and `ShouldNotContain` must not be declared at the same time")
+
+	// A single violation is returned as is.
+	err = (&TCPCheck{Name: "tcp", Hostname: "example.com", Resolution: 5}).Valid()
+	assert.EqualError(t, err, "Invalid value for `Port`.  Must contain an integer >= 1")
+	_, ok = err.(ValidationErrors)
+	assert.False(t, ok)
+
+	errs = (&DNSCheck{Name: "dns", Hostname: "example.com", Resolution: 5}).Valid().(ValidationErrors)
+	assert.Len(t, errs, 2)
+}
+
+func TestCheckValidProbeFilters(t *testing.T) {
+	check := &PingCheck{Name: "ping", Hostname: "example.com", Resolution: 5}
+	for _, filters := range []string{"region: EU", "region:NA, region: LATAM", ""} {
+		check.ProbeFilters = filters
+		assert.NoError(t, check.Valid(), filters)
+	}
+	for _, filters := range []string{"region: us-east", "country: SE", "EU"} {
+		check.ProbeFilters = filters
+		assert.Error(t, check.Valid(), filters)
+	}
+}
@@ -0,0 +1,66 @@
+package pingdom
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// VerifyResult holds the diagnostics gathered by Client.Verify.
+type VerifyResult struct {
+	// AuthOK is true when Pingdom accepted the API token.
+	AuthOK bool
+	// APIVersion is the version of the API the client talks to.
+	APIVersion string
+	// RateLimit is the remaining quota, nil when Pingdom did not report it.
+	RateLimit *RateLimit
+	// RequestID identifies the verification request on the Pingdom side.
+	RequestID string
+}
+
+// Verify performs a cheap authenticated call to confirm that the API is
+// reachable and the credentials are valid.  It is intended for startup
+// checks in services embedding the client.  When Pingdom rejects the
+// credentials the result is returned with AuthOK set to false along with
+// the error.
+func (pc *Client) Verify(ctx context.Context) (*VerifyResult, error) {
+	req, err := pc.NewRequest("GET", "/credits", nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := pc.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &VerifyResult{
+		APIVersion: pc.apiVersion(),
+		RequestID:  Diagnostics(resp).RequestID,
+	}
+	if rl, ok := parseRateLimit(resp.Header); ok {
+		result.RateLimit = &rl
+	}
+
+	if err := validateResponse(resp); err != nil {
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return result, err
+		}
+		return nil, err
+	}
+
+	result.AuthOK = true
+	return result, nil
+}
+
+// apiVersion returns the version segment of the base URL, e.g. "3.1".
+func (pc *Client) apiVersion() string {
+	p := strings.TrimSuffix(pc.BaseURL.Path, "/")
+	if p == "" {
+		return ""
+	}
+	return path.Base(p)
+}
@@ -0,0 +1,56 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientVerify(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.BaseURL, _ = url.Parse(server.URL + "/api/3.1")
+	mux.HandleFunc("/api/3.1/credits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "Bearer my_api_key", r.Header.Get("Authorization"))
+		w.Header().Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+		w.Header().Set("Req-Limit-Long", "Remaining: 71994 Time until reset: 2591989")
+		w.Header().Set("X-Request-Id", "abc-123")
+		fmt.Fprint(w, `{"credits":{"checklimit":10}}`)
+	})
+
+	result, err := client.Verify(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &VerifyResult{
+		AuthOK:     true,
+		APIVersion: "3.1",
+		RequestID:  "abc-123",
+		RateLimit: &RateLimit{
+			ShortRemaining: 394,
+			ShortReset:     3589 * time.Second,
+			LongRemaining:  71994,
+			LongReset:      2591989 * time.Second,
+		},
+	}, result)
+}
+
+func TestClientVerifyUnauthorized(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/credits", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":{"statuscode":401,"statusdesc":"Unauthorized","errormessage":"Invalid token"}}`)
+	})
+
+	result, err := client.Verify(context.Background())
+	assert.Error(t, err)
+	assert.NotNil(t, result)
+	assert.False(t, result.AuthOK)
+}
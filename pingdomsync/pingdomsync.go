@@ -0,0 +1,529 @@
+// Package pingdomsync reconciles the checks of a Pingdom account with a
+// desired set of checks, e.g. kept in version control.
+//
+// Checks are matched by name.  Plan compares the desired checks with the
+// live ones and returns the creates, updates and deletes needed, which can
+// be reviewed as a dry run before calling Apply:
+//
+//	s := pingdomsync.New(client, "managed-by-git")
+//	plan, err := s.Plan(ctx, desired)
+//	fmt.Print(plan)
+//	err = s.Apply(ctx, plan)
+//
+// With a managed tag, the tag is added to the desired checks and only the
+// live checks carrying it are considered, so checks created by hand are left
+// alone and the checks no longer desired are deleted.  Without one, every
+// check of the account is compared but none is ever deleted.
+//
+// A run, a plan and its apply, lists the checks of the account once and
+// reads each check at most once.
+package pingdomsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// ActionType is the kind of change of an Action.
+type ActionType string
+
+// Action types.
+const (
+	Create ActionType = "create"
+	Update ActionType = "update"
+	Delete ActionType = "delete"
+)
+
+// Action is a change needed to reach the desired state.
+type Action struct {
+	Type ActionType
+	Name string
+	// ID is the id of the live check, zero when creating until the plan is
+	// applied.
+	ID int
+	// Check is the desired check, with the managed tags added to its
+	// params, nil when deleting.
+	Check pingdom.CheckConfig
+	// Changes lists the params which differ, when updating.
+	Changes []Change
+}
+
+// Change is a param of a check which differs between the live check and
+// the desired one.
+type Change struct {
+	Param string
+	Old   string
+	New   string
+	// Sensitive is set for the params holding credentials, whose values
+	// are not rendered.
+	Sensitive bool
+}
+
+// String renders the change as e.g. `resolution: "5" -> "1"`.
+func (c Change) String() string {
+	if c.Sensitive {
+		return c.Param + ": (sensitive value)"
+	}
+	return fmt.Sprintf("%s: %q -> %q", c.Param, c.Old, c.New)
+}
+
+// String renders the action as e.g. "~ update web (id 12): resolution, tags".
+func (a Action) String() string {
+	switch a.Type {
+	case Create:
+		return fmt.Sprintf("+ create %s (%s)", a.Name, a.Check.Kind())
+	case Update:
+		params := make([]string, len(a.Changes))
+		for i, c := range a.Changes {
+			params[i] = c.Param
+		}
+		return fmt.Sprintf("~ update %s (id %d): %s", a.Name, a.ID, strings.Join(params, ", "))
+	}
+	return fmt.Sprintf("- delete %s (id %d)", a.Name, a.ID)
+}
+
+// Plan is the list of actions reconciling the account with the desired
+// checks.  Deletes come first, then creates and updates, each ordered by
+// name.
+type Plan struct {
+	Actions []Action
+
+	// reads are the reads made while planning, reused by Apply.
+	reads *reads
+}
+
+// Empty returns true when the account is in the desired state.
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// PlanSummary counts the actions of a plan by type.
+type PlanSummary struct {
+	Create int
+	Update int
+	Delete int
+}
+
+// String renders the summary as e.g. "1 to create, 2 to update, 0 to
+// delete".
+func (s PlanSummary) String() string {
+	return fmt.Sprintf("%d to create, %d to update, %d to delete", s.Create, s.Update, s.Delete)
+}
+
+// Summary counts the actions of the plan.
+func (p *Plan) Summary() PlanSummary {
+	var s PlanSummary
+	for _, a := range p.Actions {
+		switch a.Type {
+		case Create:
+			s.Create++
+		case Update:
+			s.Update++
+		case Delete:
+			s.Delete++
+		}
+	}
+	return s
+}
+
+// String renders the plan for review: an action per line, followed by the
+// changes of the updates, and a summary line.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "no changes\n"
+	}
+	var b strings.Builder
+	for _, a := range p.Actions {
+		b.WriteString(a.String())
+		b.WriteString("\n")
+		for _, c := range a.Changes {
+			b.WriteString("    ")
+			b.WriteString(c.String())
+			b.WriteString("\n")
+		}
+	}
+	fmt.Fprintf(&b, "Plan: %s.\n", p.Summary())
+	return b.String()
+}
+
+// Syncer plans and applies the changes reconciling an account with desired
+// checks.
+type Syncer struct {
+	client *pingdom.Client
+	// ManagedTag marks the checks owned by the Syncer, see the package
+	// documentation.
+	ManagedTag string
+}
+
+// New returns a Syncer using client.  managedTag may be empty.
+func New(client *pingdom.Client, managedTag string) *Syncer {
+	return &Syncer{client: client, ManagedTag: managedTag}
+}
+
+// serverDefaults are the params Pingdom fills in when they are not sent,
+// they are only compared when the desired check sets them.
+var serverDefaults = map[string]bool{
+	"sendnotificationwhendown": true,
+	"responsetime_threshold":   true,
+	"verify_certificate":       true,
+	"ssl_down_days_before":     true,
+}
+
+// listParams are the params whose values are unordered lists.
+var listParams = map[string]bool{
+	"tags":           true,
+	"userids":        true,
+	"teamids":        true,
+	"integrationids": true,
+	"probe_filters":  true,
+}
+
+// Plan compares the desired checks with the live ones and returns the
+// actions reconciling them.  Desired checks must have unique names, as must
+// the live checks considered.  A check whose type changed is deleted and
+// created again.  Pingdom doesn't return the details of DNS, SMTP, POP3 and
+// IMAP checks, see pingdom.CheckResponse.Config, so only the params shared
+// by every check type are compared for them.
+func (s *Syncer) Plan(ctx context.Context, desired []pingdom.CheckConfig) (*Plan, error) {
+	want := map[string]pingdom.CheckConfig{}
+	for _, c := range desired {
+		if err := c.Valid(); err != nil {
+			return nil, fmt.Errorf("check %q: %v", c.CheckName(), err)
+		}
+		if _, ok := want[c.CheckName()]; ok {
+			return nil, fmt.Errorf("check %q is desired more than once", c.CheckName())
+		}
+		tagged, err := s.tag(c)
+		if err != nil {
+			return nil, err
+		}
+		want[c.CheckName()] = tagged
+	}
+
+	r := &reads{client: s.client, details: map[int]*pingdom.CheckResponse{}}
+	live, err := s.listLive(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletes, creates, updates []Action
+	for name, c := range live {
+		if _, ok := want[name]; !ok && s.ManagedTag != "" {
+			deletes = append(deletes, Action{Type: Delete, Name: name, ID: c.ID})
+		}
+	}
+	for name, c := range want {
+		l, ok := live[name]
+		if !ok {
+			creates = append(creates, Action{Type: Create, Name: name, Check: c})
+			continue
+		}
+
+		details, err := r.read(ctx, l.ID)
+		if err != nil {
+			return nil, err
+		}
+		if details.Type.Name != c.Kind() {
+			deletes = append(deletes, Action{Type: Delete, Name: name, ID: l.ID})
+			creates = append(creates, Action{Type: Create, Name: name, Check: c})
+			continue
+		}
+		var changed []Change
+		if current, err := details.Config(); err == nil {
+			changed = changes(c, current)
+		} else {
+			changed = sharedChanges(c, details)
+		}
+		if len(changed) > 0 {
+			updates = append(updates, Action{Type: Update, Name: name, ID: l.ID, Check: c, Changes: changed})
+		}
+	}
+
+	plan := &Plan{reads: r}
+	for _, actions := range [][]Action{deletes, creates, updates} {
+		sort.Slice(actions, func(i, j int) bool { return actions[i].Name < actions[j].Name })
+		plan.Actions = append(plan.Actions, actions...)
+	}
+	return plan, nil
+}
+
+// Apply makes the changes of the plan in order and stops at the first
+// error, the actions before it were applied.  The ids of the created checks
+// are set in the plan.
+//
+// With a managed tag on the client, the client reads each check before
+// changing it to make sure it carries the tag.  Apply skips that read when
+// the check was read while planning, and checks the tag itself.
+func (s *Syncer) Apply(ctx context.Context, plan *Plan) error {
+	r := plan.reads
+	if r == nil {
+		r = &reads{client: s.client, details: map[int]*pingdom.CheckResponse{}}
+	}
+	for i := range plan.Actions {
+		a := &plan.Actions[i]
+		var err error
+		switch a.Type {
+		case Create:
+			var created *pingdom.CheckResponse
+			created, err = s.client.Checks.CreateWithContext(ctx, a.Check)
+			if err == nil {
+				a.ID = created.ID
+			}
+		case Update:
+			_, err = s.client.Checks.UpdateWithContext(s.guarded(ctx, r, a.ID), a.ID, a.Check)
+		case Delete:
+			_, err = s.client.Checks.DeleteWithContext(s.guarded(ctx, r, a.ID), a.ID)
+		}
+		r.invalidate(a.ID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", a, err)
+		}
+	}
+	return nil
+}
+
+// Sync plans the changes and applies them unless dryRun is set.  The plan
+// is returned in both cases.
+func (s *Syncer) Sync(ctx context.Context, desired []pingdom.CheckConfig, dryRun bool) (*Plan, error) {
+	plan, err := s.Plan(ctx, desired)
+	if err != nil || dryRun {
+		return plan, err
+	}
+	return plan, s.Apply(ctx, plan)
+}
+
+// guarded returns ctx made with pingdom.Force when the check was read during
+// the run and carries the managed tag of the client, so that the client
+// doesn't read it again to check the tag.
+func (s *Syncer) guarded(ctx context.Context, r *reads, id int) context.Context {
+	tag := s.client.ManagedTag()
+	if tag == "" {
+		return ctx
+	}
+	if c, ok := r.cached(id); ok && hasTag(c, tag) {
+		return pingdom.Force(ctx)
+	}
+	return ctx
+}
+
+// listLive returns the live checks considered, by name.
+func (s *Syncer) listLive(ctx context.Context, r *reads) (map[string]pingdom.CheckResponse, error) {
+	checks, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	live := map[string]pingdom.CheckResponse{}
+	for _, c := range checks {
+		if s.ManagedTag != "" && !hasTag(c, s.ManagedTag) {
+			continue
+		}
+		if _, ok := live[c.Name]; ok {
+			return nil, fmt.Errorf("several live checks are named %q", c.Name)
+		}
+		live[c.Name] = c
+	}
+	return live, nil
+}
+
+// taggedCheck is a desired check with the managed tags added to the tags
+// it sends, whatever its type.
+type taggedCheck struct {
+	pingdom.CheckConfig
+	managed []string
+}
+
+// PutParams returns the params of the check with the managed tags added.
+func (c taggedCheck) PutParams() map[string]string {
+	return c.addTags(c.CheckConfig.PutParams())
+}
+
+// PostParams returns the params of the check with the managed tags added.
+func (c taggedCheck) PostParams() map[string]string {
+	return c.addTags(c.CheckConfig.PostParams())
+}
+
+func (c taggedCheck) addTags(params map[string]string) map[string]string {
+	tags := pingdom.SplitTags(params["tags"])
+	have := map[string]bool{}
+	for _, t := range tags {
+		have[t] = true
+	}
+	for _, t := range c.managed {
+		if !have[t] {
+			tags = append(tags, t)
+			have[t] = true
+		}
+	}
+	params["tags"] = strings.Join(tags, ",")
+	return params
+}
+
+// tag returns the check with the managed tags of the Syncer and of the
+// client added, as the client adds its own when saving the check.  The
+// desired check itself is left unchanged.
+func (s *Syncer) tag(c pingdom.CheckConfig) (pingdom.CheckConfig, error) {
+	var managed []string
+	for _, t := range []string{s.ManagedTag, s.client.ManagedTag()} {
+		if t != "" {
+			managed = append(managed, t)
+		}
+	}
+	if len(managed) == 0 {
+		return c, nil
+	}
+	if _, err := pingdom.JoinTags(managed...); err != nil {
+		return nil, err
+	}
+	return taggedCheck{CheckConfig: c, managed: managed}, nil
+}
+
+// changes returns the params of the desired check which differ from the
+// current one, sorted.
+func changes(desired, current pingdom.CheckConfig) []Change {
+	return diffParams(desired.PutParams(), current.PutParams())
+}
+
+// sharedChanges returns the params shared by every check type which differ
+// between the desired check and the live one, sorted.
+func sharedChanges(desired pingdom.CheckConfig, live *pingdom.CheckResponse) []Change {
+	have := map[string]string{
+		"name":          live.Name,
+		"host":          live.Hostname,
+		"resolution":    strconv.Itoa(live.Resolution),
+		"tags":          strings.Join(live.TagNames(), ","),
+		"userids":       joinIDs(live.UserIds),
+		"teamids":       joinIDs(live.TeamIds),
+		"probe_filters": strings.Join(live.ProbeFilters, ","),
+	}
+	want := map[string]string{}
+	for k, v := range desired.PutParams() {
+		if _, ok := have[k]; ok {
+			want[k] = v
+		}
+	}
+	return diffParams(want, have)
+}
+
+// diffParams returns the params which differ between want and have, sorted.
+// Server defaults only present in have are ignored.
+func diffParams(want, have map[string]string) []Change {
+	keys := map[string]bool{}
+	for k := range want {
+		keys[k] = true
+	}
+	for k := range have {
+		if _, ok := want[k]; ok || !serverDefaults[k] {
+			keys[k] = true
+		}
+	}
+
+	var changed []Change
+	for k := range keys {
+		if normalize(k, want[k]) != normalize(k, have[k]) {
+			changed = append(changed, Change{Param: k, Old: have[k], New: want[k], Sensitive: sensitive(k)})
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Param < changed[j].Param })
+	return changed
+}
+
+func joinIDs(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ",")
+}
+
+// sensitive reports whether a param holds credentials: those of HTTP and
+// SMTP checks and the values of the custom headers of HTTP checks.
+func sensitive(param string) bool {
+	return param == "auth" || strings.HasPrefix(param, "requestheader")
+}
+
+// normalize sorts the items of list params so that their order is ignored.
+func normalize(key, value string) string {
+	if !listParams[key] {
+		return value
+	}
+	items := pingdom.SplitTags(value)
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+// reads memoizes the reads of a run, a plan and its apply: the checks of the
+// account are listed once and each check is read at most once.  Changing a
+// check invalidates what was read about it.
+type reads struct {
+	client  *pingdom.Client
+	checks  []pingdom.CheckResponse
+	listed  bool
+	details map[int]*pingdom.CheckResponse
+}
+
+// list returns every check of the account, with their tags.
+func (r *reads) list(ctx context.Context) ([]pingdom.CheckResponse, error) {
+	if !r.listed {
+		checks, err := r.client.Checks.ListAllWithContext(ctx, map[string]string{"include_tags": "true"})
+		if err != nil {
+			return nil, err
+		}
+		r.checks, r.listed = checks, true
+	}
+	return r.checks, nil
+}
+
+// read returns the details of a check.
+func (r *reads) read(ctx context.Context, id int) (*pingdom.CheckResponse, error) {
+	if c, ok := r.details[id]; ok {
+		return c, nil
+	}
+	c, err := r.client.Checks.ReadWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.details[id] = c
+	return c, nil
+}
+
+// cached returns what was read about a check, without making requests.
+func (r *reads) cached(id int) (pingdom.CheckResponse, bool) {
+	if c, ok := r.details[id]; ok {
+		return *c, true
+	}
+	for _, c := range r.checks {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return pingdom.CheckResponse{}, false
+}
+
+// invalidate forgets what was read about a check after it was changed.  The
+// checks are listed again when needed, what was listed about the others is
+// still used by cached.
+func (r *reads) invalidate(id int) {
+	delete(r.details, id)
+	kept := r.checks[:0]
+	for _, c := range r.checks {
+		if c.ID != id {
+			kept = append(kept, c)
+		}
+	}
+	r.checks, r.listed = kept, false
+}
+
+func hasTag(c pingdom.CheckResponse, tag string) bool {
+	for _, t := range c.TagNames() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
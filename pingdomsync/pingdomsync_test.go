@@ -0,0 +1,254 @@
+package pingdomsync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/russellcardullo/go-pingdom/pingdomtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncerPlanAndApply(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	tags := func(names ...string) []pingdom.CheckResponseTag {
+		var tags []pingdom.CheckResponseTag
+		for _, n := range names {
+			tags = append(tags, pingdom.CheckResponseTag{Name: n})
+		}
+		return tags
+	}
+	server.AddCheck(pingdom.CheckResponse{ID: 1, Name: "manual", Hostname: "example.com", Resolution: 5, Type: pingdom.CheckResponseType{Name: "ping"}})
+	server.AddCheck(pingdom.CheckResponse{ID: 2, Name: "old", Hostname: "old.example.com", Resolution: 5, Tags: tags("git"), Type: pingdom.CheckResponseType{Name: "ping"}})
+	server.AddCheck(pingdom.CheckResponse{ID: 3, Name: "api", Hostname: "api.example.com", Resolution: 5, Tags: tags("git", "prod"), Type: pingdom.CheckResponseType{
+		Name: "http",
+		HTTP: &pingdom.CheckResponseHTTPDetails{Url: "/health", VerifyCertificate: true},
+	}})
+	server.AddCheck(pingdom.CheckResponse{ID: 4, Name: "gw", Hostname: "gw.example.com", Resolution: 1, Tags: tags("git"), Type: pingdom.CheckResponseType{Name: "ping"}})
+
+	desired := []pingdom.CheckConfig{
+		&pingdom.HttpCheck{Name: "api", Hostname: "api.example.com", Resolution: 1, Url: "/health", Tags: "prod"},
+		&pingdom.PingCheck{Name: "gw", Hostname: "gw.example.com", Resolution: 1},
+		&pingdom.TCPCheck{Name: "smtp", Hostname: "mail.example.com", Resolution: 15, Port: 25},
+	}
+
+	s := New(client, "git")
+	plan, err := s.Plan(context.Background(), desired)
+	assert.NoError(t, err)
+	assert.Equal(t, "- delete old (id 2)\n"+
+		"+ create smtp (tcp)\n"+
+		"~ update api (id 3): resolution\n"+
+		"    resolution: \"5\" -> \"1\"\n"+
+		"Plan: 1 to create, 1 to update, 1 to delete.\n", plan.String())
+	assert.Equal(t, PlanSummary{Create: 1, Update: 1, Delete: 1}, plan.Summary())
+	assert.Equal(t, []Change{{Param: "resolution", Old: "5", New: "1"}}, plan.Actions[2].Changes)
+	assert.Equal(t, "prod", desired[0].(*pingdom.HttpCheck).Tags, "desired checks are not modified")
+
+	assert.NoError(t, s.Apply(context.Background(), plan))
+	assert.NotZero(t, plan.Actions[1].ID)
+
+	names := map[string]pingdom.CheckResponse{}
+	for _, c := range server.Checks() {
+		names[c.Name] = c
+	}
+	assert.Len(t, names, 4)
+	assert.Contains(t, names, "manual")
+	assert.NotContains(t, names, "old")
+	assert.Equal(t, 1, names["api"].Resolution)
+	assert.Equal(t, []string{"git"}, names["smtp"].TagNames())
+
+	plan, err = s.Plan(context.Background(), desired)
+	assert.NoError(t, err)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, "no changes\n", plan.String())
+}
+
+func TestSyncerWithoutManagedTag(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	server.AddCheck(pingdom.CheckResponse{Name: "manual", Hostname: "example.com", Resolution: 5, Type: pingdom.CheckResponseType{Name: "ping"}})
+	server.AddCheck(pingdom.CheckResponse{Name: "gw", Hostname: "gw.example.com", Resolution: 5, Type: pingdom.CheckResponseType{Name: "ping"}})
+
+	desired := []pingdom.CheckConfig{
+		&pingdom.TCPCheck{Name: "gw", Hostname: "gw.example.com", Resolution: 5, Port: 22},
+	}
+	plan, err := New(client, "").Sync(context.Background(), desired, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "- delete gw (id 2)\n+ create gw (tcp)\nPlan: 1 to create, 0 to update, 1 to delete.\n", plan.String())
+	assert.Len(t, server.Checks(), 2, "dry runs change nothing")
+}
+
+func TestSyncerClientManagedTag(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   pingdomtest.Token,
+		BaseURL:    server.URL,
+		ManagedTag: "ci",
+	})
+	assert.NoError(t, err)
+
+	server.AddCheck(pingdom.CheckResponse{Name: "manual", Hostname: "example.com", Resolution: 5, Type: pingdom.CheckResponseType{Name: "ping"}})
+
+	desired := []pingdom.CheckConfig{
+		&pingdom.PingCheck{Name: "gw", Hostname: "gw.example.com", Resolution: 5},
+	}
+	s := New(client, "git")
+	plan, err := s.Sync(context.Background(), desired, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "+ create gw (ping)\nPlan: 1 to create, 0 to update, 0 to delete.\n", plan.String())
+
+	plan, err = s.Plan(context.Background(), desired)
+	assert.NoError(t, err)
+	assert.True(t, plan.Empty(), "the tag of the client is expected")
+
+	desired = append(desired, &pingdom.PingCheck{Name: "manual", Hostname: "example.com", Resolution: 1})
+	plan, err = New(client, "").Sync(context.Background(), desired, false)
+	assert.True(t, errors.Is(err, pingdom.ErrUnmanagedCheck))
+	assert.Equal(t, "~ update gw (id 2): tags\n"+
+		"    tags: \"git,ci\" -> \"ci\"\n"+
+		"~ update manual (id 1): resolution, tags\n"+
+		"    resolution: \"5\" -> \"1\"\n"+
+		"    tags: \"\" -> \"ci\"\n"+
+		"Plan: 0 to create, 2 to update, 0 to delete.\n", plan.String())
+}
+
+func TestSyncerTagsEveryCheckType(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	desired := []pingdom.CheckConfig{
+		&pingdom.DNSCheck{Name: "ns", Hostname: "example.com", Resolution: 5, ExpectedIP: "192.0.2.1", NameServer: "ns.example.com", Tags: "dns"},
+		&pingdom.SMTPCheck{Name: "mail", Hostname: "mail.example.com", Resolution: 5},
+		&pingdom.POP3Check{Name: "pop", Hostname: "mail.example.com", Resolution: 5},
+		&pingdom.IMAPCheck{Name: "imap", Hostname: "mail.example.com", Resolution: 5},
+	}
+	plan, err := New(client, "git").Sync(context.Background(), desired, false)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Actions, 4)
+
+	tags := map[string][]string{}
+	for _, c := range server.Checks() {
+		tags[c.Name] = c.TagNames()
+	}
+	assert.Equal(t, map[string][]string{
+		"ns":   {"dns", "git"},
+		"mail": {"git"},
+		"pop":  {"git"},
+		"imap": {"git"},
+	}, tags)
+	assert.Equal(t, "dns", desired[0].(*pingdom.DNSCheck).Tags, "desired checks are not modified")
+}
+
+func TestSyncerPlanErrors(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+	s := New(client, "")
+
+	_, err = s.Plan(context.Background(), []pingdom.CheckConfig{&pingdom.PingCheck{Name: "gw"}})
+	assert.Error(t, err)
+
+	gw := &pingdom.PingCheck{Name: "gw", Hostname: "gw.example.com", Resolution: 5}
+	_, err = s.Plan(context.Background(), []pingdom.CheckConfig{gw, gw})
+	assert.EqualError(t, err, `check "gw" is desired more than once`)
+
+	server.AddCheck(pingdom.CheckResponse{Name: "gw", Type: pingdom.CheckResponseType{Name: "ping"}})
+	server.AddCheck(pingdom.CheckResponse{Name: "gw", Type: pingdom.CheckResponseType{Name: "ping"}})
+	_, err = s.Plan(context.Background(), []pingdom.CheckConfig{gw})
+	assert.EqualError(t, err, `several live checks are named "gw"`)
+}
+
+func TestSyncerComparesSharedParams(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	server.AddCheck(pingdom.CheckResponse{Name: "ns", Hostname: "example.com", Resolution: 5, Type: pingdom.CheckResponseType{Name: "dns"}})
+	ns := &pingdom.DNSCheck{Name: "ns", Hostname: "example.com", Resolution: 5, ExpectedIP: "192.0.2.1", NameServer: "ns.example.com"}
+	s := New(client, "")
+	plan, err := s.Plan(context.Background(), []pingdom.CheckConfig{ns})
+	assert.NoError(t, err)
+	assert.True(t, plan.Empty(), "the details Pingdom doesn't return are not compared")
+
+	ns.Resolution = 15
+	plan, err = s.Plan(context.Background(), []pingdom.CheckConfig{ns})
+	assert.NoError(t, err)
+	assert.Equal(t, "~ update ns (id 1): resolution\n"+
+		"    resolution: \"5\" -> \"15\"\n"+
+		"Plan: 0 to create, 1 to update, 0 to delete.\n", plan.String())
+}
+
+func TestChanges(t *testing.T) {
+	verify := false
+	desired := &pingdom.HttpCheck{Name: "api", Hostname: "example.com", Resolution: 5, Tags: "b,a", UserIds: []int{2, 1}}
+	current := &pingdom.HttpCheck{Name: "api", Hostname: "example.com", Resolution: 5, Tags: "a,b", UserIds: []int{1, 2}, SendNotificationWhenDown: 2, VerifyCertificate: &verify}
+	assert.Empty(t, changes(desired, current))
+
+	desired.SendNotificationWhenDown = 3
+	desired.Url = "/health"
+	desired.Username, desired.Password = "admin", "hunter2"
+	assert.Equal(t, []Change{
+		{Param: "auth", New: "admin:hunter2", Sensitive: true},
+		{Param: "sendnotificationwhendown", Old: "2", New: "3"},
+		{Param: "url", New: "/health"},
+	}, changes(desired, current))
+	assert.Equal(t, "auth: (sensitive value)", changes(desired, current)[0].String())
+}
+
+// countingTransport counts the requests made, by method and path.
+type countingTransport struct {
+	mu    sync.Mutex
+	count map[string]int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.count[req.Method+" "+req.URL.Path]++
+	t.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSyncerMemoizesReads(t *testing.T) {
+	server := pingdomtest.NewServer()
+	defer server.Close()
+	transport := &countingTransport{count: map[string]int{}}
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken:   pingdomtest.Token,
+		BaseURL:    server.URL,
+		ManagedTag: "ci",
+		Transport:  transport,
+	})
+	assert.NoError(t, err)
+
+	tags := []pingdom.CheckResponseTag{{Name: "git"}, {Name: "ci"}}
+	server.AddCheck(pingdom.CheckResponse{ID: 1, Name: "old", Hostname: "old.example.com", Resolution: 5, Tags: tags, Type: pingdom.CheckResponseType{Name: "ping"}})
+	server.AddCheck(pingdom.CheckResponse{ID: 2, Name: "older", Hostname: "old.example.com", Resolution: 5, Tags: tags, Type: pingdom.CheckResponseType{Name: "ping"}})
+	server.AddCheck(pingdom.CheckResponse{ID: 3, Name: "gw", Hostname: "gw.example.com", Resolution: 5, Tags: tags, Type: pingdom.CheckResponseType{Name: "ping"}})
+
+	desired := []pingdom.CheckConfig{&pingdom.PingCheck{Name: "gw", Hostname: "gw.example.com", Resolution: 1}}
+	plan, err := New(client, "git").Sync(context.Background(), desired, false)
+	assert.NoError(t, err)
+	assert.Equal(t, PlanSummary{Update: 1, Delete: 2}, plan.Summary())
+	assert.Equal(t, map[string]int{
+		"GET /checks":      1,
+		"GET /checks/3":    1,
+		"DELETE /checks/1": 1,
+		"DELETE /checks/2": 1,
+		"PUT /checks/3":    1,
+	}, transport.count, "the checks are listed and read once per run")
+}
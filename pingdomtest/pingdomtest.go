@@ -0,0 +1,591 @@
+// Package pingdomtest provides a fake Pingdom API for the tests of programs
+// using the pingdom package.
+//
+// The fake keeps checks, maintenance windows and teams in memory and
+// implements the endpoints of the pingdom package managing them.  The status
+// history of the checks, served as summary outages, is recorded by a
+// Simulator:
+//
+//	server := pingdomtest.NewServer()
+//	defer server.Close()
+//	client, _ := server.NewClient()
+//	check, _ := client.Checks.Create(&pingdom.HttpCheck{Name: "web", Hostname: "example.com", Resolution: 5})
+//
+// It only validates what the real API rejects most commonly, tests should not
+// rely on it to catch invalid requests.
+package pingdomtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Token is the API token the fake expects, NewClient configures it.
+const Token = "pingdomtest-token"
+
+// Server is a fake Pingdom API served over HTTP.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	nextID       int
+	checks       map[int]pingdom.CheckResponse
+	maintenances map[int]pingdom.MaintenanceResponse
+	teams        map[int]pingdom.TeamResponse
+	// states is the status history of the checks, recorded by a Simulator.
+	states map[int][]pingdom.SummaryOutageState
+}
+
+// NewServer starts a fake Pingdom API with empty stores.  It must be closed
+// with Close.
+func NewServer() *Server {
+	s := &Server{
+		nextID:       1,
+		checks:       map[int]pingdom.CheckResponse{},
+		maintenances: map[int]pingdom.MaintenanceResponse{},
+		teams:        map[int]pingdom.TeamResponse{},
+		states:       map[int][]pingdom.SummaryOutageState{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/checks", s.handleChecks)
+	mux.HandleFunc("/checks/", s.handleCheck)
+	mux.HandleFunc("/maintenance", s.handleMaintenances)
+	mux.HandleFunc("/maintenance/", s.handleMaintenance)
+	mux.HandleFunc("/alerting/teams", s.handleTeams)
+	mux.HandleFunc("/alerting/teams/", s.handleTeam)
+	mux.HandleFunc("/summary.outage/", s.handleSummaryOutage)
+	s.Server = httptest.NewServer(authorize(mux))
+	return s
+}
+
+// NewClient returns a pingdom client talking to the fake.
+func (s *Server) NewClient() (*pingdom.Client, error) {
+	return pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken: Token,
+		BaseURL:  s.URL,
+	})
+}
+
+// AddCheck stores a check, e.g. to set up a test, and returns its id.  The id
+// of the check is used when set.
+func (s *Server) AddCheck(check pingdom.CheckResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	check.ID = s.id(check.ID)
+	s.checks[check.ID] = check
+	return check.ID
+}
+
+// Checks returns the stored checks ordered by id.
+func (s *Server) Checks() []pingdom.CheckResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedChecks()
+}
+
+// AddMaintenance stores a maintenance window and returns its id.  The id of
+// the window is used when set.
+func (s *Server) AddMaintenance(maintenance pingdom.MaintenanceResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	maintenance.ID = s.id(maintenance.ID)
+	s.maintenances[maintenance.ID] = maintenance
+	return maintenance.ID
+}
+
+// Maintenances returns the stored maintenance windows ordered by id.
+func (s *Server) Maintenances() []pingdom.MaintenanceResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedMaintenances()
+}
+
+// AddTeam stores a team and returns its id.  The id of the team is used when
+// set.
+func (s *Server) AddTeam(team pingdom.TeamResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	team.ID = s.id(team.ID)
+	s.teams[team.ID] = team
+	return team.ID
+}
+
+// Teams returns the stored teams ordered by id.
+func (s *Server) Teams() []pingdom.TeamResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedTeams()
+}
+
+// id returns id when set, or the next free id.  Callers hold s.mu.
+func (s *Server) id(id int) int {
+	if id == 0 {
+		id = s.nextID
+	}
+	if id >= s.nextID {
+		s.nextID = id + 1
+	}
+	return id
+}
+
+// sortedChecks returns the stored checks ordered by id.  Callers hold s.mu.
+func (s *Server) sortedChecks() []pingdom.CheckResponse {
+	checks := make([]pingdom.CheckResponse, 0, len(s.checks))
+	for _, c := range s.checks {
+		checks = append(checks, c)
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].ID < checks[j].ID })
+	return checks
+}
+
+// sortedMaintenances returns the stored maintenance windows ordered by id.
+// Callers hold s.mu.
+func (s *Server) sortedMaintenances() []pingdom.MaintenanceResponse {
+	maintenances := make([]pingdom.MaintenanceResponse, 0, len(s.maintenances))
+	for _, m := range s.maintenances {
+		maintenances = append(maintenances, m)
+	}
+	sort.Slice(maintenances, func(i, j int) bool { return maintenances[i].ID < maintenances[j].ID })
+	return maintenances
+}
+
+// sortedTeams returns the stored teams ordered by id.  Callers hold s.mu.
+func (s *Server) sortedTeams() []pingdom.TeamResponse {
+	teams := make([]pingdom.TeamResponse, 0, len(s.teams))
+	for _, t := range s.teams {
+		teams = append(teams, t)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+	return teams
+}
+
+func (s *Server) handleChecks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		var tags []string
+		if v := r.Form.Get("tags"); v != "" {
+			tags = strings.Split(v, ",")
+		}
+		checks := []pingdom.CheckResponse{}
+		for _, c := range s.sortedChecks() {
+			if hasAnyTag(c, tags) {
+				checks = append(checks, c)
+			}
+		}
+		total := len(checks)
+		start, end := pageBounds(r.Form, total)
+		checks = checks[start:end]
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"checks": checks,
+			"counts": map[string]int{"total": total, "limited": len(checks), "filtered": total},
+		})
+
+	case http.MethodPost:
+		if r.Form.Get("name") == "" || r.Form.Get("host") == "" {
+			writeError(w, http.StatusBadRequest, "name and host are required")
+			return
+		}
+		c := pingdom.CheckResponse{ID: s.id(0), Created: time.Now().Unix(), Status: "up"}
+		s.applyCheckParams(&c, r.Form)
+		s.checks[c.ID] = c
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"check": map[string]interface{}{"id": c.ID, "name": c.Name},
+		})
+
+	case http.MethodPut:
+		ids := splitIDs(r.Form.Get("checkids"))
+		for _, id := range ids {
+			if c, ok := s.checks[id]; ok {
+				flag := url.Values{"paused": r.Form["paused"]}
+				s.applyCheckParams(&c, flag)
+				s.checks[id] = c
+			}
+		}
+		writeMessage(w, fmt.Sprintf("Modification of %d checks was successful!", len(ids)))
+
+	case http.MethodDelete:
+		for _, id := range splitIDs(r.Form.Get("delcheckids")) {
+			delete(s.checks, id)
+		}
+		writeMessage(w, "Deletion of checks was successful!")
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/checks/"))
+	c, ok := s.checks[id]
+	if err != nil || !ok {
+		writeError(w, http.StatusNotFound, "Check not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"check": c})
+	case http.MethodPut:
+		s.applyCheckParams(&c, r.Form)
+		s.checks[id] = c
+		writeMessage(w, "Modification of check was successful!")
+	case http.MethodDelete:
+		delete(s.checks, id)
+		writeMessage(w, "Deletion of check was successful!")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// applyCheckParams updates a check from the params of a create or update
+// request, leaving the fields without a param untouched.
+func (s *Server) applyCheckParams(c *pingdom.CheckResponse, form url.Values) {
+	str := func(key string, f func(v string)) {
+		if vs, ok := form[key]; ok && len(vs) > 0 {
+			f(vs[0])
+		}
+	}
+	num := func(key string, dst *int) {
+		str(key, func(v string) { *dst, _ = strconv.Atoi(v) })
+	}
+	flag := func(key string, dst *bool) {
+		str(key, func(v string) { *dst, _ = strconv.ParseBool(v) })
+	}
+
+	str("name", func(v string) { c.Name = v })
+	str("host", func(v string) { c.Hostname = v })
+	num("resolution", &c.Resolution)
+	flag("paused", &c.Paused)
+	num("notifyagainevery", &c.NotifyAgainEvery)
+	flag("notifywhenbackup", &c.NotifyWhenBackup)
+	num("sendnotificationwhendown", &c.SendNotificationWhenDown)
+	num("responsetime_threshold", &c.ResponseTimeThreshold)
+	str("severity_level", func(v string) { c.SeverityLevel = v })
+	str("userids", func(v string) { c.UserIds = splitIDs(v) })
+	str("integrationids", func(v string) { c.IntegrationIds = splitIDs(v) })
+	str("probe_filters", func(v string) { c.ProbeFilters = splitList(v) })
+	str("tags", func(v string) {
+		c.Tags = nil
+		for _, tag := range splitList(v) {
+			c.Tags = append(c.Tags, pingdom.CheckResponseTag{Name: tag, Type: "a", Count: 0})
+		}
+	})
+	str("teamids", func(v string) {
+		c.Teams = nil
+		for _, id := range splitIDs(v) {
+			c.Teams = append(c.Teams, pingdom.CheckTeamResponse{ID: id, Name: s.teams[id].Name})
+		}
+	})
+
+	str("type", func(v string) { c.Type = pingdom.CheckResponseType{Name: v} })
+	switch c.Type.Name {
+	case pingdom.CheckKindHTTP:
+		if c.Type.HTTP == nil {
+			c.Type.HTTP = &pingdom.CheckResponseHTTPDetails{}
+		}
+		d := c.Type.HTTP
+		str("url", func(v string) { d.Url = v })
+		flag("encryption", &d.Encryption)
+		num("port", &d.Port)
+		str("shouldcontain", func(v string) { d.ShouldContain = v })
+		str("shouldnotcontain", func(v string) { d.ShouldNotContain = v })
+		str("postdata", func(v string) { d.PostData = v })
+		flag("verify_certificate", &d.VerifyCertificate)
+		num("ssl_down_days_before", &d.SSLDownDaysBefore)
+	case pingdom.CheckKindTCP:
+		if c.Type.TCP == nil {
+			c.Type.TCP = &pingdom.CheckResponseTCPDetails{}
+		}
+		d := c.Type.TCP
+		num("port", &d.Port)
+		str("stringtosend", func(v string) { d.StringToSend = v })
+		str("stringtoexpect", func(v string) { d.StringToExpect = v })
+	}
+}
+
+func hasAnyTag(c pingdom.CheckResponse, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, t := range c.Tags {
+		for _, want := range tags {
+			if t.Name == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Server) handleMaintenances(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		maintenances := s.sortedMaintenances()
+		start, end := pageBounds(r.Form, len(maintenances))
+		writeJSON(w, http.StatusOK, map[string]interface{}{"maintenance": maintenances[start:end]})
+
+	case http.MethodPost:
+		if r.Form.Get("description") == "" || r.Form.Get("from") == "" || r.Form.Get("to") == "" {
+			writeError(w, http.StatusBadRequest, "description, from and to are required")
+			return
+		}
+		m := pingdom.MaintenanceResponse{ID: s.id(0), RecurrenceType: "none"}
+		applyMaintenanceParams(&m, r.Form)
+		s.maintenances[m.ID] = m
+		writeJSON(w, http.StatusOK, map[string]interface{}{"maintenance": m})
+
+	case http.MethodDelete:
+		for _, id := range splitIDs(r.Form.Get("maintenanceids")) {
+			delete(s.maintenances, id)
+		}
+		writeMessage(w, "Maintenance windows successfully deleted")
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleMaintenance(w http.ResponseWriter, r *http.Request) {
+	if strings.TrimPrefix(r.URL.Path, "/maintenance/") == "" {
+		// MaintenanceService.MultiDelete uses a trailing slash.
+		s.handleMaintenances(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/maintenance/"))
+	m, ok := s.maintenances[id]
+	if err != nil || !ok {
+		writeError(w, http.StatusNotFound, "Maintenance window not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"maintenance": m})
+	case http.MethodPut:
+		applyMaintenanceParams(&m, r.Form)
+		s.maintenances[id] = m
+		writeMessage(w, "Maintenance window successfully modified")
+	case http.MethodDelete:
+		delete(s.maintenances, id)
+		writeMessage(w, "Maintenance window successfully deleted")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// applyMaintenanceParams updates a maintenance window from the params of a
+// create or update request.
+func applyMaintenanceParams(m *pingdom.MaintenanceResponse, form url.Values) {
+	if v, ok := form["description"]; ok {
+		m.Description = v[0]
+	}
+	if v, ok := form["from"]; ok {
+		m.From, _ = strconv.ParseInt(v[0], 10, 64)
+	}
+	if v, ok := form["to"]; ok {
+		m.To, _ = strconv.ParseInt(v[0], 10, 64)
+	}
+	if v, ok := form["recurrencetype"]; ok {
+		m.RecurrenceType = v[0]
+	}
+	if v, ok := form["repeatevery"]; ok {
+		m.RepeatEvery, _ = strconv.Atoi(v[0])
+	}
+	if v, ok := form["effectiveto"]; ok {
+		m.EffectiveTo, _ = strconv.ParseInt(v[0], 10, 64)
+	}
+	if v, ok := form["uptimeids"]; ok {
+		m.Checks.Uptime = splitIDs(v[0])
+	}
+	if v, ok := form["tmsids"]; ok {
+		m.Checks.Tms = splitIDs(v[0])
+	}
+}
+
+func (s *Server) handleTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"teams": s.sortedTeams()})
+	case http.MethodPost:
+		var team pingdom.Team
+		if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		t := pingdom.TeamResponse{ID: s.id(0), Name: team.Name, Members: teamMembers(team.MemberIDs)}
+		s.teams[t.ID] = t
+		writeJSON(w, http.StatusOK, map[string]interface{}{"team": pingdom.TeamResponse{ID: t.ID}})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleTeam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/alerting/teams/"))
+	t, ok := s.teams[id]
+	if err != nil || !ok {
+		writeError(w, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"team": t})
+	case http.MethodPut:
+		var team pingdom.Team
+		if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		t.Name = team.Name
+		t.Members = teamMembers(team.MemberIDs)
+		s.teams[id] = t
+		writeMessage(w, "Team successfully modified")
+	case http.MethodDelete:
+		delete(s.teams, id)
+		writeMessage(w, "Team successfully deleted")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleSummaryOutage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/summary.outage/"))
+	if _, ok := s.checks[id]; err != nil || !ok {
+		writeError(w, http.StatusNotFound, "Check not found")
+		return
+	}
+
+	from, _ := strconv.ParseInt(r.Form.Get("from"), 10, 64)
+	to, err := strconv.ParseInt(r.Form.Get("to"), 10, 64)
+	if err != nil {
+		to = math.MaxInt64
+	}
+	states := []pingdom.SummaryOutageState{}
+	for _, st := range s.states[id] {
+		if st.TimeTo > from && st.TimeFrom < to {
+			states = append(states, st)
+		}
+	}
+	if r.Form.Get("order") == "desc" {
+		for i, j := 0, len(states)-1; i < j; i, j = i+1, j-1 {
+			states[i], states[j] = states[j], states[i]
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"summary": map[string]interface{}{"states": states},
+	})
+}
+
+// teamMembers returns the members of a team with the given contact ids.
+func teamMembers(ids []int) []pingdom.TeamMemberResponse {
+	var members []pingdom.TeamMemberResponse
+	for _, id := range ids {
+		members = append(members, pingdom.TeamMemberResponse{ID: id})
+	}
+	return members
+}
+
+// authorize rejects the requests without the fake API token, and parses the
+// form of the others.
+func authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "Invalid API token")
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// pageBounds returns the range of n items selected by the limit and offset
+// params.
+func pageBounds(form url.Values, n int) (int, int) {
+	offset, _ := strconv.Atoi(form.Get("offset"))
+	if offset > n {
+		offset = n
+	}
+	end := n
+	if limit, err := strconv.Atoi(form.Get("limit")); err == nil && offset+limit < n {
+		end = offset + limit
+	}
+	return offset, end
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func splitIDs(v string) []int {
+	var ids []int
+	for _, item := range splitList(v) {
+		if id, err := strconv.Atoi(item); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeMessage(w http.ResponseWriter, msg string) {
+	writeJSON(w, http.StatusOK, map[string]string{"message": msg})
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"statuscode":   status,
+			"statusdesc":   http.StatusText(status),
+			"errormessage": msg,
+		},
+	})
+}
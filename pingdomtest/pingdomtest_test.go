@@ -0,0 +1,127 @@
+package pingdomtest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerChecks(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	teamID := server.AddTeam(pingdom.TeamResponse{Name: "ops"})
+
+	created, err := client.Checks.Create(&pingdom.HttpCheck{
+		Name:       "web",
+		Hostname:   "example.com",
+		Resolution: 5,
+		Url:        "/health",
+		Tags:       "prod,web",
+		TeamIds:    []int{teamID},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "web", created.Name)
+
+	check, err := client.Checks.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", check.Hostname)
+	assert.Equal(t, "http", check.Type.Name)
+	assert.Equal(t, "/health", check.Type.HTTP.Url)
+	assert.Equal(t, []int{teamID}, check.TeamIds)
+	assert.Equal(t, "ops", check.Teams[0].Name)
+
+	_, err = client.Checks.Update(created.ID, &pingdom.HttpCheck{Name: "web", Hostname: "example.org", Resolution: 1, Tags: "prod"})
+	assert.NoError(t, err)
+	assert.Equal(t, "example.org", server.Checks()[0].Hostname)
+	assert.Equal(t, 1, server.Checks()[0].Resolution)
+
+	server.AddCheck(pingdom.CheckResponse{Name: "db", Hostname: "db.example.com"})
+	prod, err := client.Checks.List(map[string]string{"tags": "prod"})
+	assert.NoError(t, err)
+	assert.Len(t, prod, 1)
+
+	page, err := client.Checks.ListWithMeta(map[string]string{"limit": "1", "offset": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "db", page.Checks[0].Name)
+	assert.Equal(t, 2, page.Meta.Total)
+
+	_, err = client.Checks.SetPaused(true, created.ID)
+	assert.NoError(t, err)
+	assert.True(t, server.Checks()[0].Paused)
+
+	_, err = client.Checks.Delete(created.ID)
+	assert.NoError(t, err)
+	_, err = client.Checks.Read(created.ID)
+	assert.True(t, pingdom.IsNotFound(err))
+}
+
+func TestServerMaintenances(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	created, err := client.Maintenances.Create(&pingdom.MaintenanceWindow{
+		Description: "deploy",
+		From:        1600000000,
+		To:          1600003600,
+		UptimeIDs:   "1,2",
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Maintenances.Update(created.ID, &pingdom.MaintenanceWindow{Description: "long deploy", From: 1600000000, To: 1600007200})
+	assert.NoError(t, err)
+
+	maintenance, err := client.Maintenances.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "long deploy", maintenance.Description)
+	assert.Equal(t, int64(1600007200), maintenance.To)
+	assert.Equal(t, []int{1, 2}, maintenance.Checks.Uptime)
+
+	second := server.AddMaintenance(pingdom.MaintenanceResponse{Description: "backup"})
+	_, err = client.Maintenances.MultiDelete(&pingdom.MaintenanceWindowDelete{MaintenanceIDs: "1," + strconv.Itoa(second)})
+	assert.NoError(t, err)
+	assert.Empty(t, server.Maintenances())
+}
+
+func TestServerTeams(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	id := server.AddTeam(pingdom.TeamResponse{Name: "ops"})
+	_, err = client.Teams.Update(id, &pingdom.Team{Name: "sre", MemberIDs: []int{7}})
+	assert.NoError(t, err)
+
+	teams, err := client.Teams.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []pingdom.TeamResponse{{ID: id, Name: "sre", Members: []pingdom.TeamMemberResponse{{ID: 7}}}}, teams)
+
+	created, err := client.Teams.Create(&pingdom.Team{Name: "dev", MemberIDs: []int{8}})
+	assert.NoError(t, err)
+	_, err = client.Teams.AddMembers(created.ID, 9)
+	assert.NoError(t, err)
+	team, err := client.Teams.Read(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []pingdom.TeamMemberResponse{{ID: 8}, {ID: 9}}, team.Members)
+
+	_, err = client.Teams.Delete(id)
+	assert.NoError(t, err)
+	assert.Len(t, server.Teams(), 1)
+}
+
+func TestServerRejectsBadToken(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{APIToken: "wrong", BaseURL: server.URL})
+	assert.NoError(t, err)
+
+	_, err = client.Checks.List()
+	assert.True(t, pingdom.IsUnauthorized(err))
+}
@@ -0,0 +1,195 @@
+package pingdomtest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Simulator evolves the status of the checks of a Server over a virtual
+// clock, so that code watching checks, computing SLOs or routing alerts can
+// be tested against outages, flaps and maintenance without waiting for
+// them:
+//
+//	sim := server.NewSimulator(time.Now())
+//	sim.Outage(id, sim.Now().Add(time.Minute), 10*time.Minute)
+//	sim.Advance(5 * time.Minute)
+//
+// Changes are scheduled, then applied in order as Advance moves the clock
+// past them.  The checks of the Server take the status of the latest change,
+// and the status history from the start of the simulation is served as the
+// summary outage of each check.  Checks are "up" until told otherwise.
+type Simulator struct {
+	server *Server
+
+	mu        sync.Mutex
+	start     time.Time
+	now       time.Time
+	scheduled []statusChange
+	seq       int
+	// status is the status of each check ignoring maintenance, paused is
+	// set while a check is in maintenance.
+	status map[int]string
+	paused map[int]bool
+}
+
+// statusChange is a scheduled change of the status of a check or, with pause
+// set, of its maintenance.  seq keeps the changes due at the same time in
+// the order they were scheduled.
+type statusChange struct {
+	at     time.Time
+	seq    int
+	check  int
+	status string
+	pause  *bool
+}
+
+// NewSimulator starts a simulation of the checks of the server at start.
+// The server should only be driven by one Simulator.
+func (s *Server) NewSimulator(start time.Time) *Simulator {
+	return &Simulator{
+		server: s,
+		start:  start,
+		now:    start,
+		status: map[int]string{},
+		paused: map[int]bool{},
+	}
+}
+
+// Now returns the current virtual time.
+func (sim *Simulator) Now() time.Time {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	return sim.now
+}
+
+// SetStatus schedules the check to take status, e.g. "down", at at.
+func (sim *Simulator) SetStatus(checkID int, at time.Time, status string) {
+	sim.schedule(statusChange{at: at, check: checkID, status: status})
+}
+
+// Outage schedules the check to be down from at for d.
+func (sim *Simulator) Outage(checkID int, at time.Time, d time.Duration) {
+	sim.SetStatus(checkID, at, "down")
+	sim.SetStatus(checkID, at.Add(d), "up")
+}
+
+// Flap schedules count outages of the check lasting period, each followed by
+// period up, starting at at.
+func (sim *Simulator) Flap(checkID int, at time.Time, period time.Duration, count int) {
+	for i := 0; i < count; i++ {
+		sim.Outage(checkID, at.Add(time.Duration(2*i)*period), period)
+	}
+}
+
+// Maintenance adds a maintenance window of d from at covering the check to
+// the server, schedules the check to be paused during it and returns the id
+// of the window.  Outages scheduled during the window show once it ends.
+func (sim *Simulator) Maintenance(checkID int, at time.Time, d time.Duration) int {
+	id := sim.server.AddMaintenance(pingdom.MaintenanceResponse{
+		Description:    "simulated maintenance",
+		From:           at.Unix(),
+		To:             at.Add(d).Unix(),
+		RecurrenceType: "none",
+		Checks:         pingdom.MaintenanceCheckResponse{Uptime: []int{checkID}},
+	})
+	pause, resume := true, false
+	sim.schedule(statusChange{at: at, check: checkID, pause: &pause})
+	sim.schedule(statusChange{at: at.Add(d), check: checkID, pause: &resume})
+	return id
+}
+
+// Advance moves the virtual clock forward by d, applying the changes
+// scheduled until then in order.
+func (sim *Simulator) Advance(d time.Duration) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	sim.now = sim.now.Add(d)
+
+	due := 0
+	for due < len(sim.scheduled) && !sim.scheduled[due].at.After(sim.now) {
+		due++
+	}
+	changes := sim.scheduled[:due]
+	sim.scheduled = sim.scheduled[due:]
+
+	srv := sim.server
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	for _, c := range changes {
+		if c.pause != nil {
+			sim.paused[c.check] = *c.pause
+		} else {
+			sim.status[c.check] = c.status
+		}
+		sim.record(c.check, c.at)
+	}
+	for id := range srv.checks {
+		sim.record(id, sim.now)
+	}
+}
+
+func (sim *Simulator) schedule(c statusChange) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	sim.seq++
+	c.seq = sim.seq
+	sim.scheduled = append(sim.scheduled, c)
+	sort.Slice(sim.scheduled, func(i, j int) bool {
+		a, b := sim.scheduled[i], sim.scheduled[j]
+		if !a.at.Equal(b.at) {
+			return a.at.Before(b.at)
+		}
+		return a.seq < b.seq
+	})
+}
+
+// current returns the status the check shows.  Callers hold sim.mu.
+func (sim *Simulator) current(id int) string {
+	if sim.paused[id] {
+		return "paused"
+	}
+	if status, ok := sim.status[id]; ok {
+		return status
+	}
+	return "up"
+}
+
+// record updates the check to its current status at the time at, extending
+// its history.  Checks which don't exist are skipped.  Callers hold sim.mu
+// and the server lock.
+func (sim *Simulator) record(id int, at time.Time) {
+	srv := sim.server
+	check, ok := srv.checks[id]
+	if !ok {
+		return
+	}
+	status := sim.current(id)
+	check.Status = status
+	check.Paused = sim.paused[id]
+	check.LastTestTime = at.Unix()
+	if status == "down" {
+		check.LastErrorTime = at.Unix()
+	}
+	srv.checks[id] = check
+
+	states := srv.states[id]
+	if len(states) == 0 {
+		from := sim.start.Unix()
+		states = []pingdom.SummaryOutageState{{Status: "up", TimeFrom: from, TimeTo: from}}
+	}
+	last := &states[len(states)-1]
+	if at.Unix() > last.TimeTo {
+		last.TimeTo = at.Unix()
+	}
+	if last.Status != status {
+		if last.TimeFrom == last.TimeTo {
+			last.Status = status
+		} else {
+			states = append(states, pingdom.SummaryOutageState{Status: status, TimeFrom: last.TimeTo, TimeTo: last.TimeTo})
+		}
+	}
+	srv.states[id] = states
+}
@@ -0,0 +1,113 @@
+package pingdomtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatorOutages(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	id := server.AddCheck(pingdom.CheckResponse{Name: "web", Hostname: "example.com", Resolution: 1})
+	start := time.Unix(1600000000, 0)
+	sim := server.NewSimulator(start)
+	sim.Outage(id, start.Add(30*time.Minute), 20*time.Minute)
+
+	sim.Advance(40 * time.Minute)
+	assert.Equal(t, "down", server.Checks()[0].Status)
+	sim.Advance(20 * time.Minute)
+	assert.Equal(t, "up", server.Checks()[0].Status)
+	assert.Equal(t, start.Add(time.Hour), sim.Now())
+
+	summary, err := client.Checks.SummaryOutage(pingdom.SummaryOutageRequest{Id: id, From: start.Unix(), To: sim.Now().Unix()})
+	assert.NoError(t, err)
+	s := start.Unix()
+	assert.Equal(t, []pingdom.SummaryOutageState{
+		{Status: "up", TimeFrom: s, TimeTo: s + 1800},
+		{Status: "down", TimeFrom: s + 1800, TimeTo: s + 3000},
+		{Status: "up", TimeFrom: s + 3000, TimeTo: s + 3600},
+	}, summary.Summary.States)
+
+	alerter, err := pingdom.NewBurnRateAlerter(pingdom.SLO{Target: 99.9, Window: 30 * 24 * time.Hour},
+		pingdom.BurnRateRule{Name: "page", LongWindow: time.Hour, ShortWindow: 30 * time.Minute, Threshold: 14.4})
+	assert.NoError(t, err)
+	events, err := alerter.Poll(client.Checks, id, sim.Now())
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.True(t, events[0].Firing)
+	}
+	sim.Advance(time.Hour)
+	events, err = alerter.Poll(client.Checks, id, sim.Now())
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.False(t, events[0].Firing)
+	}
+}
+
+func TestSimulatorMaintenance(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	id := server.AddCheck(pingdom.CheckResponse{Name: "web", Hostname: "example.com", Resolution: 1})
+	start := time.Unix(1600000000, 0)
+	sim := server.NewSimulator(start)
+	windowID := sim.Maintenance(id, start.Add(10*time.Minute), 30*time.Minute)
+	sim.Outage(id, start.Add(20*time.Minute), 5*time.Minute)
+
+	sim.Advance(20 * time.Minute)
+	assert.True(t, server.Checks()[0].Paused)
+	assert.Equal(t, "paused", server.Checks()[0].Status)
+	sim.Advance(40 * time.Minute)
+	assert.False(t, server.Checks()[0].Paused)
+
+	window, err := client.Maintenances.Read(windowID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{id}, window.Checks.Uptime)
+
+	summary, err := client.Checks.SummaryOutage(pingdom.SummaryOutageRequest{Id: id})
+	assert.NoError(t, err)
+	s := start.Unix()
+	assert.Equal(t, []pingdom.SummaryOutageState{
+		{Status: "up", TimeFrom: s, TimeTo: s + 600},
+		{Status: "paused", TimeFrom: s + 600, TimeTo: s + 2400},
+		{Status: "up", TimeFrom: s + 2400, TimeTo: s + 3600},
+	}, summary.Summary.States, "the outage is hidden by the maintenance")
+}
+
+func TestSimulatorDrivesWatch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	assert.NoError(t, err)
+
+	id := server.AddCheck(pingdom.CheckResponse{Name: "web", Hostname: "example.com", Resolution: 1})
+	sim := server.NewSimulator(time.Unix(1600000000, 0))
+	sim.Flap(id, sim.Now().Add(time.Minute), time.Minute, 1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := client.Checks.Watch(ctx, time.Millisecond)
+
+	// The first poll is the baseline, keep flapping until a change is seen.
+	for i := 0; i < 1000; i++ {
+		sim.Advance(time.Minute)
+		select {
+		case e := <-events:
+			assert.Equal(t, pingdom.CheckStatusChanged, e.Type)
+			assert.Equal(t, id, e.Check.ID)
+			assert.NotEqual(t, e.Previous.Status, e.Check.Status)
+			return
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	t.Fatal("no status change was watched")
+}